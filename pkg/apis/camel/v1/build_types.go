@@ -45,6 +45,8 @@ type BuildSpec struct {
 	// and its phase set to BuildPhaseFailed.
 	// +kubebuilder:validation:Format=duration
 	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// RetryPolicy defines how the Build is retried when it fails, unless the failure is permanent.
+	RetryPolicy *BuildRetryPolicy `json:"retryPolicy,omitempty"`
 	// the maximum amount of parallel running builds started by this operator instance
 	// Deprecated: no longer in use in Camel K 2 - maintained for backward compatibility
 	MaxRunningBuilds int32 `json:"maxRunningBuilds,omitempty"`
@@ -80,6 +82,8 @@ type Task struct {
 	S2i *S2iTask `json:"s2i,omitempty"`
 	// a JibTask, for Jib strategy
 	Jib *JibTask `json:"jib,omitempty"`
+	// an OCILayoutTask, for OCILayout strategy
+	OCILayout *OCILayoutTask `json:"ociLayout,omitempty"`
 }
 
 // BaseTask is a base for the struct hierarchy.
@@ -109,14 +113,25 @@ type BuilderTask struct {
 	Sources []SourceSpec `json:"sources,omitempty"`
 	// the configuration of the project to build on Git
 	Git *GitConfigSpec `json:"git,omitempty"`
+	// the hooks executed along the steps of this task
+	Hooks []BuildHook `json:"hooks,omitempty"`
 }
 
 // GitConfigSpec defines the Git configuration of a project.
 type GitConfigSpec struct {
 	// the URL of the project
 	URL string `json:"url,omitempty"`
-	// the Kubernetes secret where token is stored
+	// the Kubernetes secret where token is stored, or the SSH private key in the `ssh-privatekey` entry, as in
+	// a `kubernetes.io/ssh-auth` Secret, along with the `known_hosts` entry holding the public keys of the Git server
 	Secret string `json:"secret,omitempty"`
+	// the branch to build, the default branch of the project if neither a branch nor a tag is set
+	Branch string `json:"branch,omitempty"`
+	// the tag to build
+	Tag string `json:"tag,omitempty"`
+	// the commit to build, on the given branch if any
+	Commit string `json:"commit,omitempty"`
+	// the directory of the project to build within the repository, the only one checked out
+	Path string `json:"path,omitempty"`
 }
 
 // MavenBuildSpec defines the Maven configuration plus additional repositories to use.
@@ -139,6 +154,8 @@ type PublishTask struct {
 	Image string `json:"image,omitempty"`
 	// where to publish the final image
 	Registry RegistrySpec `json:"registry,omitempty"`
+	// the signature of the final image, for the strategies supporting it
+	Signing *ImageSigningSpec `json:"signing,omitempty"`
 }
 
 // BuildahTask is used to configure Buildah.
@@ -182,6 +199,16 @@ type JibTask struct {
 	PublishTask `json:",inline"`
 }
 
+// OCILayoutTask is used to write the image to an OCI image layout, instead of pushing it to a registry.
+type OCILayoutTask struct {
+	BaseTask    `json:",inline"`
+	PublishTask `json:",inline"`
+	// the PersistentVolumeClaim the image is written to
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// the format of the image
+	Format OCILayoutFormat `json:"format,omitempty"`
+}
+
 // SpectrumTask is used to configure Spectrum.
 type SpectrumTask struct {
 	BaseTask    `json:",inline"`
@@ -222,12 +249,18 @@ type BuildStatus struct {
 	Image string `json:"image,omitempty"`
 	// the digest from image
 	Digest string `json:"digest,omitempty"`
+	// the SHA of the Git commit built, if the project is built from Git
+	GitCommit string `json:"gitCommit,omitempty"`
 	// root image (the first image from which the incremental image has started)
 	RootImage string `json:"rootImage,omitempty"`
 	// the base image used for this build
 	BaseImage string `json:"baseImage,omitempty"`
 	// a list of artifacts contained in the build
 	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// the resolved dependency tree of the build
+	DependencyTree []DependencyTreeNode `json:"dependencyTree,omitempty"`
+	// the provenance attestation of the image built
+	Provenance *Provenance `json:"provenance,omitempty"`
 	// the error description (if any)
 	Error string `json:"error,omitempty"`
 	// the reason of the failure (if any)
@@ -106,6 +106,9 @@ func ConfigurationTasksByName(tasks []Task, name string) *BuildConfiguration {
 		if t.Jib != nil && t.Jib.Name == name {
 			return &t.Jib.Configuration
 		}
+		if t.OCILayout != nil && t.OCILayout.Name == name {
+			return &t.OCILayout.Configuration
+		}
 	}
 	return &BuildConfiguration{}
 }
@@ -34,6 +34,9 @@ const (
 	IntegrationProfileAnnotation = "camel.apache.org/integration-profile.id"
 	// IntegrationProfileNamespaceAnnotation integration profile id annotation label.
 	IntegrationProfileNamespaceAnnotation = "camel.apache.org/integration-profile.namespace"
+	// SuspendedReplicasAnnotation marks a suspended Integration or Pipe, recording the replicas to restore on resume
+	// (an empty value when the replicas were not set).
+	SuspendedReplicasAnnotation = "camel.apache.org/suspended.replicas"
 )
 
 // BuildConfiguration represent the configuration required to build the runtime.
@@ -108,6 +111,18 @@ var BuildOrderStrategies = []BuildOrderStrategy{
 	BuildOrderStrategySequential,
 }
 
+// BuildRetryPolicy defines how the failed builds are retried, with an exponential backoff between the attempts.
+type BuildRetryPolicy struct {
+	// the maximum number of attempts to recover a failed build, 0 to never retry (default 5)
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// the duration to wait before the first attempt, doubled on each following attempt (default 5s)
+	// +kubebuilder:validation:Format=duration
+	MinBackoff *metav1.Duration `json:"minBackoff,omitempty"`
+	// the maximum duration to wait between two attempts (default 1m)
+	// +kubebuilder:validation:Format=duration
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
 // KameletRepositorySpec defines the location of the Kamelet catalog to use.
 type KameletRepositorySpec struct {
 	// the remote repository in the format github:ORG/REPO/PATH_TO_KAMELETS_FOLDER
@@ -140,16 +155,73 @@ type Artifact struct {
 	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
 }
 
+// DependencyTreeNode represents a dependency of the resolved dependency tree of a build.
+// The tree is flattened in depth-first order, the depth of each node identifying its parent.
+type DependencyTreeNode struct {
+	// the Maven coordinates of the dependency, in the form groupId:artifactId:type[:classifier]:version
+	ID string `json:"id"`
+	// the depth of the dependency in the tree, starting at 0 for the direct dependencies
+	Depth int `json:"depth,omitempty"`
+	// the Maven scope of the dependency
+	Scope string `json:"scope,omitempty"`
+	// the version originally required, when it has been overridden by the dependency management (BOM)
+	ManagedFrom string `json:"managedFrom,omitempty"`
+	// the version resolved instead, when the dependency has been omitted for conflict
+	ConflictsWith string `json:"conflictsWith,omitempty"`
+	// whether the version of the dependency has been pinned by the user
+	Pinned bool `json:"pinned,omitempty"`
+}
+
+// Provenance is the attestation of the inputs an image has been built from.
+// The builds from the same inputs, with the same builder image, give the same image.
+type Provenance struct {
+	// the image of the builder
+	BuilderImage string `json:"builderImage,omitempty"`
+	// the runtime version
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+	// the runtime provider
+	RuntimeProvider RuntimeProvider `json:"runtimeProvider,omitempty"`
+	// the image built, addressed by its digest
+	Image string `json:"image,omitempty"`
+	// the image the image has been built on top of
+	BaseImage string `json:"baseImage,omitempty"`
+	// the digest of the image the image has been built on top of, resolved when the image is built
+	BaseImageDigest string `json:"baseImageDigest,omitempty"`
+	// the dependencies of the build
+	Dependencies []string `json:"dependencies,omitempty"`
+	// the SHA of the Git commit built, if the project is built from Git
+	GitCommit string `json:"gitCommit,omitempty"`
+	// the digest of all the inputs of the build, i.e. the runtime, the dependencies, the sources and the Maven configuration
+	InputsDigest string `json:"inputsDigest,omitempty"`
+}
+
 // Failure represent a message specifying the reason and the time of an event failure.
 type Failure struct {
 	// a short text specifying the reason
 	Reason string `json:"reason"`
 	// the time when the failure has happened
 	Time metav1.Time `json:"time"`
+	// the class of the last failure, telling whether it may be recovered by retrying
+	Class FailureClass `json:"class,omitempty"`
 	// the recovery attempted for this failure
 	Recovery FailureRecovery `json:"recovery"`
 }
 
+// FailureClass classifies the failures depending on whether they may be recovered by retrying.
+// +kubebuilder:validation:Enum=Transient;Permanent;Unknown
+type FailureClass string
+
+const (
+	// FailureClassTransient is the class of the failures that may not happen again when retrying,
+	// e.g. a network timeout, a server error of the Maven repository or of the image registry, or an evicted Pod.
+	FailureClassTransient FailureClass = "Transient"
+	// FailureClassPermanent is the class of the failures that happen again when retrying, e.g. a compilation error
+	// or a missing artifact. They are never retried.
+	FailureClassPermanent FailureClass = "Permanent"
+	// FailureClassUnknown is the class of the failures that cannot be classified. They are retried.
+	FailureClassUnknown FailureClass = "Unknown"
+)
+
 // FailureRecovery defines the attempts to recover a failure.
 type FailureRecovery struct {
 	// attempt number
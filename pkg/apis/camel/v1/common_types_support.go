@@ -188,6 +188,15 @@ func GetIntegrationProfileNamespaceAnnotation(obj metav1.Object) string {
 	return GetAnnotation(IntegrationProfileNamespaceAnnotation, obj)
 }
 
+// IsSuspended returns true if the Integration or Pipe has been suspended.
+func IsSuspended(obj metav1.Object) bool {
+	if obj == nil || obj.GetAnnotations() == nil {
+		return false
+	}
+	_, ok := obj.GetAnnotations()[SuspendedReplicasAnnotation]
+	return ok
+}
+
 // GetAnnotation safely get the annotation value.
 func GetAnnotation(name string, obj metav1.Object) string {
 	if obj == nil || obj.GetAnnotations() == nil {
@@ -97,6 +97,8 @@ type IntegrationStatus struct {
 	Image string `json:"image,omitempty"`
 	// the Java jar dependency to execute (if available)
 	Jar string `json:"jar,omitempty"`
+	// the SHA of the Git commit built, if the project is built from Git
+	GitCommit string `json:"gitCommit,omitempty"`
 	// a list of dependencies needed by the application
 	Dependencies []string `json:"dependencies,omitempty"`
 	// the profile needed to run this Integration
@@ -171,6 +173,8 @@ const (
 	IntegrationPhaseError IntegrationPhase = "Error"
 	// IntegrationPhaseUnknown --.
 	IntegrationPhaseUnknown IntegrationPhase = "Unknown"
+	// IntegrationPhaseSuspended if the Integration has been scaled down to zero, or its CronJob suspended.
+	IntegrationPhaseSuspended IntegrationPhase = "Suspended"
 
 	// IntegrationConditionReady --.
 	IntegrationConditionReady IntegrationConditionType = "Ready"
@@ -198,6 +202,8 @@ const (
 	IntegrationConditionProbesAvailable IntegrationConditionType = "ProbesAvailable"
 	// IntegrationConditionTraitInfo --.
 	IntegrationConditionTraitInfo IntegrationConditionType = "TraitInfo"
+	// IntegrationConditionImageSignatureVerified is the condition reporting the verification of the signature of the image.
+	IntegrationConditionImageSignatureVerified IntegrationConditionType = "ImageSignatureVerified"
 
 	// IntegrationConditionKitAvailableReason --.
 	IntegrationConditionKitAvailableReason string = "IntegrationKitAvailable"
@@ -269,6 +275,14 @@ const (
 	IntegrationConditionKameletsNotAvailableReason string = "KameletsNotAvailable"
 	// IntegrationConditionImportingKindAvailableReason used (as false) if we're trying to import an unsupported kind.
 	IntegrationConditionImportingKindAvailableReason string = "ImportingKindAvailable"
+	// IntegrationConditionImageSignatureVerifiedReason used (as true) when the signature of the image is valid.
+	IntegrationConditionImageSignatureVerifiedReason string = "ImageSignatureVerified"
+	// IntegrationConditionImageUnsignedReason used (as false) when the image has no signature.
+	IntegrationConditionImageUnsignedReason string = "ImageUnsigned"
+	// IntegrationConditionImageSignatureInvalidReason used (as false) when the signature of the image is not valid.
+	IntegrationConditionImageSignatureInvalidReason string = "ImageSignatureInvalid"
+	// IntegrationConditionSuspendedReason used (as false) for the ready condition of a suspended Integration.
+	IntegrationConditionSuspendedReason string = "Suspended"
 )
 
 // IntegrationCondition describes the state of a resource at a certain point.
@@ -111,6 +111,10 @@ type IntegrationKitStatus struct {
 	Digest string `json:"digest,omitempty"`
 	// list of artifacts used by the kit
 	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// the resolved dependency tree of the kit
+	DependencyTree []DependencyTreeNode `json:"dependencyTree,omitempty"`
+	// the provenance attestation of the image of the kit
+	Provenance *Provenance `json:"provenance,omitempty"`
 	// failure reason (if any)
 	Failure *Failure `json:"failure,omitempty"`
 	// the runtime version for which this kit was configured
@@ -123,6 +127,8 @@ type IntegrationKitStatus struct {
 	Platform string `json:"platform,omitempty"`
 	// the Camel K operator version for which this kit was configured
 	Version string `json:"version,omitempty"`
+	// the last time the kit was seen in use by an Integration, as recorded by the garbage collection of the kits
+	LastUsedAt *metav1.Time `json:"lastUsedAt,omitempty"`
 	// a list of conditions which happened for the events related the kit
 	Conditions []IntegrationKitCondition `json:"conditions,omitempty"`
 }
@@ -172,6 +178,9 @@ const (
 	// IntegrationKitPriorityLabel labels the kit priority.
 	IntegrationKitPriorityLabel = "camel.apache.org/kit.priority"
 
+	// IntegrationKitWarmLabel labels the kits built ahead of time, with the name of the declaring platform or profile.
+	IntegrationKitWarmLabel = "camel.apache.org/kit.warm"
+
 	// IntegrationKitPhaseNone --.
 	IntegrationKitPhaseNone IntegrationKitPhase = ""
 	// IntegrationKitPhaseInitialization --.
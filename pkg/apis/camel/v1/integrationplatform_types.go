@@ -132,8 +132,20 @@ type IntegrationPlatformBuildSpec struct {
 	BuildCatalogToolTimeout *metav1.Duration `json:"buildCatalogToolTimeout,omitempty"`
 	// how much time to wait before time out the pipeline process
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// the retry policy of the failed pipelines
+	RetryPolicy *BuildRetryPolicy `json:"retryPolicy,omitempty"`
 	// Maven configuration used to build the Camel/Camel-Quarkus applications
 	Maven MavenSpec `json:"maven,omitempty"`
+	// the OCI image layout the images are written to, when using the OCILayout publish strategy
+	OCILayout *OCILayoutSpec `json:"ociLayout,omitempty"`
+	// the signature of the images, and its verification before the Integrations are deployed
+	Signing *ImageSigningSpec `json:"signing,omitempty"`
+	// the garbage collection of the unused IntegrationKits and of their images
+	KitRetention *KitRetentionPolicy `json:"kitRetention,omitempty"`
+	// the IntegrationKits built ahead of time, as bases of the incremental builds of the Integrations
+	WarmKits []WarmKitSpec `json:"warmKits,omitempty"`
+	// the named steps executed by the builder at the end of given phases of the builds
+	Hooks []BuildHook `json:"hooks,omitempty"`
 	// Deprecated: no longer in use
 	PublishStrategyOptions map[string]string `json:"PublishStrategyOptions,omitempty"`
 	// the maximum amount of parallel running pipelines started by this operator instance
@@ -162,6 +174,9 @@ const (
 	// IntegrationPlatformBuildPublishStrategyJib uses Jib maven plugin (https://github.com/GoogleContainerTools/jib)
 	// in order to push the incremental images to the image repository.
 	IntegrationPlatformBuildPublishStrategyJib IntegrationPlatformBuildPublishStrategy = "Jib"
+	// IntegrationPlatformBuildPublishStrategyOCILayout writes the images to an OCI image layout on a PersistentVolumeClaim,
+	// instead of pushing them to the image repository, e.g. to promote them to an air-gapped cluster.
+	IntegrationPlatformBuildPublishStrategyOCILayout IntegrationPlatformBuildPublishStrategy = "OCILayout"
 )
 
 // IntegrationPlatformBuildPublishStrategies the list of all available publish strategies.
@@ -169,6 +184,101 @@ var IntegrationPlatformBuildPublishStrategies = []IntegrationPlatformBuildPublis
 	IntegrationPlatformBuildPublishStrategyS2I,
 	IntegrationPlatformBuildPublishStrategySpectrum,
 	IntegrationPlatformBuildPublishStrategyJib,
+	IntegrationPlatformBuildPublishStrategyOCILayout,
+}
+
+// OCILayoutSpec defines the OCI image layout the images are written to by the OCILayout publish strategy.
+// Each image is written to its own directory or archive, named after the image, on the PersistentVolumeClaim.
+type OCILayoutSpec struct {
+	// The PersistentVolumeClaim the images are written to, mounted by the builder pods.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	// The format of the images, either an OCI image layout `Directory`, or a `Tarball` holding it. `Tarball` by default.
+	// +kubebuilder:validation:Enum=Directory;Tarball
+	Format OCILayoutFormat `json:"format,omitempty"`
+}
+
+// OCILayoutFormat is the format of the images written by the OCILayout publish strategy.
+type OCILayoutFormat string
+
+const (
+	// OCILayoutFormatDirectory writes the image as an OCI image layout directory.
+	OCILayoutFormatDirectory OCILayoutFormat = "Directory"
+	// OCILayoutFormatTarball writes the image as a tarball of an OCI image layout, i.e. an OCI archive.
+	OCILayoutFormatTarball OCILayoutFormat = "Tarball"
+)
+
+// ImageSigningSpec configures the signature of the images published by the Jib and Spectrum strategies, and the
+// verification of the images of the Integrations before they are deployed. The signatures are Sigstore compatible,
+// and stored in the registry next to the images, so that they can also be verified with cosign.
+type ImageSigningSpec struct {
+	// The Secret holding the keys, as created by `cosign generate-key-pair k8s://<namespace>/<name>`: the private key
+	// the images are signed with in the `cosign.key` entry, its password in the `cosign.password` entry, and the public
+	// key the signatures are verified with in the `cosign.pub` entry.
+	Secret string `json:"secret"`
+	// Make the verification mandatory: the Integrations whose image is not signed with the key are not deployed.
+	// Otherwise, only the Integrations whose image has an invalid signature are not deployed.
+	Enforce bool `json:"enforce,omitempty"`
+}
+
+// KitRetentionPolicy defines when the IntegrationKits of type `platform` that are no longer used by any Integration
+// are garbage collected. The other kits, e.g. the external ones, are never garbage collected.
+type KitRetentionPolicy struct {
+	// the maximum number of unused kits kept per namespace, the least recently used ones being garbage collected first
+	MaxUnusedKits *int `json:"maxUnusedKits,omitempty"`
+	// how long an unused kit is kept since it was last used by an Integration
+	// +kubebuilder:validation:Format=duration
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Delete the images of the garbage collected kits from the registry of the platform.
+	DeleteImages bool `json:"deleteImages,omitempty"`
+}
+
+// WarmKitSpec declares an IntegrationKit built ahead of time from a set of dependencies. The Integrations requiring
+// these dependencies are built incrementally on top of its image, instead of from scratch.
+type WarmKitSpec struct {
+	// The name of the kit, derived from its dependencies if not set.
+	Name string `json:"name,omitempty"`
+	// The dependencies of the kit, e.g. `camel:kafka`, in addition to the ones of the Camel K runtime.
+	Dependencies []string `json:"dependencies"`
+}
+
+// BuildHook declares a named step executed by the builder at the end of a phase of the builds, e.g. to add a
+// certificate authority to the image, or to patch the generated `pom.xml`. It runs either a shell script in the
+// builder image, or appends a Dockerfile fragment to the Dockerfile of the image.
+type BuildHook struct {
+	// The name of the hook, unique within the platform.
+	Name string `json:"name"`
+	// The phase of the builds at the end of which the hook is executed.
+	// +kubebuilder:validation:Enum=Init;ProjectGeneration;ProjectBuild;ApplicationPackage
+	Phase BuildHookPhase `json:"phase"`
+	// The shell script executed from the build directory, with the `sh` shell of the builder image. Only supported
+	// with the `pod` build strategy.
+	Script string `json:"script,omitempty"`
+	// The Dockerfile instructions appended to the Dockerfile of the image, as a Go template of the `BaseImage`,
+	// `DeploymentDir` and `RuntimeVersion` values. Only valid at the `ApplicationPackage` phase, and only supported
+	// by the publish strategies building the image from its Dockerfile.
+	Dockerfile string `json:"dockerfile,omitempty"`
+}
+
+// BuildHookPhase is the phase of the builds a BuildHook is executed at.
+type BuildHookPhase string
+
+const (
+	// BuildHookPhaseInit executes the hook once the build is initialized, e.g. once the catalog is loaded.
+	BuildHookPhaseInit BuildHookPhase = "Init"
+	// BuildHookPhaseProjectGeneration executes the hook once the project is generated, before it is built.
+	BuildHookPhaseProjectGeneration BuildHookPhase = "ProjectGeneration"
+	// BuildHookPhaseProjectBuild executes the hook once the project is built.
+	BuildHookPhaseProjectBuild BuildHookPhase = "ProjectBuild"
+	// BuildHookPhaseApplicationPackage executes the hook once the context of the image is packaged.
+	BuildHookPhaseApplicationPackage BuildHookPhase = "ApplicationPackage"
+)
+
+// BuildHookPhases the list of all the phases the BuildHooks can be executed at.
+var BuildHookPhases = []BuildHookPhase{
+	BuildHookPhaseInit,
+	BuildHookPhaseProjectGeneration,
+	BuildHookPhaseProjectBuild,
+	BuildHookPhaseApplicationPackage,
 }
 
 // IntegrationPlatformPhase is the phase of an IntegrationPlatform.
@@ -185,8 +295,7 @@ const (
 	IntegrationPlatformPhaseNone IntegrationPlatformPhase = ""
 	// IntegrationPlatformPhaseCreating when the IntegrationPlatform is under creation process.
 	IntegrationPlatformPhaseCreating IntegrationPlatformPhase = "Creating"
-	// IntegrationPlatformPhaseWarming when the IntegrationPlatform is warming (ie, creating Kaniko cache).
-	// Deprecated no longer in use.
+	// IntegrationPlatformPhaseWarming when the IntegrationPlatform is building its warm IntegrationKits.
 	IntegrationPlatformPhaseWarming IntegrationPlatformPhase = "Warming"
 	// IntegrationPlatformPhaseReady when the IntegrationPlatform is ready.
 	IntegrationPlatformPhaseReady IntegrationPlatformPhase = "Ready"
@@ -209,6 +318,12 @@ const (
 	IntegrationPlatformConditionKameletCatalogAvailable IntegrationPlatformConditionType = "KameletCatalogAvailable"
 	// IntegrationPlatformConditionMavenSettingsAvailable is the condition for the availability of a Maven settings configuration.
 	IntegrationPlatformConditionMavenSettingsAvailable IntegrationPlatformConditionType = "MavenSettingsAvailable"
+	// IntegrationPlatformConditionMavenCacheAvailable is the condition for the availability of the persistent Maven cache.
+	IntegrationPlatformConditionMavenCacheAvailable IntegrationPlatformConditionType = "MavenCacheAvailable"
+	// IntegrationPlatformConditionOCILayoutAvailable is the condition for the availability of the OCI image layout volume.
+	IntegrationPlatformConditionOCILayoutAvailable IntegrationPlatformConditionType = "OCILayoutAvailable"
+	// IntegrationPlatformConditionWarmKitsReady is the condition for the readiness of the warm IntegrationKits.
+	IntegrationPlatformConditionWarmKitsReady IntegrationPlatformConditionType = "WarmKitsReady"
 
 	// IntegrationPlatformConditionCreatedReason represents the reason that the IntegrationPlatform is created.
 	IntegrationPlatformConditionCreatedReason = "IntegrationPlatformCreated"
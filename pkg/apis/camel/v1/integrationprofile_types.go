@@ -90,6 +90,8 @@ type IntegrationProfileBuildSpec struct {
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 	// Maven configuration used to build the Camel/Camel-Quarkus applications
 	Maven MavenSpec `json:"maven,omitempty"`
+	// the IntegrationKits built ahead of time, as bases of the incremental builds of the Integrations
+	WarmKits []WarmKitSpec `json:"warmKits,omitempty"`
 }
 
 // IntegrationProfileKameletSpec define the behavior for all the Kamelets controller by the IntegrationProfile.
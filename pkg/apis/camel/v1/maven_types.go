@@ -52,6 +52,37 @@ type MavenSpec struct {
 	// e.g., `-V,--no-transfer-progress,-Dstyle.color=never`.
 	// See https://maven.apache.org/ref/3.8.4/maven-embedder/cli.html.
 	CLIOptions []string `json:"cliOptions,omitempty"`
+	// The bundle of pre-resolved dependencies that Maven resolves the dependencies from, in offline mode.
+	Offline *MavenOfflineSpec `json:"offline,omitempty"`
+	// The persistent Maven repository shared by the builds running in pods.
+	Cache *MavenCacheSpec `json:"cache,omitempty"`
+}
+
+// MavenOfflineSpec defines the bundle of pre-resolved dependencies used by offline builds, as produced by
+// the `kamel offline bundle` command.
+// The bundle is used as a read-only repository mirroring all the repositories, and the builds fail fast
+// when the bundle is missing some of the dependencies of the integration.
+type MavenOfflineSpec struct {
+	// The location of the bundle, accessible to the builder: either the path of a directory or of a `.tar.gz` archive,
+	// e.g. from a mounted volume, or the reference of an OCI image prefixed with `oci://`.
+	Bundle string `json:"bundle"`
+}
+
+// MavenCacheSpec defines a Maven local repository, backed by a PersistentVolumeClaim, shared by the builds running
+// with the pod strategy, so that they do not resolve all the dependencies from the remote repositories.
+// The builds that find their dependencies in the cache read it concurrently in offline mode, while the builds that
+// need to resolve new dependencies have an exclusive access to it. The least recently used artifacts are evicted
+// when the cache grows above its maximum size.
+type MavenCacheSpec struct {
+	// The name of the PersistentVolumeClaim holding the cache, that is created by the operator if it does not exist.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	// The storage class of the PersistentVolumeClaim created by the operator, the default storage class if empty.
+	StorageClass string `json:"storageClass,omitempty"`
+	// The storage size of the PersistentVolumeClaim created by the operator, `10Gi` by default.
+	Size string `json:"size,omitempty"`
+	// The size above which the least recently used artifacts are evicted from the cache, 80% of the storage size
+	// by default.
+	MaxSize string `json:"maxSize,omitempty"`
 }
 
 // Repository defines a Maven repository.
@@ -126,8 +157,10 @@ type PluginConfiguration struct {
 
 // Container -- .
 type Container struct {
-	Entrypoint string `xml:"entrypoint" json:"entrypoint"`
-	Args       Args   `xml:"args" json:"args"`
+	Entrypoint            string `xml:"entrypoint" json:"entrypoint"`
+	Args                  Args   `xml:"args" json:"args"`
+	CreationTime          string `xml:"creationTime,omitempty" json:"creationTime,omitempty"`
+	FilesModificationTime string `xml:"filesModificationTime,omitempty" json:"filesModificationTime,omitempty"`
 }
 
 // Args -- .
@@ -19,8 +19,14 @@ package v1
 
 import (
 	"encoding/xml"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// DefaultMavenCacheSize is the storage size of the Maven cache when not set.
+const DefaultMavenCacheSize = "10Gi"
+
 //nolint:nestif
 func (in *MavenArtifact) GetDependencyID() string {
 	mvn := "mvn:" + in.GroupID + ":" + in.ArtifactID
@@ -130,3 +136,34 @@ func (m PluginProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) err
 
 	return e.EncodeToken(start.End())
 }
+
+// GetSize returns the storage size of the PersistentVolumeClaim holding the cache.
+func (in *MavenCacheSpec) GetSize() (resource.Quantity, error) {
+	size := in.Size
+	if size == "" {
+		size = DefaultMavenCacheSize
+	}
+	q, err := resource.ParseQuantity(size)
+	if err != nil {
+		return q, fmt.Errorf("invalid Maven cache size %s: %w", size, err)
+	}
+
+	return q, nil
+}
+
+// GetMaxSize returns the size, in bytes, above which the least recently used artifacts are evicted from the cache.
+func (in *MavenCacheSpec) GetMaxSize() (int64, error) {
+	if in.MaxSize != "" {
+		q, err := resource.ParseQuantity(in.MaxSize)
+		if err != nil {
+			return 0, fmt.Errorf("invalid Maven cache maximum size %s: %w", in.MaxSize, err)
+		}
+		return q.Value(), nil
+	}
+	size, err := in.GetSize()
+	if err != nil {
+		return 0, err
+	}
+
+	return size.Value() * 8 / 10, nil
+}
@@ -203,3 +203,23 @@ func TestArtifactToString(t *testing.T) {
 	}
 	assert.Equal(t, "mvn:org.mygroup:my-artifact:jar", a8.GetDependencyID())
 }
+
+func TestMavenCacheSizes(t *testing.T) {
+	cache := MavenCacheSpec{PersistentVolumeClaim: "maven-cache"}
+	size, err := cache.GetSize()
+	require.NoError(t, err)
+	assert.Equal(t, "10Gi", size.String())
+	maxSize, err := cache.GetMaxSize()
+	require.NoError(t, err)
+	assert.Equal(t, int64(8589934592), maxSize)
+
+	cache.Size = "1Gi"
+	cache.MaxSize = "500Mi"
+	maxSize, err = cache.GetMaxSize()
+	require.NoError(t, err)
+	assert.Equal(t, int64(524288000), maxSize)
+
+	cache.MaxSize = "invalid"
+	_, err = cache.GetMaxSize()
+	require.Error(t, err)
+}
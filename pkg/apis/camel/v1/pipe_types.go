@@ -154,6 +154,8 @@ const (
 	PipePhaseError PipePhase = "Error"
 	// PipePhaseReady --.
 	PipePhaseReady PipePhase = "Ready"
+	// PipePhaseSuspended if the Pipe Integration has been suspended.
+	PipePhaseSuspended PipePhase = "Suspended"
 )
 
 // +kubebuilder:object:root=true
@@ -39,6 +39,12 @@ type BuilderTrait struct {
 	// The build order strategy to use, either `dependencies`, `fifo` or `sequential` (default is the platform default)
 	// +kubebuilder:validation:Enum=dependencies;fifo;sequential
 	OrderStrategy string `property:"order-strategy" json:"orderStrategy,omitempty"`
+	// The build tool used to generate and build the project, either `maven` or `gradle` (default `maven`).
+	// The Gradle build resolves the dependencies from the repositories, mirrors and proxies of the Maven settings,
+	// and requires the `gradle` command, or the one set with the `GRADLE_CMD` environment variable, to be available
+	// to the builder. It does not support native builds nor the Jib publish strategy.
+	// +kubebuilder:validation:Enum=maven;gradle
+	BuildTool string `property:"build-tool" json:"buildTool,omitempty"`
 	// When using `pod` strategy, the minimum amount of CPU required by the pod builder.
 	// Deprecated: use TasksRequestCPU instead with task name `builder`.
 	RequestCPU string `property:"request-cpu" json:"requestCPU,omitempty"`
@@ -76,4 +82,19 @@ type BuilderTrait struct {
 	Annotations map[string]string `property:"annotations" json:"annotations,omitempty"`
 	// The list of manifest platforms to use to build a container image (default `linux/amd64`).
 	ImagePlatforms []string `property:"platforms" json:"platforms,omitempty"`
+	// The maximum number of attempts to recover a failed build, 0 to never retry (default is the platform retry policy).
+	// The permanent failures, such as a compilation error or a missing artifact, are never retried.
+	RetryMaxAttempts *int `property:"retry-max-attempts" json:"retryMaxAttempts,omitempty"`
+	// The duration to wait before the first attempt to recover a failed build, doubled on each following attempt
+	// (default is the platform retry policy).
+	RetryMinBackoff string `property:"retry-min-backoff" json:"retryMinBackoff,omitempty"`
+	// The maximum duration to wait between two attempts to recover a failed build (default is the platform retry policy).
+	RetryMaxBackoff string `property:"retry-max-backoff" json:"retryMaxBackoff,omitempty"`
 }
+
+const (
+	// MavenBuildTool represents the Maven build of the project.
+	MavenBuildTool = "maven"
+	// GradleBuildTool represents the Gradle build of the project.
+	GradleBuildTool = "gradle"
+)
@@ -39,4 +39,8 @@ type JVMTrait struct {
 	Classpath string `property:"classpath" json:"classpath,omitempty"`
 	// The Jar dependency which will run the application. Leave it empty for managed Integrations.
 	Jar string `property:"jar" json:"jar,omitempty"`
+	// Activates a continuous Java Flight Recorder recording, written to an emptyDir volume mounted at `/var/lib/camel/jfr`
+	JFR *bool `property:"jfr" json:"jfr,omitempty"`
+	// The maximum size of the continuous JFR recording, also used as the size limit of its volume (default `250Mi`)
+	JFRMaxSize string `property:"jfr-max-size" json:"jfrMaxSize,omitempty"`
 }
@@ -119,6 +119,11 @@ func (in *BuilderTrait) DeepCopyInto(out *BuilderTrait) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RetryMaxAttempts != nil {
+		in, out := &in.RetryMaxAttempts, &out.RetryMaxAttempts
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderTrait.
@@ -524,6 +529,11 @@ func (in *JVMTrait) DeepCopyInto(out *JVMTrait) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.JFR != nil {
+		in, out := &in.JFR, &out.JFR
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JVMTrait.
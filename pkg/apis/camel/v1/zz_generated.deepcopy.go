@@ -176,6 +176,21 @@ func (in *BuildConfiguration) DeepCopy() *BuildConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildHook) DeepCopyInto(out *BuildHook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildHook.
+func (in *BuildHook) DeepCopy() *BuildHook {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildList) DeepCopyInto(out *BuildList) {
 	*out = *in
@@ -208,6 +223,36 @@ func (in *BuildList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRetryPolicy) DeepCopyInto(out *BuildRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinBackoff != nil {
+		in, out := &in.MinBackoff, &out.MinBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRetryPolicy.
+func (in *BuildRetryPolicy) DeepCopy() *BuildRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	*out = *in
@@ -220,6 +265,11 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	}
 	in.Configuration.DeepCopyInto(&out.Configuration)
 	out.Timeout = in.Timeout
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(BuildRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSpec.
@@ -240,6 +290,16 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = make([]Artifact, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependencyTree != nil {
+		in, out := &in.DependencyTree, &out.DependencyTree
+		*out = make([]DependencyTreeNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(Provenance)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Failure != nil {
 		in, out := &in.Failure, &out.Failure
 		*out = new(Failure)
@@ -272,7 +332,7 @@ func (in *BuildStatus) DeepCopy() *BuildStatus {
 func (in *BuildahTask) DeepCopyInto(out *BuildahTask) {
 	*out = *in
 	in.BaseTask.DeepCopyInto(&out.BaseTask)
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 	if in.Verbose != nil {
 		in, out := &in.Verbose, &out.Verbose
 		*out = new(bool)
@@ -318,6 +378,11 @@ func (in *BuilderTask) DeepCopyInto(out *BuilderTask) {
 		*out = new(GitConfigSpec)
 		**out = **in
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]BuildHook, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderTask.
@@ -805,6 +870,21 @@ func (in *DataTypesSpec) DeepCopy() *DataTypesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyTreeNode) DeepCopyInto(out *DependencyTreeNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyTreeNode.
+func (in *DependencyTreeNode) DeepCopy() *DependencyTreeNode {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyTreeNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Endpoint) DeepCopyInto(out *Endpoint) {
 	*out = *in
@@ -1162,6 +1242,21 @@ func (in *HealthCheckResponse) DeepCopy() *HealthCheckResponse {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSigningSpec) DeepCopyInto(out *ImageSigningSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSigningSpec.
+func (in *ImageSigningSpec) DeepCopy() *ImageSigningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSigningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Integration) DeepCopyInto(out *Integration) {
 	*out = *in
@@ -1344,6 +1439,16 @@ func (in *IntegrationKitStatus) DeepCopyInto(out *IntegrationKitStatus) {
 		*out = make([]Artifact, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependencyTree != nil {
+		in, out := &in.DependencyTree, &out.DependencyTree
+		*out = make([]DependencyTreeNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(Provenance)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Failure != nil {
 		in, out := &in.Failure, &out.Failure
 		*out = new(Failure)
@@ -1354,6 +1459,10 @@ func (in *IntegrationKitStatus) DeepCopyInto(out *IntegrationKitStatus) {
 		*out = new(Catalog)
 		**out = **in
 	}
+	if in.LastUsedAt != nil {
+		in, out := &in.LastUsedAt, &out.LastUsedAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]IntegrationKitCondition, len(*in))
@@ -1489,7 +1598,39 @@ func (in *IntegrationPlatformBuildSpec) DeepCopyInto(out *IntegrationPlatformBui
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(BuildRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Maven.DeepCopyInto(&out.Maven)
+	if in.OCILayout != nil {
+		in, out := &in.OCILayout, &out.OCILayout
+		*out = new(OCILayoutSpec)
+		**out = **in
+	}
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(ImageSigningSpec)
+		**out = **in
+	}
+	if in.KitRetention != nil {
+		in, out := &in.KitRetention, &out.KitRetention
+		*out = new(KitRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmKits != nil {
+		in, out := &in.WarmKits, &out.WarmKits
+		*out = make([]WarmKitSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]BuildHook, len(*in))
+		copy(*out, *in)
+	}
 	if in.PublishStrategyOptions != nil {
 		in, out := &in.PublishStrategyOptions, &out.PublishStrategyOptions
 		*out = make(map[string]string, len(*in))
@@ -1668,6 +1809,13 @@ func (in *IntegrationProfileBuildSpec) DeepCopyInto(out *IntegrationProfileBuild
 		**out = **in
 	}
 	in.Maven.DeepCopyInto(&out.Maven)
+	if in.WarmKits != nil {
+		in, out := &in.WarmKits, &out.WarmKits
+		*out = make([]WarmKitSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationProfileBuildSpec.
@@ -2066,7 +2214,7 @@ func (in *JSONSchemaProps) DeepCopy() *JSONSchemaProps {
 func (in *JibTask) DeepCopyInto(out *JibTask) {
 	*out = *in
 	in.BaseTask.DeepCopyInto(&out.BaseTask)
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JibTask.
@@ -2290,7 +2438,7 @@ func (in *KameletStatus) DeepCopy() *KameletStatus {
 func (in *KanikoTask) DeepCopyInto(out *KanikoTask) {
 	*out = *in
 	in.BaseTask.DeepCopyInto(&out.BaseTask)
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 	if in.Verbose != nil {
 		in, out := &in.Verbose, &out.Verbose
 		*out = new(bool)
@@ -2329,6 +2477,31 @@ func (in *KanikoTaskCache) DeepCopy() *KanikoTaskCache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KitRetentionPolicy) DeepCopyInto(out *KitRetentionPolicy) {
+	*out = *in
+	if in.MaxUnusedKits != nil {
+		in, out := &in.MaxUnusedKits, &out.MaxUnusedKits
+		*out = new(int)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KitRetentionPolicy.
+func (in *KitRetentionPolicy) DeepCopy() *KitRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KitRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenArtifact) DeepCopyInto(out *MavenArtifact) {
 	*out = *in
@@ -2372,6 +2545,36 @@ func (in *MavenBuildSpec) DeepCopy() *MavenBuildSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenCacheSpec) DeepCopyInto(out *MavenCacheSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenCacheSpec.
+func (in *MavenCacheSpec) DeepCopy() *MavenCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenOfflineSpec) DeepCopyInto(out *MavenOfflineSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenOfflineSpec.
+func (in *MavenOfflineSpec) DeepCopy() *MavenOfflineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenOfflineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 	*out = *in
@@ -2408,6 +2611,16 @@ func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Offline != nil {
+		in, out := &in.Offline, &out.Offline
+		*out = new(MavenOfflineSpec)
+		**out = **in
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(MavenCacheSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenSpec.
@@ -2420,6 +2633,38 @@ func (in *MavenSpec) DeepCopy() *MavenSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCILayoutSpec) DeepCopyInto(out *OCILayoutSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCILayoutSpec.
+func (in *OCILayoutSpec) DeepCopy() *OCILayoutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OCILayoutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCILayoutTask) DeepCopyInto(out *OCILayoutTask) {
+	*out = *in
+	in.BaseTask.DeepCopyInto(&out.BaseTask)
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCILayoutTask.
+func (in *OCILayoutTask) DeepCopy() *OCILayoutTask {
+	if in == nil {
+		return nil
+	}
+	out := new(OCILayoutTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Path) DeepCopyInto(out *Path) {
 	*out = *in
@@ -2828,10 +3073,35 @@ func (in Properties) DeepCopy() Properties {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provenance) DeepCopyInto(out *Provenance) {
+	*out = *in
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provenance.
+func (in *Provenance) DeepCopy() *Provenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Provenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishTask) DeepCopyInto(out *PublishTask) {
 	*out = *in
 	out.Registry = in.Registry
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(ImageSigningSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishTask.
@@ -2948,7 +3218,7 @@ func (in *RuntimeSpec) DeepCopy() *RuntimeSpec {
 func (in *S2iTask) DeepCopyInto(out *S2iTask) {
 	*out = *in
 	in.BaseTask.DeepCopyInto(&out.BaseTask)
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S2iTask.
@@ -3014,7 +3284,7 @@ func (in *SourceSpec) DeepCopy() *SourceSpec {
 func (in *SpectrumTask) DeepCopyInto(out *SpectrumTask) {
 	*out = *in
 	in.BaseTask.DeepCopyInto(&out.BaseTask)
-	out.PublishTask = in.PublishTask
+	in.PublishTask.DeepCopyInto(&out.PublishTask)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpectrumTask.
@@ -3092,6 +3362,11 @@ func (in *Task) DeepCopyInto(out *Task) {
 		*out = new(JibTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OCILayout != nil {
+		in, out := &in.OCILayout, &out.OCILayout
+		*out = new(OCILayoutTask)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
@@ -3437,3 +3712,23 @@ func (in *ValueSource) DeepCopy() *ValueSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmKitSpec) DeepCopyInto(out *WarmKitSpec) {
+	*out = *in
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmKitSpec.
+func (in *WarmKitSpec) DeepCopy() *WarmKitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmKitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
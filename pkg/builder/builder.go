@@ -62,10 +62,18 @@ func (t *builderTask) Do(ctx context.Context) v1.BuildStatus {
 		Namespace: t.build.Namespace,
 		Build:     *t.task,
 		BaseImage: t.task.BaseImage,
+		Registry:  publishRegistry(t.build),
 	}
 	t.log.Infof("running builder task %s in context directory: %s", c.Build.Name, c.Path)
 
-	steps, err := StepsFrom(t.task.Steps...)
+	// The hook steps are resolved from the hooks of the task
+	hookSteps, err := HookSteps(t.task.Hooks, hookConstraints(t.build, t.task))
+	if err != nil {
+		t.log.Errorf(err, "invalid build hooks")
+		return result.Failed(err)
+	}
+
+	steps, err := stepsFrom(hookSteps, t.task.Steps...)
 	if err != nil {
 		t.log.Errorf(err, "invalid builder steps: %s", t.task.Steps)
 		result.Failed(err)
@@ -115,8 +123,15 @@ steps:
 	}
 
 	result.BaseImage = c.BaseImage
+	result.GitCommit = c.GitCommit
 	result.Artifacts = make([]v1.Artifact, 0, len(c.Artifacts))
 	result.Artifacts = append(result.Artifacts, c.Artifacts...)
+	result.DependencyTree = c.DependencyTree
+	provenance, err := newProvenance(t.build, &c)
+	if err != nil {
+		return result.Failed(err)
+	}
+	result.Provenance = provenance
 
 	t.log.Debugf("dependencies: %s", t.task.Dependencies)
 	t.log.Debugf("artifacts: %s", artifactIDs(c.Artifacts))
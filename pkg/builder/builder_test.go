@@ -74,6 +74,36 @@ func TestBuilderFailure(t *testing.T) {
 	assert.Equal(t, "an error", status.Error)
 }
 
+func TestBuilderHookScriptWithRoutineStrategy(t *testing.T) {
+	c, err := internal.NewFakeClient()
+	require.NoError(t, err)
+
+	b := New(c)
+
+	hooks := []v1.BuildHook{{Name: "ca", Phase: v1.BuildHookPhaseInit, Script: "echo ca"}}
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{
+							Name:          "builder",
+							Configuration: v1.BuildConfiguration{Strategy: v1.BuildStrategyRoutine},
+						},
+						Hooks: hooks,
+						Steps: []string{hookStepIDPrefix + "ca"},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := newContext()
+	status := b.Build(build).TaskByName("builder").Do(ctx)
+	assert.Equal(t, v1.BuildPhaseFailed, status.Phase)
+	assert.Equal(t, "build hook ca can only run a script with the pod build strategy", status.Error)
+}
+
 func TestS2IPublishingFailure(t *testing.T) {
 	c, err := internal.NewFakeClient()
 	require.NoError(t, err)
@@ -34,7 +34,12 @@ func newMavenContext(ctx *builderContext) *maven.Context {
 	mc.UserSettings = ctx.Maven.UserSettings
 	mc.SettingsSecurity = ctx.Maven.SettingsSecurity
 	mc.LocalRepository = ctx.Build.Maven.LocalRepository
+	if cache := mavenCache(ctx); cache != nil {
+		mc.LocalRepository = cache.Dir
+	}
 	mc.AdditionalArguments = ctx.Build.Maven.CLIOptions
+	mc.Offline = ctx.Maven.OfflineRepository != ""
+	mc.SkipPomGeneration = ctx.Maven.PomGenerated
 
 	if ctx.Maven.TrustStoreName != "" {
 		mc.ExtraMavenOpts = append(mc.ExtraMavenOpts,
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/digest"
+	"github.com/apache/camel-k/v2/pkg/util/io"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+// DependencyTreeFile is the name of the dependency tree report, added to the artifacts of the kits.
+const DependencyTreeFile = "dependency-tree.json"
+
+// computeQuarkusDependencyTree resolves the dependency tree of the Maven project built by the
+// builder task. The dependency tree is a report, so that failing to compute it does not fail the build.
+// The goal runs with a lease on the persistent Maven cache, if any, as the builds do.
+func computeQuarkusDependencyTree(ctx *builderContext) error {
+	mc := newMavenContext(ctx)
+	treePath := filepath.Join(mc.Path, "target", "dependency-tree.txt")
+	mc.AddArguments(maven.DependencyTreeGoal(treePath)...)
+	err := buildWithMavenCache(ctx, *mc, func(mc maven.Context) error {
+		return ctx.Maven.Project.Command(mc).Do(ctx.C)
+	})
+	if err != nil {
+		log.Infof("Unable to resolve the dependency tree of %s: %s", ctx.Build.Name, err.Error())
+		return nil
+	}
+
+	f, err := os.Open(treePath)
+	if err != nil {
+		log.Infof("Unable to read the dependency tree of %s: %s", ctx.Build.Name, err.Error())
+		return nil
+	}
+	defer f.Close()
+	tree, err := maven.ParseDependencyTree(f)
+	if err != nil {
+		log.Infof("Unable to parse the dependency tree of %s: %s", ctx.Build.Name, err.Error())
+		return nil
+	}
+	pinDependencies(tree, ctx.Build.Dependencies)
+
+	report, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		return err
+	}
+	reportPath := filepath.Join(mc.Path, "target", DependencyTreeFile)
+	if err := os.WriteFile(reportPath, report, io.FilePerm644); err != nil {
+		return err
+	}
+	sha1, err := digest.ComputeSHA1(reportPath)
+	if err != nil {
+		return err
+	}
+
+	ctx.DependencyTree = tree
+	ctx.Artifacts = append(ctx.Artifacts, v1.Artifact{
+		ID:       DependencyTreeFile,
+		Location: reportPath,
+		Target:   filepath.Join(DependenciesDir, DependencyTreeFile),
+		Checksum: "sha1:" + sha1,
+	})
+
+	return nil
+}
+
+// pinDependencies marks the direct dependencies whose version is explicitly set by the user,
+// with the `mvn:groupId:artifactId:version` dependencies of the build.
+func pinDependencies(tree []v1.DependencyTreeNode, dependencies []string) {
+	pinned := make(map[string]bool)
+	for _, d := range dependencies {
+		if !strings.HasPrefix(d, "mvn:") {
+			continue
+		}
+		dependency, err := maven.ParseGAV(strings.TrimPrefix(d, "mvn:"))
+		if err != nil || dependency.Version == "" {
+			continue
+		}
+		pinned[dependency.GroupID+":"+dependency.ArtifactID] = true
+	}
+
+	for i := range tree {
+		if tree[i].Depth == 0 && pinned[maven.DependencyGroupArtifact(tree[i].ID)] {
+			tree[i].Pinned = true
+		}
+	}
+}
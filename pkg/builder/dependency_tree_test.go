@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestPinDependencies(t *testing.T) {
+	tree := []v1.DependencyTreeNode{
+		{ID: "org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0", Scope: "compile"},
+		{ID: "com.fasterxml.jackson.core:jackson-core:jar:2.16.1", Depth: 1, Scope: "compile", ConflictsWith: "2.17.0"},
+		{ID: "com.fasterxml.jackson.core:jackson-core:jar:2.17.0", Scope: "compile"},
+		{ID: "org.acme:library:jar:1.0", Scope: "compile"},
+	}
+
+	pinDependencies(tree, []string{
+		"camel:core",
+		"mvn:com.fasterxml.jackson.core:jackson-core:2.17.0",
+		"mvn:org.acme:library",
+	})
+
+	assert.False(t, tree[0].Pinned)
+	assert.False(t, tree[1].Pinned)
+	assert.True(t, tree[2].Pinned)
+	assert.False(t, tree[3].Pinned)
+}
@@ -18,13 +18,28 @@ limitations under the License.
 package builder
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/log"
 )
 
+// knownHostsEntry is the entry of the Git Secret holding the public keys of the SSH Git servers.
+const knownHostsEntry = "known_hosts"
+
 func init() {
 	registerSteps(Git)
 
@@ -56,29 +71,176 @@ var Git = gitSteps{
 	ComputeDependencies: NewStep(ProjectBuildPhase+1, computeFatJarDependency),
 }
 
+// cloneProject clones the Git project in the maven directory, and records the SHA of the commit checked out.
+// The history is only fetched when a commit is requested, and only the directory of the project is checked out
+// when a path is set.
 func cloneProject(ctx *builderContext) error {
+	config := ctx.Build.Git
+	if err := validateGitConfig(config); err != nil {
+		return err
+	}
+
 	gitCloneOptions := &git.CloneOptions{
-		URL: ctx.Build.Git.URL,
+		URL:        config.URL,
+		NoCheckout: true,
+	}
+	switch {
+	case config.Branch != "":
+		gitCloneOptions.ReferenceName = plumbing.NewBranchReferenceName(config.Branch)
+		gitCloneOptions.SingleBranch = true
+	case config.Tag != "":
+		gitCloneOptions.ReferenceName = plumbing.NewTagReferenceName(config.Tag)
+		gitCloneOptions.SingleBranch = true
+	}
+	if config.Commit == "" {
+		// Shallow clone, only the commit to build is needed
+		gitCloneOptions.Depth = 1
+	}
+
+	if config.Secret != "" {
+		auth, err := gitAuth(ctx, config)
+		if err != nil {
+			return err
+		}
+		gitCloneOptions.Auth = auth
+	}
+
+	projectDir := filepath.Join(ctx.Path, "maven")
+	cloneDir := projectDir
+	if config.Path != "" {
+		cloneDir = filepath.Join(ctx.Path, "git")
+	}
+
+	repo, err := git.PlainCloneContext(ctx.C, cloneDir, false, gitCloneOptions)
+	if err != nil {
+		return err
 	}
 
-	if ctx.Build.Git.Secret != "" {
-		secret, err := ctx.Client.CoreV1().Secrets(ctx.Namespace).Get(ctx.C, ctx.Build.Git.Secret, metav1.GetOptions{})
+	var hash plumbing.Hash
+	if config.Commit != "" {
+		h, err := repo.ResolveRevision(plumbing.Revision(config.Commit))
+		if err != nil {
+			return fmt.Errorf("cannot find commit %s: %w", config.Commit, err)
+		}
+		hash = *h
+	} else {
+		head, err := repo.Head()
+		if err != nil {
+			return err
+		}
+		hash = head.Hash()
+	}
+	// Tags may reference annotated tag objects rather than commits
+	if tag, err := repo.TagObject(hash); err == nil {
+		commit, err := tag.Commit()
 		if err != nil {
 			return err
 		}
-		token := ""
-		for _, v := range secret.Data {
-			if v != nil {
-				token = string(v)
+		hash = commit.Hash
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	checkoutOptions := &git.CheckoutOptions{
+		Hash:  hash,
+		Force: true,
+	}
+	if config.Path != "" {
+		// Sparse checkout of the project directory only
+		checkoutOptions.SparseCheckoutDirectories = []string{filepath.ToSlash(filepath.Clean(config.Path))}
+	}
+	if err := worktree.Checkout(checkoutOptions); err != nil {
+		return err
+	}
+
+	if config.Path != "" {
+		if err := os.Rename(filepath.Join(cloneDir, filepath.Clean(config.Path)), projectDir); err != nil {
+			return fmt.Errorf("cannot find path %s in Git repository %s: %w", config.Path, config.URL, err)
+		}
+		if err := os.RemoveAll(cloneDir); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Cloned Git repository %s at commit %s", config.URL, hash.String())
+	ctx.GitCommit = hash.String()
+
+	return nil
+}
+
+func validateGitConfig(config *v1.GitConfigSpec) error {
+	if config.Branch != "" && config.Tag != "" {
+		return errors.New("a Git branch and a Git tag cannot be set together")
+	}
+	if config.Tag != "" && config.Commit != "" {
+		return errors.New("a Git tag and a Git commit cannot be set together")
+	}
+	if config.Path != "" {
+		path := filepath.Clean(config.Path)
+		if filepath.IsAbs(path) || path == "." || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid Git path %s: a sub directory of the repository is expected", config.Path)
+		}
+	}
+
+	return nil
+}
+
+// gitAuth returns the authentication to the Git server from the Git Secret, either an SSH private key or a token.
+func gitAuth(ctx *builderContext, config *v1.GitConfigSpec) (transport.AuthMethod, error) {
+	secret, err := ctx.Client.CoreV1().Secrets(ctx.Namespace).Get(ctx.C, config.Secret, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if key, ok := secret.Data[corev1.SSHAuthPrivateKey]; ok {
+		user := "git"
+		if endpoint, err := transport.NewEndpoint(config.URL); err == nil && endpoint.User != "" {
+			user = endpoint.User
+		}
+		auth, err := gitssh.NewPublicKeys(user, key, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH private key in secret %s: %w", config.Secret, err)
+		}
+		if knownHosts, ok := secret.Data[knownHostsEntry]; ok {
+			if auth.HostKeyCallback, err = knownHostsCallback(knownHosts); err != nil {
+				return nil, fmt.Errorf("invalid known hosts in secret %s: %w", config.Secret, err)
 			}
 		}
-		gitCloneOptions.Auth = &http.BasicAuth{
-			Username: "camel-k", // yes, this can be anything except an empty string
-			Password: token,
+		// Otherwise, the default known hosts files of the builder are used
+
+		return auth, nil
+	}
+
+	token := ""
+	for _, v := range secret.Data {
+		if v != nil {
+			token = string(v)
 		}
 	}
 
-	_, err := git.PlainClone(filepath.Join(ctx.Path, "maven"), false, gitCloneOptions)
+	return &http.BasicAuth{
+		Username: "camel-k", // yes, this can be anything except an empty string
+		Password: token,
+	}, nil
+}
+
+// knownHostsCallback returns the callback checking the SSH host keys against the given known hosts.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	file, err := os.CreateTemp("", "known-hosts-")
+	if err != nil {
+		return nil, err
+	}
+	// The known hosts are read when creating the callback
+	defer os.Remove(file.Name())
+	if _, err := file.Write(knownHosts); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
 
-	return err
+	return gitssh.NewKnownHostsCallback(file.Name())
 }
@@ -22,6 +22,11 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/v2/pkg/util"
@@ -79,6 +84,7 @@ func TestGitPrivateRepoFail(t *testing.T) {
 	require.NoError(t, err)
 
 	ctx := &builderContext{
+		C:    context.TODO(),
 		Path: tmpGitDir,
 		Build: v1.BuilderTask{
 			Git: &v1.GitConfigSpec{
@@ -94,3 +100,116 @@ func TestGitPrivateRepoFail(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no such file or directory")
 }
+
+// newLocalGitRepo creates a Git repository with a tagged commit and a second commit on the default branch, followed by
+// a commit on a feature branch, and returns the SHA of the commits.
+func newLocalGitRepo(t *testing.T) (string, []string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	signature := &object.Signature{Name: "camel-k", Email: "camel-k@apache.org", When: time.Now()}
+
+	commit := func(files map[string]string) plumbing.Hash {
+		for file, content := range files {
+			require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, file)), 0o755))
+			require.NoError(t, os.WriteFile(path.Join(dir, file), []byte(content), 0o600))
+			_, err := worktree.Add(file)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: signature})
+		require.NoError(t, err)
+		return hash
+	}
+
+	first := commit(map[string]string{"README.md": "readme", "apps/my-app/pom.xml": "v1"})
+	_, err = repo.CreateTag("v1", first, &git.CreateTagOptions{Tagger: signature, Message: "v1"})
+	require.NoError(t, err)
+	second := commit(map[string]string{"apps/my-app/pom.xml": "v2"})
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+	third := commit(map[string]string{"apps/my-app/pom.xml": "v3"})
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.Master}))
+
+	return dir, []string{first.String(), second.String(), third.String()}
+}
+
+func TestGitCloneRefs(t *testing.T) {
+	repo, commits := newLocalGitRepo(t)
+	first, second, third := commits[0], commits[1], commits[2]
+
+	tests := []struct {
+		name    string
+		config  v1.GitConfigSpec
+		commit  string
+		content string
+	}{
+		{name: "default branch", config: v1.GitConfigSpec{}, commit: second, content: "v2"},
+		{name: "branch", config: v1.GitConfigSpec{Branch: "feature"}, commit: third, content: "v3"},
+		{name: "tag", config: v1.GitConfigSpec{Tag: "v1"}, commit: first, content: "v1"},
+		{name: "commit", config: v1.GitConfigSpec{Commit: first}, commit: first, content: "v1"},
+		{name: "commit on branch", config: v1.GitConfigSpec{Branch: "feature", Commit: second}, commit: second, content: "v2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config
+			config.URL = "file://" + repo
+			ctx := &builderContext{
+				C:     context.TODO(),
+				Path:  t.TempDir(),
+				Build: v1.BuilderTask{Git: &config},
+			}
+
+			require.NoError(t, cloneProject(ctx))
+			assert.Equal(t, test.commit, ctx.GitCommit)
+			content, err := util.ReadFile(path.Join(ctx.Path, "maven", "apps", "my-app", "pom.xml"))
+			require.NoError(t, err)
+			assert.Equal(t, test.content, string(content))
+		})
+	}
+}
+
+func TestGitClonePath(t *testing.T) {
+	repo, _ := newLocalGitRepo(t)
+	ctx := &builderContext{
+		C:    context.TODO(),
+		Path: t.TempDir(),
+		Build: v1.BuilderTask{
+			Git: &v1.GitConfigSpec{
+				URL:    "file://" + repo,
+				Branch: "feature",
+				Path:   "apps/my-app",
+			},
+		},
+	}
+
+	require.NoError(t, cloneProject(ctx))
+	content, err := util.ReadFile(path.Join(ctx.Path, "maven", "pom.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, "v3", string(content))
+	_, err = os.Stat(path.Join(ctx.Path, "git"))
+	assert.True(t, os.IsNotExist(err))
+
+	ctx.Path = t.TempDir()
+	ctx.Build.Git.Path = "apps/missing"
+	require.ErrorContains(t, cloneProject(ctx), "cannot find path apps/missing")
+}
+
+func TestGitCloneInvalidConfig(t *testing.T) {
+	tests := map[string]v1.GitConfigSpec{
+		"a Git branch and a Git tag cannot be set together":                      {Branch: "main", Tag: "v1"},
+		"a Git tag and a Git commit cannot be set together":                      {Tag: "v1", Commit: "abcdef"},
+		"invalid Git path ../app: a sub directory of the repository is expected": {Path: "../app"},
+		"invalid Git path /app: a sub directory of the repository is expected":   {Path: "/app"},
+	}
+	for message, config := range tests {
+		ctx := &builderContext{
+			C:     context.TODO(),
+			Path:  t.TempDir(),
+			Build: v1.BuilderTask{Git: &config},
+		}
+		require.EqualError(t, cloneProject(ctx), message)
+	}
+}
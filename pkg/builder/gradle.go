@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/camel-k/v2/pkg/util/gradle"
+)
+
+// GradleDir is the directory, within the build directory, of the Gradle project.
+const GradleDir = "gradle"
+
+func init() {
+	registerSteps(Gradle)
+
+	Gradle.CommonSteps = []Step{
+		Quarkus.LoadCamelQuarkusCatalog,
+		Quarkus.GenerateQuarkusProject,
+		Gradle.GenerateGradleProject,
+		Gradle.BuildGradleContext,
+		Gradle.BuildGradleProject,
+	}
+}
+
+type gradleSteps struct {
+	GenerateGradleProject     Step
+	BuildGradleContext        Step
+	BuildGradleProject        Step
+	ComputeGradleDependencies Step
+
+	CommonSteps []Step
+}
+
+// Gradle holds the steps building the Quarkus application with Gradle. The project is generated from the Maven one,
+// once the dependencies have been injected, so that both build tools produce the same application.
+//
+//nolint:mnd
+var Gradle = gradleSteps{
+	GenerateGradleProject:     NewStep(ProjectGenerationPhase+5, generateGradleProject),
+	BuildGradleContext:        NewStep(ProjectGenerationPhase+6, buildGradleContext),
+	BuildGradleProject:        NewStep(ProjectBuildPhase+2, buildGradleProject),
+	ComputeGradleDependencies: NewStep(ProjectBuildPhase+1, computeGradleDependencies),
+}
+
+func generateGradleProject(ctx *builderContext) error {
+	ctx.Gradle.Project = gradle.NewProjectFromMaven(ctx.Maven.Project)
+
+	return nil
+}
+
+// buildGradleContext configures the repositories and proxies of the Gradle build from the Maven settings.
+func buildGradleContext(ctx *builderContext) error {
+	settings, err := gradle.ParseSettings(ctx.Maven.UserSettings, ctx.Maven.GlobalSettings)
+	if err != nil {
+		return err
+	}
+
+	gc := gradle.NewContext(filepath.Join(ctx.Path, GradleDir))
+	gc.Configure(ctx.Maven.Project.Repositories, settings...)
+	if ctx.Maven.TrustStoreName != "" {
+		gc.SystemProperties["systemProp.javax.net.ssl.trustStore"] = filepath.Join(ctx.Path, ctx.Maven.TrustStoreName)
+		gc.SystemProperties["systemProp.javax.net.ssl.trustStorePassword"] = ctx.Maven.TrustStorePass
+	}
+	if ctx.Maven.OfflineRepository != "" {
+		// The repositories are all mirrored by the offline bundle, that is a local file repository
+		gc.AddArgument("--offline")
+	}
+	ctx.Gradle.Context = gc
+
+	return nil
+}
+
+func buildGradleProject(ctx *builderContext) error {
+	gc := ctx.Gradle.Context
+	resourcesPath := filepath.Join(gc.Path, "src", "main", "resources")
+	if err := os.MkdirAll(resourcesPath, os.ModePerm); err != nil {
+		return fmt.Errorf("failure while creating resource folder: %w", err)
+	}
+	if err := computeApplicationProperties(filepath.Join(resourcesPath, "application.properties"), ctx.Build.Maven.Properties); err != nil {
+		return err
+	}
+	if err := ctx.Gradle.Project.Command(gc).DoProject(); err != nil {
+		return fmt.Errorf("failure while generating gradle project: %w", err)
+	}
+	gc.AddArgument("quarkusBuild")
+	if err := ctx.Gradle.Project.Command(gc).Do(ctx.C); err != nil {
+		return fmt.Errorf("failure while building project: %w", err)
+	}
+
+	return nil
+}
+
+func computeGradleDependencies(ctx *builderContext) error {
+	// The Quarkus Gradle plugin generates the same fast-jar layout as the Maven one
+	quarkusAppDir := filepath.Join(ctx.Path, GradleDir, "build", "quarkus-app")
+	artifacts, err := processQuarkusTransitiveDependencies(quarkusAppDir)
+	if err != nil {
+		return err
+	}
+	ctx.Artifacts = append(ctx.Artifacts, artifacts...)
+
+	return nil
+}
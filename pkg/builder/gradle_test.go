@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/gradle"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+func TestBuildGradleProject(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "ck-gradle-dir")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	// A fake Gradle command that generates a Quarkus fast-jar layout
+	gradleCmd := filepath.Join(tmpDir, "fake-gradle")
+	err = os.WriteFile(gradleCmd, []byte("#!/bin/sh\nmkdir -p build/quarkus-app/lib/main && "+
+		"echo jar > build/quarkus-app/quarkus-run.jar && echo jar > build/quarkus-app/lib/main/dep.jar\n"), 0o700)
+	require.NoError(t, err)
+	t.Setenv("GRADLE_CMD", gradleCmd)
+
+	global, err := maven.NewSettings(maven.DefaultRepositories)
+	require.NoError(t, err)
+	globalSettings, err := global.MarshalBytes()
+	require.NoError(t, err)
+
+	ctx := builderContext{
+		C:    context.TODO(),
+		Path: tmpDir,
+		Build: v1.BuilderTask{
+			Runtime: v1.RuntimeSpec{
+				Version:  "1.2.3",
+				Provider: v1.RuntimeProviderQuarkus,
+				Metadata: map[string]string{"quarkus.version": "4.5.6"},
+			},
+			Maven: v1.MavenBuildSpec{
+				Repositories: []v1.Repository{maven.NewRepository("https://repo.acme.org/maven@id=acme")},
+				MavenSpec: v1.MavenSpec{
+					Properties: map[string]string{"quarkus.package.jar.type": "fast-jar"},
+				},
+			},
+		},
+	}
+	ctx.Maven.GlobalSettings = globalSettings
+
+	require.NoError(t, generateQuarkusProject(&ctx))
+	ctx.Maven.Project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-timer", "")
+	require.NoError(t, generateGradleProject(&ctx))
+	require.NoError(t, buildGradleContext(&ctx))
+	assert.Equal(t, []gradle.Repository{
+		{Name: "central", URL: "https://repo.maven.apache.org/maven2", Releases: true},
+		{Name: "acme", URL: "https://repo.acme.org/maven", Releases: true},
+	}, ctx.Gradle.Context.Repositories)
+
+	require.NoError(t, buildGradleProject(&ctx))
+	gradleDir := filepath.Join(tmpDir, GradleDir)
+	buildScript, err := util.ReadFile(filepath.Join(gradleDir, gradle.BuildFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(buildScript), "id 'io.quarkus' version '4.5.6'")
+	assert.Contains(t, string(buildScript), "implementation enforcedPlatform('org.apache.camel.k:camel-k-runtime-bom:1.2.3')")
+	assert.Contains(t, string(buildScript), "implementation 'org.apache.camel.quarkus:camel-quarkus-timer'")
+	for _, file := range []string{gradle.SettingsFile, gradle.PropertiesFile, gradle.InitScriptFile, "src/main/resources/application.properties"} {
+		exists, err := util.FileExists(filepath.Join(gradleDir, file))
+		require.NoError(t, err)
+		assert.True(t, exists, file)
+	}
+
+	require.NoError(t, computeGradleDependencies(&ctx))
+	assert.Len(t, ctx.Artifacts, 2)
+	targets := []string{ctx.Artifacts[0].Target, ctx.Artifacts[1].Target}
+	assert.ElementsMatch(t, []string{"dependencies/quarkus-run.jar", "dependencies/lib/main/dep.jar"}, targets)
+}
+
+func TestBuildGradleProjectFailure(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "ck-gradle-dir")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	gradleCmd := filepath.Join(tmpDir, "fake-gradle")
+	err = os.WriteFile(gradleCmd, []byte("#!/bin/sh\necho '> Task :quarkusBuild FAILED'\n"+
+		"echo '> Could not resolve org.acme:missing:1.0.'\nexit 1\n"), 0o700)
+	require.NoError(t, err)
+	t.Setenv("GRADLE_CMD", gradleCmd)
+
+	ctx := builderContext{
+		C:    context.TODO(),
+		Path: tmpDir,
+	}
+	ctx.Gradle.Context = gradle.NewContext(filepath.Join(tmpDir, GradleDir))
+
+	err = buildGradleProject(&ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not resolve org.acme:missing:1.0.")
+}
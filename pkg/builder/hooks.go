@@ -0,0 +1,250 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"text/template"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+)
+
+// hookPhaseOffset executes the hooks at the end of their phase, after the steps of the phase.
+const hookPhaseOffset int32 = 9
+
+var (
+	hookPhases = map[v1.BuildHookPhase]int32{
+		v1.BuildHookPhaseInit:               InitPhase,
+		v1.BuildHookPhaseProjectGeneration:  ProjectGenerationPhase,
+		v1.BuildHookPhaseProjectBuild:       ProjectBuildPhase,
+		v1.BuildHookPhaseApplicationPackage: ApplicationPackagePhase,
+	}
+	hookStepIDPrefix = reflect.TypeOf(builderStep{}).PkgPath() + "/hooks/"
+)
+
+// hookDockerfileData are the values the Dockerfile fragments of the hooks are rendered with.
+type hookDockerfileData struct {
+	BaseImage      string
+	DeploymentDir  string
+	RuntimeVersion string
+}
+
+// HookConstraints describe the build executing the hooks, as not all the hooks can be executed by any build.
+type HookConstraints struct {
+	// Strategy is the strategy of the build. The scripts are only executed by the builder Pods, not by the operator.
+	Strategy v1.BuildStrategy
+	// Dockerfile tells whether the image is built from its Dockerfile, so that the Dockerfile fragments are applied.
+	Dockerfile bool
+	// Git tells whether the project is built from a Git repository, i.e. built at the ProjectGeneration phase.
+	Git bool
+}
+
+// ValidateHooks checks the given hooks have a unique name and a known phase, and run either a script, or a
+// Dockerfile fragment at the ApplicationPackage phase, and that they can be executed by the build with the given
+// constraints.
+func ValidateHooks(hooks []v1.BuildHook, constraints HookConstraints) error {
+	names := make(map[string]bool, len(hooks))
+	for _, hook := range hooks {
+		if hook.Name == "" {
+			return errors.New("build hooks must have a name")
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("duplicated build hook: %s", hook.Name)
+		}
+		names[hook.Name] = true
+
+		if _, ok := hookPhases[hook.Phase]; !ok {
+			return fmt.Errorf("unknown phase of build hook %s: %q. One of %v is expected", hook.Name, hook.Phase, v1.BuildHookPhases)
+		}
+		if (hook.Script == "") == (hook.Dockerfile == "") {
+			return fmt.Errorf("build hook %s must have either a script or a Dockerfile", hook.Name)
+		}
+		if hook.Phase == v1.BuildHookPhaseProjectGeneration && constraints.Git {
+			return fmt.Errorf("build hook %s cannot run at the %s phase of the builds from Git, as the project is already built",
+				hook.Name, v1.BuildHookPhaseProjectGeneration)
+		}
+		if hook.Script != "" && constraints.Strategy != v1.BuildStrategyPod {
+			return fmt.Errorf("build hook %s can only run a script with the %s build strategy", hook.Name, v1.BuildStrategyPod)
+		}
+		if hook.Dockerfile != "" {
+			if hook.Phase != v1.BuildHookPhaseApplicationPackage {
+				return fmt.Errorf("build hook %s can only have a Dockerfile at the %s phase", hook.Name, v1.BuildHookPhaseApplicationPackage)
+			}
+			if !constraints.Dockerfile {
+				return fmt.Errorf("build hook %s cannot have a Dockerfile, as the image is not built from its Dockerfile", hook.Name)
+			}
+			if _, err := template.New(hook.Name).Parse(hook.Dockerfile); err != nil {
+				return fmt.Errorf("invalid Dockerfile of build hook %s: %w", hook.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// HookSteps validates the given hooks and returns their steps. The ID of the step of a hook is derived from its
+// name. The hook steps are not registered, but resolved from the hooks of each builder task, so that a builder task
+// always executes its own version of the hooks.
+func HookSteps(hooks []v1.BuildHook, constraints HookConstraints) ([]Step, error) {
+	if err := ValidateHooks(hooks, constraints); err != nil {
+		return nil, err
+	}
+
+	steps := make([]Step, 0, len(hooks))
+	for _, hook := range hooks {
+		h := hook
+		steps = append(steps, &builderStep{
+			StepID: hookStepIDPrefix + h.Name,
+			phase:  hookPhases[h.Phase] + hookPhaseOffset,
+			task: func(ctx *builderContext) error {
+				return executeHook(ctx, h)
+			},
+		})
+	}
+
+	return steps, nil
+}
+
+// stepsFrom returns the steps with the given IDs, resolved from the given hook steps, or from the registered steps.
+func stepsFrom(hookSteps []Step, ids ...string) ([]Step, error) {
+	steps := make([]Step, 0, len(ids))
+	for _, id := range ids {
+		i := slices.IndexFunc(hookSteps, func(step Step) bool {
+			return step.ID() == id
+		})
+		if i >= 0 {
+			steps = append(steps, hookSteps[i])
+			continue
+		}
+		registered, err := StepsFrom(id)
+		if err != nil {
+			return steps, err
+		}
+		steps = append(steps, registered...)
+	}
+
+	return steps, nil
+}
+
+// hookConstraints returns the constraints of the given build on the hooks of its builder task.
+func hookConstraints(build *v1.Build, task *v1.BuilderTask) HookConstraints {
+	constraints := HookConstraints{
+		Git: task.Git != nil,
+	}
+	if configuration := build.BuilderConfiguration(); configuration != nil {
+		constraints.Strategy = configuration.Strategy
+	}
+	// The S2I and the custom publish tasks build the image from its Dockerfile
+	for _, t := range build.Spec.Tasks {
+		if t.S2i != nil || t.Custom != nil {
+			constraints.Dockerfile = true
+		}
+	}
+
+	return constraints
+}
+
+func executeHook(ctx *builderContext, hook v1.BuildHook) error {
+	if hook.Dockerfile != "" {
+		return appendHookDockerfile(ctx, hook)
+	}
+
+	return runHookScript(ctx, hook)
+}
+
+// runHookScript runs the script of the hook from the build directory. At the ProjectGeneration phase, the
+// pom.xml of the Maven project is generated beforehand, so that the script can patch it. The scripts are only run
+// by the builder Pods, and never by the operator, see ValidateHooks.
+func runHookScript(ctx *builderContext, hook v1.BuildHook) error {
+	if hook.Phase == v1.BuildHookPhaseProjectGeneration && ctx.Maven.Project.ArtifactID != "" {
+		mc := newMavenContext(ctx)
+		if err := ctx.Maven.Project.Command(*mc).DoPom(ctx.C); err != nil {
+			return fmt.Errorf("failure while generating pom file: %w", err)
+		}
+		ctx.Maven.PomGenerated = true
+	}
+
+	cmd := exec.CommandContext(ctx.C, "/bin/sh", "-c", hook.Script)
+	cmd.Dir = ctx.Path
+	cmd.Env = append(os.Environ(),
+		"CAMEL_K_BUILD_DIR="+ctx.Path,
+		"CAMEL_K_MAVEN_PROJECT_DIR="+filepath.Join(ctx.Path, "maven"),
+		"CAMEL_K_CONTEXT_DIR="+filepath.Join(ctx.Path, ContextDir),
+		"CAMEL_K_BUILD_PHASE="+string(hook.Phase),
+	)
+
+	stdOut := func(s string) string {
+		log.Infof("[%s] %s", hook.Name, s)
+		return ""
+	}
+	// The error output is reported as the cause of a failure
+	stdErr := func(s string) string {
+		stdOut(s)
+		return s
+	}
+	if err := util.RunAndLog(ctx.C, cmd, stdOut, stdErr); err != nil {
+		return fmt.Errorf("failure while executing build hook %s: %w", hook.Name, err)
+	}
+
+	return nil
+}
+
+// appendHookDockerfile renders the Dockerfile fragment of the hook, and appends it to the Dockerfile of the
+// image context. The hooks with a Dockerfile are rejected by the builds not building the image from its Dockerfile,
+// e.g. with the Jib publish strategy.
+func appendHookDockerfile(ctx *builderContext, hook v1.BuildHook) error {
+	p := filepath.Join(ctx.Path, ContextDir, "Dockerfile")
+	dockerfile, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		log.Infof("Skipping build hook %s, as the image of %s has no Dockerfile", hook.Name, ctx.Build.Name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(hook.Name).Parse(hook.Dockerfile)
+	if err != nil {
+		return fmt.Errorf("invalid Dockerfile of build hook %s: %w", hook.Name, err)
+	}
+	fragment := bytes.NewBuffer(dockerfile)
+	fragment.WriteString("\n")
+	if err := tmpl.Execute(fragment, hookDockerfileData{
+		BaseImage:      ctx.BaseImage,
+		DeploymentDir:  DeploymentDir,
+		RuntimeVersion: ctx.Build.Runtime.Version,
+	}); err != nil {
+		return fmt.Errorf("failure while rendering the Dockerfile of build hook %s: %w", hook.Name, err)
+	}
+	fragment.WriteString("\n")
+
+	// The Dockerfile is read-only
+	if err := os.Remove(p); err != nil {
+		return err
+	}
+
+	return writeDockerfile(ctx, fragment.Bytes())
+}
@@ -0,0 +1,298 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+func TestValidateHooks(t *testing.T) {
+	pod := HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true}
+	tests := []struct {
+		name        string
+		hooks       []v1.BuildHook
+		constraints *HookConstraints
+		err         string
+	}{
+		{
+			name: "valid",
+			hooks: []v1.BuildHook{
+				{Name: "ca", Phase: v1.BuildHookPhaseInit, Script: "echo ca"},
+				{Name: "user", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "USER 1001"},
+			},
+		},
+		{
+			name:  "no name",
+			hooks: []v1.BuildHook{{Phase: v1.BuildHookPhaseInit, Script: "echo"}},
+			err:   "build hooks must have a name",
+		},
+		{
+			name: "duplicated",
+			hooks: []v1.BuildHook{
+				{Name: "ca", Phase: v1.BuildHookPhaseInit, Script: "echo"},
+				{Name: "ca", Phase: v1.BuildHookPhaseProjectBuild, Script: "echo"},
+			},
+			err: "duplicated build hook: ca",
+		},
+		{
+			name:  "unknown phase",
+			hooks: []v1.BuildHook{{Name: "ca", Phase: "ApplicationPublish", Script: "echo"}},
+			err:   "unknown phase of build hook ca",
+		},
+		{
+			name:  "no script nor Dockerfile",
+			hooks: []v1.BuildHook{{Name: "ca", Phase: v1.BuildHookPhaseInit}},
+			err:   "build hook ca must have either a script or a Dockerfile",
+		},
+		{
+			name:  "script and Dockerfile",
+			hooks: []v1.BuildHook{{Name: "ca", Phase: v1.BuildHookPhaseApplicationPackage, Script: "echo", Dockerfile: "USER 1001"}},
+			err:   "build hook ca must have either a script or a Dockerfile",
+		},
+		{
+			name:  "Dockerfile at another phase",
+			hooks: []v1.BuildHook{{Name: "ca", Phase: v1.BuildHookPhaseProjectBuild, Dockerfile: "USER 1001"}},
+			err:   "build hook ca can only have a Dockerfile at the ApplicationPackage phase",
+		},
+		{
+			name:  "invalid Dockerfile template",
+			hooks: []v1.BuildHook{{Name: "ca", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "FROM {{ .BaseImage"}},
+			err:   "invalid Dockerfile of build hook ca",
+		},
+		{
+			name:        "script with the routine strategy",
+			hooks:       []v1.BuildHook{{Name: "ca", Phase: v1.BuildHookPhaseInit, Script: "echo"}},
+			constraints: &HookConstraints{Strategy: v1.BuildStrategyRoutine, Dockerfile: true},
+			err:         "build hook ca can only run a script with the pod build strategy",
+		},
+		{
+			name:        "Dockerfile with the routine strategy",
+			hooks:       []v1.BuildHook{{Name: "user", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "USER 1001"}},
+			constraints: &HookConstraints{Strategy: v1.BuildStrategyRoutine, Dockerfile: true},
+		},
+		{
+			name:        "Dockerfile without Dockerfile build",
+			hooks:       []v1.BuildHook{{Name: "user", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "USER 1001"}},
+			constraints: &HookConstraints{Strategy: v1.BuildStrategyPod},
+			err:         "build hook user cannot have a Dockerfile, as the image is not built from its Dockerfile",
+		},
+		{
+			name:        "ProjectGeneration with Git",
+			hooks:       []v1.BuildHook{{Name: "pom", Phase: v1.BuildHookPhaseProjectGeneration, Script: "echo"}},
+			constraints: &HookConstraints{Strategy: v1.BuildStrategyPod, Git: true},
+			err:         "build hook pom cannot run at the ProjectGeneration phase of the builds from Git",
+		},
+		{
+			name:        "ProjectBuild with Git",
+			hooks:       []v1.BuildHook{{Name: "jar", Phase: v1.BuildHookPhaseProjectBuild, Script: "echo"}},
+			constraints: &HookConstraints{Strategy: v1.BuildStrategyPod, Git: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			constraints := pod
+			if test.constraints != nil {
+				constraints = *test.constraints
+			}
+			err := ValidateHooks(test.hooks, constraints)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestHookSteps(t *testing.T) {
+	constraints := HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true}
+	hooks := []v1.BuildHook{
+		{Name: "init", Phase: v1.BuildHookPhaseInit, Script: "echo init"},
+		{Name: "package", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "USER 1001"},
+	}
+
+	steps, err := HookSteps(hooks, constraints)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, "github.com/apache/camel-k/v2/pkg/builder/hooks/init", steps[0].ID())
+	assert.Equal(t, InitPhase+hookPhaseOffset, steps[0].Phase())
+	assert.Equal(t, ApplicationPackagePhase+hookPhaseOffset, steps[1].Phase())
+
+	// The hook steps are resolved with the other steps, but are not registered
+	resolved, err := stepsFrom(steps, steps[1].ID(), Quarkus.LoadCamelQuarkusCatalog.ID(), steps[0].ID())
+	require.NoError(t, err)
+	assert.Equal(t, []Step{steps[1], Quarkus.LoadCamelQuarkusCatalog, steps[0]}, resolved)
+	_, err = StepsFrom(steps[0].ID())
+	require.ErrorContains(t, err, "unknown build step")
+
+	// A modified hook keeps the ID of its step
+	hooks[0].Script = "echo modified"
+	modified, err := HookSteps(hooks, constraints)
+	require.NoError(t, err)
+	assert.Equal(t, steps[0].ID(), modified[0].ID())
+
+	_, err = HookSteps([]v1.BuildHook{{Name: "invalid", Phase: v1.BuildHookPhaseInit}}, constraints)
+	require.Error(t, err)
+}
+
+func TestHookConstraints(t *testing.T) {
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{Builder: &v1.BuilderTask{BaseTask: v1.BaseTask{
+					Name:          "builder",
+					Configuration: v1.BuildConfiguration{Strategy: v1.BuildStrategyPod},
+				}}},
+				{Jib: &v1.JibTask{BaseTask: v1.BaseTask{Name: "jib"}}},
+			},
+		},
+	}
+	assert.Equal(t, HookConstraints{Strategy: v1.BuildStrategyPod}, hookConstraints(build, build.Spec.Tasks[0].Builder))
+
+	build.Spec.Tasks[1] = v1.Task{S2i: &v1.S2iTask{BaseTask: v1.BaseTask{Name: "s2i"}}}
+	build.Spec.Tasks[0].Builder.Git = &v1.GitConfigSpec{URL: "https://github.com/apache/camel-k-examples"}
+	assert.Equal(t, HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true, Git: true}, hookConstraints(build, build.Spec.Tasks[0].Builder))
+}
+
+func TestHookScript(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	steps, err := HookSteps([]v1.BuildHook{{
+		Name:   "marker",
+		Phase:  v1.BuildHookPhaseProjectBuild,
+		Script: `echo "$CAMEL_K_BUILD_PHASE" > marker.txt`,
+	}}, HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true})
+	require.NoError(t, err)
+
+	ctx := &builderContext{
+		C:    context.TODO(),
+		Path: tmpDir,
+	}
+	require.NoError(t, steps[0].execute(ctx))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "marker.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "ProjectBuild\n", string(content))
+}
+
+func TestHookScriptFailure(t *testing.T) {
+	steps, err := HookSteps([]v1.BuildHook{{
+		Name:   "failure",
+		Phase:  v1.BuildHookPhaseInit,
+		Script: "echo 'no certificate found' >&2; exit 1",
+	}}, HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true})
+	require.NoError(t, err)
+
+	ctx := &builderContext{
+		C:    context.TODO(),
+		Path: t.TempDir(),
+	}
+	err = steps[0].execute(ctx)
+	require.ErrorContains(t, err, "failure while executing build hook failure")
+	require.ErrorContains(t, err, "no certificate found")
+}
+
+func TestHookScriptPatchesPom(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	steps, err := HookSteps([]v1.BuildHook{{
+		Name:   "patch-pom",
+		Phase:  v1.BuildHookPhaseProjectGeneration,
+		Script: `sed -i 's|<artifactId>my-project</artifactId>|<artifactId>patched-project</artifactId>|' "$CAMEL_K_MAVEN_PROJECT_DIR/pom.xml"`,
+	}}, HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true})
+	require.NoError(t, err)
+
+	ctx := &builderContext{
+		C:    context.TODO(),
+		Path: tmpDir,
+	}
+	ctx.Maven.Project = maven.NewProjectWithGAV("org.apache.camel.k.integration", "my-project", "1.0.0")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "maven"), 0o700))
+	require.NoError(t, steps[0].execute(ctx))
+
+	pom, err := os.ReadFile(filepath.Join(tmpDir, "maven", "pom.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(pom), "<artifactId>patched-project</artifactId>")
+
+	// The patched pom.xml is not generated again by the following steps
+	mc := newMavenContext(ctx)
+	assert.True(t, mc.SkipPomGeneration)
+	require.NoError(t, ctx.Maven.Project.Command(*mc).DoPom(ctx.C))
+	pom, err = os.ReadFile(filepath.Join(tmpDir, "maven", "pom.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(pom), "<artifactId>patched-project</artifactId>")
+}
+
+func TestHookDockerfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ContextDir), 0o700))
+
+	steps, err := HookSteps([]v1.BuildHook{{
+		Name:       "company-ca",
+		Phase:      v1.BuildHookPhaseApplicationPackage,
+		Dockerfile: "LABEL base={{ .BaseImage }} runtime={{ .RuntimeVersion }}\nCOPY ca.crt {{ .DeploymentDir }}/ca.crt",
+	}}, HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true})
+	require.NoError(t, err)
+
+	ctx := &builderContext{
+		C:         context.TODO(),
+		Path:      tmpDir,
+		BaseImage: "eclipse-temurin:17",
+		Build: v1.BuilderTask{
+			BaseTask: v1.BaseTask{Name: "package"},
+			Runtime:  v1.RuntimeSpec{Version: "3.8.1"},
+		},
+	}
+	require.NoError(t, jvmDockerfile(ctx))
+	require.NoError(t, steps[0].execute(ctx))
+
+	p := filepath.Join(tmpDir, ContextDir, "Dockerfile")
+	dockerfile, err := os.ReadFile(p)
+	require.NoError(t, err)
+	assert.Contains(t, string(dockerfile), "FROM eclipse-temurin:17")
+	assert.Contains(t, string(dockerfile), "USER 1000\n\t\nLABEL base=eclipse-temurin:17 runtime=3.8.1\nCOPY ca.crt /deployments/ca.crt\n")
+
+	info, err := os.Stat(p)
+	require.NoError(t, err)
+	assert.Equal(t, ReproducibleModTime, info.ModTime().UTC())
+}
+
+func TestHookDockerfileWithoutDockerfile(t *testing.T) {
+	steps, err := HookSteps([]v1.BuildHook{{
+		Name:       "no-dockerfile",
+		Phase:      v1.BuildHookPhaseApplicationPackage,
+		Dockerfile: "USER 1001",
+	}}, HookConstraints{Strategy: v1.BuildStrategyPod, Dockerfile: true})
+	require.NoError(t, err)
+
+	ctx := &builderContext{
+		C:    context.TODO(),
+		Path: t.TempDir(),
+	}
+	require.NoError(t, steps[0].execute(ctx))
+}
@@ -22,6 +22,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/apache/camel-k/v2/pkg/util/io"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
@@ -46,6 +47,10 @@ const (
 	DependenciesDir = "dependencies"
 )
 
+// ReproducibleModTime is the modification time of the files packaged into the images, fixed so that the
+// layers of the images do not depend on when they are built, the same as the files packaged by Jib.
+var ReproducibleModTime = time.Unix(1, 0).UTC()
+
 func init() {
 	registerSteps(Image)
 }
@@ -93,12 +98,7 @@ func executableDockerfile(ctx *builderContext) error {
 		USER nonroot
 	`)
 
-	err := os.WriteFile(filepath.Join(ctx.Path, ContextDir, "Dockerfile"), dockerfile, io.FilePerm400)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writeDockerfile(ctx, dockerfile)
 }
 
 func standardImageContext(ctx *builderContext) error {
@@ -117,12 +117,7 @@ func jvmDockerfile(ctx *builderContext) error {
 		USER 1000
 	`)
 
-	err := os.WriteFile(filepath.Join(ctx.Path, ContextDir, "Dockerfile"), dockerfile, io.FilePerm400)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writeDockerfile(ctx, dockerfile)
 }
 
 func incrementalImageContext(ctx *builderContext) error {
@@ -180,7 +175,45 @@ func imageContext(ctx *builderContext, selector artifactsSelector) error {
 		}
 	}
 
-	return nil
+	return normalizeContext(contextDir)
+}
+
+// writeDockerfile writes the Dockerfile into the image context, with the same modification time as the other files.
+func writeDockerfile(ctx *builderContext, dockerfile []byte) error {
+	p := filepath.Join(ctx.Path, ContextDir, "Dockerfile")
+	if err := os.WriteFile(p, dockerfile, io.FilePerm400); err != nil {
+		return err
+	}
+
+	return os.Chtimes(p, ReproducibleModTime, ReproducibleModTime)
+}
+
+// normalizeContext sets the same modification time and permissions to all the files of the image context,
+// so that the layers packaging it are the same when built again from the same files.
+func normalizeContext(dir string) error {
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+		if err := os.Chmod(p, reproducibleMode(info)); err != nil {
+			return err
+		}
+
+		return os.Chtimes(p, ReproducibleModTime, ReproducibleModTime)
+	})
+}
+
+// reproducibleMode returns the permissions of the given file once packaged into an image, that only depend
+// on whether the file is a directory or an executable.
+func reproducibleMode(info os.FileInfo) os.FileMode {
+	if info.IsDir() || info.Mode().Perm()&0o111 != 0 {
+		return io.FilePerm755
+	}
+
+	return io.FilePerm644
 }
 
 func listPublishedImages(context *builderContext) ([]v1.IntegrationKitStatus, error) {
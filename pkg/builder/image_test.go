@@ -18,6 +18,8 @@ limitations under the License.
 package builder
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -542,3 +544,23 @@ func TestFindBestImageShouldIgnoreIntegrationArfifact(t *testing.T) {
 	assert.True(t, commonLibs["io.github.crac.org-crac-0.1.3.jar"])
 	assert.True(t, commonLibs["io.quarkus.quarkus-development-mode-spi-3.8.3.jar"])
 }
+
+func TestNormalizeContext(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "dependencies"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dependencies", "lib.jar"), []byte("lib"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "runner"), []byte("runner"), 0o700))
+
+	require.NoError(t, normalizeContext(dir))
+
+	for file, mode := range map[string]os.FileMode{
+		"dependencies":         os.ModeDir | 0o755,
+		"dependencies/lib.jar": 0o644,
+		"runner":               0o755,
+	} {
+		info, err := os.Stat(filepath.Join(dir, file))
+		require.NoError(t, err)
+		assert.Equal(t, mode, info.Mode(), file)
+		assert.True(t, ReproducibleModTime.Equal(info.ModTime()), file)
+	}
+}
@@ -123,6 +123,10 @@ func (t *jibTask) Do(ctx context.Context) v1.BuildStatus {
 		}
 	}
 
+	if err := signImage(ctx, t.c, t.build.Namespace, t.task.PublishTask, status.Digest); err != nil {
+		return status.Failed(err)
+	}
+
 	return *status
 }
 
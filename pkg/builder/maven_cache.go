@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"os"
+	"time"
+
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/offline"
+)
+
+// MavenCacheDir is the directory the persistent Maven cache is mounted into the builder pods.
+var MavenCacheDir = "/etc/maven/cache"
+
+// mavenCache returns the persistent Maven cache the build uses as local repository, or nil if the cache is not
+// configured or not mounted, e.g. when the build runs in the operator.
+func mavenCache(ctx *builderContext) *maven.Cache {
+	if ctx.Build.Maven.Cache == nil {
+		return nil
+	}
+	if info, err := os.Stat(MavenCacheDir); err != nil || !info.IsDir() {
+		return nil
+	}
+	holder, err := os.Hostname()
+	if err != nil || holder == "" {
+		holder = ctx.Build.Name
+	}
+	cache := maven.NewCache(MavenCacheDir, holder)
+
+	return &cache
+}
+
+// buildWithMavenCache runs the given Maven build with the persistent cache, if any. When the cache holds all the
+// dependencies of the project, the build runs offline with a shared lease on the cache, so that the builds of the
+// known dependencies do not wait for each other. Otherwise, or when the offline build fails, the build runs online
+// with an exclusive lease. The cache is then pruned to its maximum size.
+func buildWithMavenCache(ctx *builderContext, mc maven.Context, build func(maven.Context) error) error {
+	cache := mavenCache(ctx)
+	if cache == nil {
+		return build(mc)
+	}
+
+	if len(offline.Missing(cache.Dir, ctx.Maven.Project)) == 0 {
+		release, err := cache.Lock(ctx.C, false)
+		if err != nil {
+			return err
+		}
+		offlineContext := mc
+		offlineContext.AdditionalArguments = append([]string{"--offline"}, mc.AdditionalArguments...)
+		err = build(offlineContext)
+		release()
+		if err == nil {
+			touchMavenCache(cache, ctx.Maven.Project)
+			return nil
+		}
+		log.Infof("Offline build from the Maven cache failed, retrying online: %s", err.Error())
+	}
+
+	release, err := cache.Lock(ctx.C, true)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if err := build(mc); err != nil {
+		return err
+	}
+	touchMavenCache(cache, ctx.Maven.Project)
+
+	maxSize, err := ctx.Build.Maven.Cache.GetMaxSize()
+	if err != nil {
+		return err
+	}
+	evicted, err := cache.Prune(maxSize, time.Time{})
+	if err != nil {
+		return err
+	}
+	if len(evicted) > 0 {
+		log.Infof("Evicted %d artifacts from the Maven cache", len(evicted))
+	}
+
+	return nil
+}
+
+// touchMavenCache marks the artifacts resolved by the build of the project as used in the cache, i.e. the artifacts
+// of the dependency trees of the project and of its plugins, with their POMs, parents and imported BOMs.
+func touchMavenCache(cache *maven.Cache, project maven.Project) {
+	artifacts, _ := offline.Artifacts(cache.Dir, project)
+	cache.Touch(artifacts...)
+}
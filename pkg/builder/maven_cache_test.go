@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+func newMavenCacheContext(t *testing.T) *builderContext {
+	t.Helper()
+
+	dir := MavenCacheDir
+	MavenCacheDir = t.TempDir()
+	t.Cleanup(func() { MavenCacheDir = dir })
+
+	ctx := builderContext{
+		C:    context.TODO(),
+		Path: t.TempDir(),
+		Build: v1.BuilderTask{
+			Maven: v1.MavenBuildSpec{
+				MavenSpec: v1.MavenSpec{
+					Cache: &v1.MavenCacheSpec{PersistentVolumeClaim: "maven-cache", MaxSize: "1Mi"},
+				},
+			},
+		},
+	}
+	ctx.Maven.Project = maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	ctx.Maven.Project.AddDependencyGAV("org.acme", "lib", "1.0")
+
+	return &ctx
+}
+
+func TestMavenCacheOnlineBuild(t *testing.T) {
+	ctx := newMavenCacheContext(t)
+	assert.Equal(t, MavenCacheDir, newMavenContext(ctx).LocalRepository)
+
+	// An artifact above the maximum size of the cache, that is evicted after the build
+	stale := filepath.Join(MavenCacheDir, "org", "acme", "stale", "1.0")
+	require.NoError(t, os.MkdirAll(stale, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(stale, "stale-1.0.jar"), make([]byte, 2<<20), 0o600))
+
+	var builds [][]string
+	err := buildWithMavenCache(ctx, *newMavenContext(ctx), func(mc maven.Context) error {
+		builds = append(builds, mc.AdditionalArguments)
+		lib := filepath.Join(MavenCacheDir, "org", "acme", "lib", "1.0")
+		if err := os.MkdirAll(lib, os.ModePerm); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(lib, "lib-1.0.jar"), []byte("lib"), 0o600)
+	})
+	require.NoError(t, err)
+	require.Len(t, builds, 1)
+	assert.NotContains(t, builds[0], "--offline")
+	assert.NoDirExists(t, stale)
+	assert.FileExists(t, filepath.Join(MavenCacheDir, "org", "acme", "lib", "1.0", "lib-1.0.jar"))
+}
+
+func TestMavenCacheOfflineBuild(t *testing.T) {
+	ctx := newMavenCacheContext(t)
+	lib := filepath.Join(MavenCacheDir, "org", "acme", "lib", "1.0")
+	require.NoError(t, os.MkdirAll(lib, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(lib, "lib-1.0.jar"), []byte("lib"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(lib, "lib-1.0.pom"), []byte("<project/>"), 0o600))
+
+	var builds [][]string
+	err := buildWithMavenCache(ctx, *newMavenContext(ctx), func(mc maven.Context) error {
+		builds = append(builds, mc.AdditionalArguments)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, builds, 1)
+	assert.Contains(t, builds[0], "--offline")
+
+	// The build falls back online when it fails offline
+	builds = nil
+	err = buildWithMavenCache(ctx, *newMavenContext(ctx), func(mc maven.Context) error {
+		builds = append(builds, mc.AdditionalArguments)
+		if len(builds) == 1 {
+			return errors.New("missing transitive dependency")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, builds, 2)
+	assert.Contains(t, builds[0], "--offline")
+	assert.NotContains(t, builds[1], "--offline")
+}
+
+func TestMavenCacheNotMounted(t *testing.T) {
+	ctx := newMavenCacheContext(t)
+	MavenCacheDir = filepath.Join(MavenCacheDir, "missing")
+
+	assert.Nil(t, mavenCache(ctx))
+	calls := 0
+	err := buildWithMavenCache(ctx, *newMavenContext(ctx), func(mc maven.Context) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,376 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+)
+
+// OCILayoutDir is the directory the OCI image layout volume is mounted into the builder pods.
+var OCILayoutDir = "/etc/camel-k/oci-layout"
+
+const (
+	// ociLayoutRefNameAnnotation is the annotation holding the image reference in the index of an OCI image layout.
+	ociLayoutRefNameAnnotation = "org.opencontainers.image.ref.name"
+	// ociLayoutUser is the user the image runs as, as with the images built by the other publish strategies.
+	ociLayoutUser = "1000"
+)
+
+// OCILayoutPath returns the path, relative to the OCI image layout volume, the given image is written to.
+func OCILayoutPath(image string, format v1.OCILayoutFormat) string {
+	p := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(image)
+	if format != v1.OCILayoutFormatDirectory {
+		p += ".tar"
+	}
+	return p
+}
+
+type ociLayoutTask struct {
+	c     client.Client
+	build *v1.Build
+	task  *v1.OCILayoutTask
+}
+
+var _ Task = &ociLayoutTask{}
+
+func (t *ociLayoutTask) Do(ctx context.Context) v1.BuildStatus {
+	status := initializeStatusFrom(t.build.Status, t.task.BaseImage)
+
+	contextDir := t.task.ContextDir
+	if contextDir == "" {
+		// Use the working directory.
+		// This is useful when the task is executed in-container,
+		// so that its WorkingDir can be used to share state and
+		// coordinate with other tasks.
+		pwd, err := os.Getwd()
+		if err != nil {
+			return status.Failed(err)
+		}
+		contextDir = filepath.Join(pwd, ContextDir)
+	}
+
+	entries, err := os.ReadDir(contextDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return status.Failed(err)
+	}
+	emptyDir := len(entries) == 0
+
+	log.Debugf("Base image: %s", status.BaseImage)
+
+	tmpDir, err := os.MkdirTemp("", "oci-layout-")
+	if err != nil {
+		return status.Failed(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	base, err := t.baseImage(ctx, status.BaseImage, tmpDir)
+	if err != nil {
+		return status.Failed(fmt.Errorf("cannot read base image %s: %w", status.BaseImage, err))
+	}
+
+	img := base
+	if emptyDir {
+		// There are no more resources to add to the base image, which is still written to the OCI image layout, so
+		// that the image of the kit can be exported.
+		log.Infof("No new layer to add to the base image %s", status.BaseImage)
+	} else {
+		mediaType := types.DockerLayer
+		if mt, err := base.MediaType(); err == nil && mt == types.OCIManifestSchema1 {
+			mediaType = types.OCILayer
+		}
+		layerFile := filepath.Join(tmpDir, "layer.tar")
+		if err := writeLayer(contextDir, DeploymentDir, layerFile); err != nil {
+			return status.Failed(err)
+		}
+		layer, err := tarball.LayerFromFile(layerFile, tarball.WithMediaType(mediaType))
+		if err != nil {
+			return status.Failed(err)
+		}
+		if img, err = mutate.AppendLayers(base, layer); err != nil {
+			return status.Failed(err)
+		}
+	}
+	if img, err = withUser(img, ociLayoutUser); err != nil {
+		return status.Failed(err)
+	}
+
+	target := filepath.Join(OCILayoutDir, OCILayoutPath(t.task.Image, t.task.Format))
+	log.Infof("Writing image %s to OCI image layout %s", t.task.Image, target)
+	if err := writeOCILayout(img, t.task.Image, t.task.Format, target); err != nil {
+		return status.Failed(err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return status.Failed(err)
+	}
+	status.Image = t.task.Image
+	status.Digest = digest.String()
+
+	return *status
+}
+
+// baseImage returns the base image, from the OCI image layout volume when it has been built by a previous build, e.g.
+// for incremental builds, or from its registry otherwise.
+func (t *ociLayoutTask) baseImage(ctx context.Context, image string, tmpDir string) (containerv1.Image, error) {
+	for _, format := range []v1.OCILayoutFormat{v1.OCILayoutFormatDirectory, v1.OCILayoutFormatTarball} {
+		p := filepath.Join(OCILayoutDir, OCILayoutPath(image, format))
+		if _, err := os.Stat(p); err == nil {
+			log.Infof("Reading base image %s from OCI image layout %s", image, p)
+			return readOCILayout(p, format, filepath.Join(tmpDir, "base"))
+		}
+	}
+
+	var options []name.Option
+	if t.task.Registry.Insecure && t.task.Registry.Address != "" && strings.HasPrefix(image, t.task.Registry.Address) {
+		options = append(options, name.Insecure)
+	}
+	ref, err := name.ParseReference(image, options...)
+	if err != nil {
+		return nil, err
+	}
+	keychain, err := registry.NewKeychain(ctx, t.c, t.build.Namespace, t.task.Registry.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return remote.Image(ref,
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithPlatform(containerv1.Platform{OS: "linux", Architecture: runtime.GOARCH}))
+}
+
+// withUser returns the image running as the given user.
+func withUser(img containerv1.Image, user string) (containerv1.Image, error) {
+	cf, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	config := cf.Config
+	config.User = user
+
+	return mutate.Config(img, config)
+}
+
+// readOCILayout reads the single image of the OCI image layout at the given path, extracting it into the given
+// directory when it is a tarball.
+func readOCILayout(p string, format v1.OCILayoutFormat, dir string) (containerv1.Image, error) {
+	if format != v1.OCILayoutFormatDirectory {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if err := extractTar(f, dir); err != nil {
+			return nil, err
+		}
+		p = dir
+	}
+
+	index, err := layout.ImageIndexFromPath(p)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Manifests) == 0 {
+		return nil, fmt.Errorf("no image in OCI image layout %s", p)
+	}
+
+	return index.Image(manifest.Manifests[0].Digest)
+}
+
+// writeOCILayout writes the image to an OCI image layout at the given path, replacing any previous content once the
+// image is fully written.
+func writeOCILayout(img containerv1.Image, image string, format v1.OCILayoutFormat, p string) error {
+	tmp := p + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	dir := tmp
+	if format != v1.OCILayoutFormatDirectory {
+		var err error
+		if dir, err = os.MkdirTemp("", "oci-layout-"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	l, err := layout.Write(dir, empty.Index)
+	if err != nil {
+		return err
+	}
+	if err := l.AppendImage(img, layout.WithAnnotations(map[string]string{ociLayoutRefNameAnnotation: image})); err != nil {
+		return err
+	}
+
+	if format != v1.OCILayoutFormatDirectory {
+		f, err := os.Create(tmp)
+		if err != nil {
+			return err
+		}
+		if err := writeTar(f, dir, ""); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := os.RemoveAll(p); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, p)
+}
+
+// writeLayer writes the content of the given directory into a layer tarball, under the given directory of the image.
+func writeLayer(dir string, target string, layerFile string) error {
+	f, err := os.Create(layerFile)
+	if err != nil {
+		return err
+	}
+	if err := writeTar(f, dir, strings.TrimPrefix(target, "/")); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeTar writes the content of the given directory into a tar stream, with the entries prefixed by the given path.
+func writeTar(w io.Writer, dir string, prefix string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." && prefix == "" {
+			return nil
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(prefix, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		// Reproducible entries, that do not depend on when and by whom the files have been written
+		header.Mode = int64(reproducibleMode(info))
+		header.ModTime = ReproducibleModTime
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		header.Uid = 0
+		header.Gid = 0
+		header.Uname = ""
+		header.Gname = ""
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// extractTar extracts the tar stream into the given directory.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) && target != filepath.Clean(dir) {
+			return fmt.Errorf("invalid entry %s in archive", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil { //nolint:gosec
+				_ = f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestOCILayoutPath(t *testing.T) {
+	assert.Equal(t, "ns_camel-k-kit-123_42.tar", OCILayoutPath("ns/camel-k-kit-123:42", v1.OCILayoutFormatTarball))
+	assert.Equal(t, "ns_camel-k-kit-123_42.tar", OCILayoutPath("ns/camel-k-kit-123:42", ""))
+	assert.Equal(t, "registry_5000_ns_camel-k-kit-123_42",
+		OCILayoutPath("registry:5000/ns/camel-k-kit-123:42", v1.OCILayoutFormatDirectory))
+}
+
+func TestOCILayoutTask(t *testing.T) {
+	dir := OCILayoutDir
+	OCILayoutDir = t.TempDir()
+	defer func() { OCILayoutDir = dir }()
+
+	// The base image, written by a previous build
+	base := "ns/camel-k-kit-base:1"
+	require.NoError(t, writeOCILayout(empty.Image, base, v1.OCILayoutFormatDirectory,
+		filepath.Join(OCILayoutDir, OCILayoutPath(base, v1.OCILayoutFormatDirectory))))
+
+	contextDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(contextDir, "dependencies"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(contextDir, "dependencies", "lib.jar"), []byte("lib"), 0o600))
+
+	task := ociLayoutTask{
+		build: &v1.Build{},
+		task: &v1.OCILayoutTask{
+			BaseTask: v1.BaseTask{Name: "oci-layout"},
+			PublishTask: v1.PublishTask{
+				ContextDir: contextDir,
+				BaseImage:  base,
+				Image:      "ns/camel-k-kit-123:42",
+			},
+			Format: v1.OCILayoutFormatTarball,
+		},
+	}
+	status := task.Do(context.TODO())
+	require.Equal(t, v1.BuildPhaseNone, status.Phase, status.Error)
+	assert.Equal(t, "ns/camel-k-kit-123:42", status.Image)
+	assert.NotEmpty(t, status.Digest)
+
+	img, err := readOCILayout(filepath.Join(OCILayoutDir, "ns_camel-k-kit-123_42.tar"), v1.OCILayoutFormatTarball, t.TempDir())
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, status.Digest, digest.String())
+	cf, err := img.ConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, "1000", cf.Config.User)
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+
+	rc, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+	defer rc.Close()
+	var files []string
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		files = append(files, header.Name)
+	}
+	assert.Equal(t, []string{"deployments/", "deployments/dependencies/", "deployments/dependencies/lib.jar"}, files)
+}
+
+func TestOCILayoutTaskReproducible(t *testing.T) {
+	dir := OCILayoutDir
+	OCILayoutDir = t.TempDir()
+	defer func() { OCILayoutDir = dir }()
+
+	base := "ns/camel-k-kit-base:1"
+	require.NoError(t, writeOCILayout(empty.Image, base, v1.OCILayoutFormatDirectory,
+		filepath.Join(OCILayoutDir, OCILayoutPath(base, v1.OCILayoutFormatDirectory))))
+
+	contextDir := t.TempDir()
+	lib := filepath.Join(contextDir, "lib.jar")
+	require.NoError(t, os.WriteFile(lib, []byte("lib"), 0o600))
+
+	build := func(image string) string {
+		task := ociLayoutTask{
+			build: &v1.Build{},
+			task: &v1.OCILayoutTask{
+				BaseTask: v1.BaseTask{Name: "oci-layout"},
+				PublishTask: v1.PublishTask{
+					ContextDir: contextDir,
+					BaseImage:  base,
+					Image:      image,
+				},
+			},
+		}
+		status := task.Do(context.TODO())
+		require.Equal(t, v1.BuildPhaseNone, status.Phase, status.Error)
+		return status.Digest
+	}
+
+	digest := build("ns/camel-k-kit-123:1")
+	// The same file, written at another time with other permissions
+	require.NoError(t, os.Chmod(lib, 0o644))
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(lib, later, later))
+	assert.Equal(t, digest, build("ns/camel-k-kit-123:2"))
+}
+
+func TestOCILayoutTaskWithoutLayer(t *testing.T) {
+	dir := OCILayoutDir
+	OCILayoutDir = t.TempDir()
+	defer func() { OCILayoutDir = dir }()
+
+	base := "ns/camel-k-kit-base:1"
+	require.NoError(t, writeOCILayout(empty.Image, base, v1.OCILayoutFormatDirectory,
+		filepath.Join(OCILayoutDir, OCILayoutPath(base, v1.OCILayoutFormatDirectory))))
+
+	task := ociLayoutTask{
+		build: &v1.Build{},
+		task: &v1.OCILayoutTask{
+			BaseTask: v1.BaseTask{Name: "oci-layout"},
+			PublishTask: v1.PublishTask{
+				ContextDir: t.TempDir(),
+				BaseImage:  base,
+				Image:      "ns/camel-k-kit-123:42",
+			},
+			Format: v1.OCILayoutFormatDirectory,
+		},
+	}
+	status := task.Do(context.TODO())
+	require.Equal(t, v1.BuildPhaseNone, status.Phase, status.Error)
+	// The image of the kit is written to the OCI image layout even without new layer, so that it can be exported
+	assert.Equal(t, "ns/camel-k-kit-123:42", status.Image)
+
+	img, err := readOCILayout(filepath.Join(OCILayoutDir, "ns_camel-k-kit-123_42"), v1.OCILayoutFormatDirectory, t.TempDir())
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, status.Digest, digest.String())
+	cf, err := img.ConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, "1000", cf.Config.User)
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	assert.Empty(t, layers)
+}
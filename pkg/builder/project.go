@@ -33,6 +33,7 @@ import (
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/log"
 	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/offline"
 )
 
 func init() {
@@ -55,6 +56,7 @@ type projectSteps struct {
 	InjectDependencies      Step
 	SanitizeDependencies    Step
 	InjectProfiles          Step
+	VerifyOffline           Step
 
 	CommonSteps []Step
 }
@@ -67,6 +69,7 @@ var Project = projectSteps{
 	InjectDependencies:      NewStep(ProjectGenerationPhase+2, injectDependencies),
 	SanitizeDependencies:    NewStep(ProjectGenerationPhase+3, sanitizeDependencies),
 	InjectProfiles:          NewStep(ProjectGenerationPhase+4, injectProfiles),
+	VerifyOffline:           NewStep(ProjectGenerationPhase+5, verifyOfflineDependencies),
 }
 
 func cleanUpBuildDir(ctx *builderContext) error {
@@ -108,7 +111,24 @@ func generateProjectSettings(ctx *builderContext) error {
 		ctx.Maven.UserSettings = []byte(val)
 	}
 
-	settings, err := maven.NewSettings(maven.DefaultRepositories, maven.ProxyFromEnvironment)
+	options := []maven.SettingsOption{maven.DefaultRepositories, maven.ProxyFromEnvironment}
+	if ctx.Build.Maven.Offline != nil {
+		dir, err := resolveOfflineBundle(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.Maven.OfflineRepository = dir
+		options = append(options, maven.OfflineRepository(dir))
+		if ctx.Maven.UserSettings != nil {
+			userSettings, err := maven.OfflineUserSettings(ctx.Maven.UserSettings, dir)
+			if err != nil {
+				return err
+			}
+			ctx.Maven.UserSettings = userSettings
+		}
+	}
+
+	settings, err := maven.NewSettings(options...)
 	if err != nil {
 		return err
 	}
@@ -129,6 +149,39 @@ func generateProjectSettings(ctx *builderContext) error {
 	return nil
 }
 
+// resolveOfflineBundle returns the directory of the offline bundle of the build, making sure it has been produced
+// for the runtime of the build.
+func resolveOfflineBundle(ctx *builderContext) (string, error) {
+	dir, err := offline.Resolve(ctx.C, ctx.Client, ctx.Namespace, ctx.Registry, ctx.Build.Maven.Offline.Bundle)
+	if err != nil {
+		return "", err
+	}
+	manifest, err := offline.ReadManifest(dir)
+	if err != nil {
+		return "", err
+	}
+	if manifest != nil && manifest.RuntimeVersion != "" && manifest.RuntimeVersion != ctx.Build.Runtime.Version {
+		return "", fmt.Errorf("the offline bundle %s is for runtime version %s, but the build requires runtime version %s",
+			ctx.Build.Maven.Offline.Bundle, manifest.RuntimeVersion, ctx.Build.Runtime.Version)
+	}
+
+	return dir, nil
+}
+
+// verifyOfflineDependencies fails the build early, with the list of the missing coordinates, when the offline bundle
+// does not contain all the dependencies of the project.
+func verifyOfflineDependencies(ctx *builderContext) error {
+	if ctx.Maven.OfflineRepository == "" {
+		return nil
+	}
+	if missing := offline.Missing(ctx.Maven.OfflineRepository, ctx.Maven.Project); len(missing) > 0 {
+		return fmt.Errorf("the offline bundle %s is missing the dependencies: %s",
+			ctx.Build.Maven.Offline.Bundle, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
 func injectServersIntoMavenSettings(settings string, servers []v1.Server) string {
 	if len(servers) < 1 {
 		return settings
@@ -18,6 +18,8 @@ limitations under the License.
 package builder
 
 import (
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -34,6 +36,7 @@ import (
 	"github.com/apache/camel-k/v2/pkg/util"
 	"github.com/apache/camel-k/v2/pkg/util/camel"
 	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/offline"
 )
 
 const customSettings = `<?xml version="1.0" encoding="UTF-8"?>
@@ -496,3 +499,89 @@ func removeWhitespaces(s string) string {
 	re := regexp.MustCompile(`\s`)
 	return re.ReplaceAllString(s, "")
 }
+
+func TestMavenSettingsWithOfflineBundle(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	require.NoError(t, err)
+
+	bundle := t.TempDir()
+	require.NoError(t, offline.WriteManifest(bundle, offline.Manifest{RuntimeVersion: catalog.Runtime.Version}))
+	c, err := internal.NewFakeClient(
+		&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "maven-settings",
+			},
+			Data: map[string]string{
+				"settings.xml": "<settings><mirrors><mirror><id>internal</id><url>https://nexus.acme.org</url><mirrorOf>central</mirrorOf></mirror></mirrors></settings>",
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	ctx := builderContext{
+		Catalog:   catalog,
+		Client:    c,
+		Namespace: "ns",
+		Build: v1.BuilderTask{
+			Runtime: catalog.Runtime,
+			Maven: v1.MavenBuildSpec{
+				MavenSpec: v1.MavenSpec{
+					Offline: &v1.MavenOfflineSpec{Bundle: bundle},
+					Settings: v1.ValueSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "maven-settings",
+							},
+							Key: "settings.xml",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = Project.GenerateProjectSettings.execute(&ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, bundle, ctx.Maven.OfflineRepository)
+	assert.Contains(t, string(ctx.Maven.GlobalSettings), "<url>file://"+bundle+"</url>")
+	// The mirrors of the user settings do not preempt the offline bundle
+	assert.Contains(t, string(ctx.Maven.UserSettings), "<url>file://"+bundle+"</url>")
+	assert.NotContains(t, string(ctx.Maven.UserSettings), "nexus.acme.org")
+	assert.True(t, newMavenContext(&ctx).Offline)
+
+	require.NoError(t, offline.WriteManifest(bundle, offline.Manifest{RuntimeVersion: "1.0.0"}))
+	err = Project.GenerateProjectSettings.execute(&ctx)
+	require.EqualError(t, err, "the offline bundle "+bundle+" is for runtime version 1.0.0, but the build requires runtime version "+
+		catalog.Runtime.Version)
+}
+
+func TestVerifyOfflineDependencies(t *testing.T) {
+	bundle := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(bundle, "org", "apache", "camel", "quarkus", "camel-quarkus-timer", "3.15.0"), os.ModePerm))
+
+	ctx := builderContext{
+		Build: v1.BuilderTask{
+			Maven: v1.MavenBuildSpec{
+				MavenSpec: v1.MavenSpec{
+					Offline: &v1.MavenOfflineSpec{Bundle: "oci://registry.acme.org/bundle:1.0"},
+				},
+			},
+		},
+	}
+	ctx.Maven.OfflineRepository = bundle
+	ctx.Maven.Project = maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0")
+	ctx.Maven.Project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-timer", "")
+	require.NoError(t, Project.VerifyOffline.execute(&ctx))
+
+	ctx.Maven.Project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-kafka", "")
+	ctx.Maven.Project.AddDependencyGAV("org.acme", "lib", "1.0")
+	err := Project.VerifyOffline.execute(&ctx)
+	require.EqualError(t, err, "the offline bundle oci://registry.acme.org/bundle:1.0 is missing the dependencies: "+
+		"org.acme:lib:1.0, org.apache.camel.quarkus:camel-quarkus-kafka")
+}
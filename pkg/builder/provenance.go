@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+)
+
+// provenanceInputs are the inputs of a build the image depends on, digested into the provenance attestation.
+type provenanceInputs struct {
+	BuilderImage    string             `json:"builderImage"`
+	RuntimeVersion  string             `json:"runtimeVersion"`
+	RuntimeProvider v1.RuntimeProvider `json:"runtimeProvider"`
+	BaseImage       string             `json:"baseImage"`
+	BaseImageDigest string             `json:"baseImageDigest"`
+	Dependencies    []string           `json:"dependencies"`
+	Sources         []v1.SourceSpec    `json:"sources"`
+	Maven           v1.MavenBuildSpec  `json:"maven"`
+	Git             *v1.GitConfigSpec  `json:"git"`
+	GitCommit       string             `json:"gitCommit"`
+	Hooks           []v1.BuildHook     `json:"hooks,omitempty"`
+}
+
+// newProvenance returns the provenance attestation of the image built by the given builder task, once its steps
+// have been executed, i.e. once its base image and Git commit are resolved. The image built is not known yet, and is
+// added as the subject of the attestation once it has been published.
+func newProvenance(build *v1.Build, ctx *builderContext) (*v1.Provenance, error) {
+	dependencies := make([]string, len(ctx.Build.Dependencies))
+	copy(dependencies, ctx.Build.Dependencies)
+	sort.Strings(dependencies)
+
+	builderImage := ""
+	if configuration := build.BuilderConfiguration(); configuration != nil {
+		builderImage = configuration.ToolImage
+	}
+
+	inputs := provenanceInputs{
+		BuilderImage:    builderImage,
+		RuntimeVersion:  ctx.Build.Runtime.Version,
+		RuntimeProvider: ctx.Build.Runtime.Provider,
+		BaseImage:       ctx.BaseImage,
+		BaseImageDigest: resolveBaseImageDigest(build, ctx),
+		Dependencies:    dependencies,
+		Sources:         ctx.Build.Sources,
+		Maven:           ctx.Build.Maven,
+		Git:             ctx.Build.Git,
+		GitCommit:       ctx.GitCommit,
+		Hooks:           ctx.Build.Hooks,
+	}
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+
+	return &v1.Provenance{
+		BuilderImage:    inputs.BuilderImage,
+		RuntimeVersion:  inputs.RuntimeVersion,
+		RuntimeProvider: inputs.RuntimeProvider,
+		BaseImage:       inputs.BaseImage,
+		BaseImageDigest: inputs.BaseImageDigest,
+		Dependencies:    inputs.Dependencies,
+		GitCommit:       inputs.GitCommit,
+		InputsDigest:    "sha256:" + hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// resolveBaseImageDigest returns the digest of the base image, from its reference when it is addressed by digest, or
+// from the registry it is pulled from otherwise. The base image is resolved with the credentials of the registry the
+// image is published to. It returns an empty digest, when the base image cannot be resolved, e.g. when it is read
+// from an OCI image layout.
+func resolveBaseImageDigest(build *v1.Build, ctx *builderContext) string {
+	if ctx.BaseImage == "" {
+		return ""
+	}
+
+	reg := publishRegistry(build)
+	var options []name.Option
+	if reg.Insecure && reg.Address != "" && strings.HasPrefix(ctx.BaseImage, reg.Address) {
+		options = append(options, name.Insecure)
+	}
+	ref, err := name.ParseReference(ctx.BaseImage, options...)
+	if err != nil {
+		log.Infof("Unable to resolve the digest of base image %s: %s", ctx.BaseImage, err.Error())
+		return ""
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr()
+	}
+
+	keychain, err := registry.NewKeychain(ctx.C, ctx.Client, build.Namespace, reg.Secret)
+	if err != nil {
+		log.Infof("Unable to resolve the digest of base image %s: %s", ctx.BaseImage, err.Error())
+		return ""
+	}
+	desc, err := remote.Head(ref, remote.WithContext(ctx.C), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		log.Infof("Unable to resolve the digest of base image %s: %s", ctx.BaseImage, err.Error())
+		return ""
+	}
+
+	return desc.Digest.String()
+}
+
+// publishRegistry returns the registry the image of the given build is published to.
+func publishRegistry(build *v1.Build) v1.RegistrySpec {
+	for _, task := range build.Spec.Tasks {
+		switch {
+		case task.Spectrum != nil:
+			return task.Spectrum.Registry
+		case task.Jib != nil:
+			return task.Jib.Registry
+		case task.OCILayout != nil:
+			return task.OCILayout.Registry
+		case task.S2i != nil:
+			return task.S2i.Registry
+		}
+	}
+
+	return v1.RegistrySpec{}
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestNewProvenance(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	baseImage := strings.TrimPrefix(server.URL, "http://") + "/eclipse-temurin:17"
+	ref, err := name.ParseReference(baseImage)
+	require.NoError(t, err)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	baseImageDigest, err := img.Digest()
+	require.NoError(t, err)
+
+	build := &v1.Build{
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{
+							Name:          "builder",
+							Configuration: v1.BuildConfiguration{ToolImage: "camel-k-operator:2.5.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+	newContext := func(dependencies ...string) *builderContext {
+		return &builderContext{
+			C:         context.TODO(),
+			BaseImage: baseImage,
+			Build: v1.BuilderTask{
+				Runtime:      v1.RuntimeSpec{Version: "3.8.1", Provider: v1.RuntimeProviderQuarkus},
+				Dependencies: dependencies,
+			},
+		}
+	}
+
+	provenance, err := newProvenance(build, newContext("camel:timer", "camel:log"))
+	require.NoError(t, err)
+	assert.Equal(t, "camel-k-operator:2.5.0", provenance.BuilderImage)
+	assert.Equal(t, "3.8.1", provenance.RuntimeVersion)
+	assert.Equal(t, v1.RuntimeProviderQuarkus, provenance.RuntimeProvider)
+	assert.Equal(t, baseImage, provenance.BaseImage)
+	assert.Equal(t, baseImageDigest.String(), provenance.BaseImageDigest)
+	assert.Empty(t, provenance.Image)
+	assert.Equal(t, []string{"camel:log", "camel:timer"}, provenance.Dependencies)
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", provenance.InputsDigest)
+
+	// The same inputs, in another order
+	same, err := newProvenance(build, newContext("camel:log", "camel:timer"))
+	require.NoError(t, err)
+	assert.Equal(t, provenance, same)
+
+	other, err := newProvenance(build, newContext("camel:log", "camel:timer", "camel:http"))
+	require.NoError(t, err)
+	assert.NotEqual(t, provenance.InputsDigest, other.InputsDigest)
+
+	// The base image is updated under the same tag
+	img, err = random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	updated, err := newProvenance(build, newContext("camel:log", "camel:timer"))
+	require.NoError(t, err)
+	assert.NotEqual(t, provenance.BaseImageDigest, updated.BaseImageDigest)
+	assert.NotEqual(t, provenance.InputsDigest, updated.InputsDigest)
+}
+
+func TestResolveBaseImageDigest(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	build := &v1.Build{}
+
+	// The base image is addressed by its digest
+	assert.Equal(t, digest, resolveBaseImageDigest(build, &builderContext{
+		C:         context.TODO(),
+		BaseImage: "registry:5000/ns/camel-k-kit-abc@" + digest,
+	}))
+
+	// The base image cannot be resolved
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	assert.Empty(t, resolveBaseImageDigest(build, &builderContext{
+		C:         context.TODO(),
+		BaseImage: strings.TrimPrefix(server.URL, "http://") + "/missing:1",
+	}))
+}
@@ -22,8 +22,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
-	"time"
 
 	"github.com/apache/camel-k/v2/pkg/util/boolean"
 	"github.com/apache/camel-k/v2/pkg/util/jib"
@@ -39,6 +39,10 @@ import (
 
 const projectModePerm = 0600
 
+// reproducibleBuildTimestamp is the timestamp of the entries of the archives built by Maven, fixed so that
+// the same project always gives the same archives. It is the lowest timestamp supported by the zip format.
+const reproducibleBuildTimestamp = "1980-01-01T00:00:02Z"
+
 func init() {
 	registerSteps(Quarkus)
 
@@ -51,24 +55,26 @@ func init() {
 }
 
 type quarkusSteps struct {
-	LoadCamelQuarkusCatalog    Step
-	GenerateQuarkusProject     Step
-	BuildQuarkusMavenContext   Step
-	BuildQuarkusMavenProject   Step
-	ComputeQuarkusDependencies Step
-	PrepareProjectWithSources  Step
+	LoadCamelQuarkusCatalog      Step
+	GenerateQuarkusProject       Step
+	BuildQuarkusMavenContext     Step
+	BuildQuarkusMavenProject     Step
+	ComputeQuarkusDependencies   Step
+	ComputeQuarkusDependencyTree Step
+	PrepareProjectWithSources    Step
 
 	CommonSteps []Step
 }
 
 //nolint:mnd
 var Quarkus = quarkusSteps{
-	LoadCamelQuarkusCatalog:    NewStep(InitPhase, loadCamelQuarkusCatalog),
-	GenerateQuarkusProject:     NewStep(ProjectGenerationPhase, generateQuarkusProject),
-	BuildQuarkusMavenContext:   NewStep(ProjectGenerationPhase+1, buildMavenContextSettings),
-	PrepareProjectWithSources:  NewStep(ProjectBuildPhase-1, prepareProjectWithSources),
-	BuildQuarkusMavenProject:   NewStep(ProjectBuildPhase+2, buildMavenProject),
-	ComputeQuarkusDependencies: NewStep(ProjectBuildPhase+1, computeQuarkusDependencies),
+	LoadCamelQuarkusCatalog:      NewStep(InitPhase, loadCamelQuarkusCatalog),
+	GenerateQuarkusProject:       NewStep(ProjectGenerationPhase, generateQuarkusProject),
+	BuildQuarkusMavenContext:     NewStep(ProjectGenerationPhase+1, buildMavenContextSettings),
+	PrepareProjectWithSources:    NewStep(ProjectBuildPhase-1, prepareProjectWithSources),
+	BuildQuarkusMavenProject:     NewStep(ProjectBuildPhase+2, buildMavenProject),
+	ComputeQuarkusDependencies:   NewStep(ProjectBuildPhase+1, computeQuarkusDependencies),
+	ComputeQuarkusDependencyTree: NewStep(ProjectBuildPhase+3, computeQuarkusDependencyTree),
 }
 
 func prepareProjectWithSources(ctx *builderContext) error {
@@ -160,7 +166,7 @@ func generateQuarkusProjectCommon(runtimeProvider v1.RuntimeProvider, runtimeVer
 	// set fast-jar packaging by default, since it gives some startup time improvements
 	p.Properties.Add("quarkus.package.jar.type", "fast-jar")
 	// Reproducible builds: https://maven.apache.org/guides/mini/guide-reproducible-builds.html
-	p.Properties.Add("project.build.outputTimestamp", time.Now().Format(time.RFC3339))
+	p.Properties.Add("project.build.outputTimestamp", reproducibleBuildTimestamp)
 	// DependencyManagement
 	if runtimeProvider == v1.RuntimeProviderPlainQuarkus {
 		p.DependencyManagement.Dependencies = append(p.DependencyManagement.Dependencies,
@@ -215,10 +221,27 @@ func generateQuarkusProjectCommon(runtimeProvider v1.RuntimeProvider, runtimeVer
 	return p
 }
 
+// GenerateQuarkusProjectWithDependencies generates the Maven project of a Quarkus application with the given
+// dependencies, the same way the builder does, e.g. to resolve the dependencies ahead of the builds.
+func GenerateQuarkusProjectWithDependencies(catalog *camel.RuntimeCatalog, dependencies []string) (maven.Project, error) {
+	p := generateQuarkusProjectCommon(
+		catalog.Runtime.Provider,
+		catalog.Runtime.Version,
+		catalog.Runtime.Metadata["quarkus.version"],
+	)
+	if err := camel.ManageIntegrationDependencies(&p, dependencies, catalog); err != nil {
+		return p, err
+	}
+
+	return p, camel.SanitizeIntegrationDependencies(p.Dependencies)
+}
+
 func buildMavenProject(ctx *builderContext) error {
 	mc := newMavenContext(ctx)
 
-	return BuildQuarkusRunnerCommon(ctx.C, *mc, ctx.Maven.Project, ctx.Build.Maven.Properties)
+	return buildWithMavenCache(ctx, *mc, func(mc maven.Context) error {
+		return BuildQuarkusRunnerCommon(ctx.C, mc, ctx.Maven.Project, ctx.Build.Maven.Properties)
+	})
 }
 
 func BuildQuarkusRunnerCommon(ctx context.Context, mc maven.Context, project maven.Project, applicationProperties map[string]string) error {
@@ -273,9 +296,14 @@ func computeApplicationProperties(appPropertiesPath string, applicationPropertie
 			return err
 		}
 	}
-	// Fill with properties coming from user configuration
-	for k, v := range applicationProperties {
-		if _, err := f.WriteString(fmt.Sprintf("%s=%s\n", k, v)); err != nil {
+	// Fill with properties coming from user configuration, sorted so that the file is reproducible
+	keys := make([]string, 0, len(applicationProperties))
+	for k := range applicationProperties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err := f.WriteString(fmt.Sprintf("%s=%s\n", k, applicationProperties[k])); err != nil {
 			return err
 		}
 	}
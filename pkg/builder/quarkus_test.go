@@ -42,6 +42,7 @@ func TestGenerateQuarkusProjectCommon(t *testing.T) {
 	assert.Equal(t, "camel-k-integration", p.ArtifactID)
 	assert.Equal(t, defaults.Version, p.Version)
 	assert.Equal(t, "fast-jar", p.Properties["quarkus.package.jar.type"])
+	assert.Equal(t, "1980-01-01T00:00:02Z", p.Properties["project.build.outputTimestamp"])
 	assert.Equal(t, "org.apache.camel.k", p.DependencyManagement.Dependencies[0].GroupID)
 	assert.Equal(t, "camel-k-runtime-bom", p.DependencyManagement.Dependencies[0].ArtifactID)
 	assert.Equal(t, "1.2.3", p.DependencyManagement.Dependencies[0].Version)
@@ -52,6 +53,18 @@ func TestGenerateQuarkusProjectCommon(t *testing.T) {
 	assert.Equal(t, "4.5.6", p.Build.Plugins[0].Version)
 }
 
+func TestComputeApplicationPropertiesSorted(t *testing.T) {
+	appPropertiesPath := filepath.Join(t.TempDir(), "application.properties")
+	require.NoError(t, computeApplicationProperties(appPropertiesPath, map[string]string{
+		"camel.b": "2",
+		"camel.a": "1",
+	}))
+
+	appProps, err := os.ReadFile(appPropertiesPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(appProps), "camel.a=1\ncamel.b=2\nquarkus."))
+}
+
 func TestLoadCamelQuarkusCatalogMissing(t *testing.T) {
 	c, err := internal.NewFakeClient()
 	require.NoError(t, err)
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+	"github.com/apache/camel-k/v2/pkg/util/signature"
+)
+
+// signImage signs the published image with the given digest, with the private key of the signing Secret, if any.
+func signImage(ctx context.Context, c client.Client, namespace string, task v1.PublishTask, digest string) error {
+	if task.Signing == nil {
+		return nil
+	}
+	digest = strings.TrimSpace(digest)
+	if digest == "" {
+		return fmt.Errorf("cannot sign image %s: unknown digest", task.Image)
+	}
+
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, task.Signing.Secret, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot read the signing secret %s: %w", task.Signing.Secret, err)
+	}
+	key, err := signature.ParsePrivateKey(secret.Data[signature.PrivateKeyEntry], secret.Data[signature.PasswordEntry])
+	if err != nil {
+		return fmt.Errorf("cannot read the private key of the signing secret %s: %w", task.Signing.Secret, err)
+	}
+
+	var opts []name.Option
+	if task.Registry.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(task.Image, opts...)
+	if err != nil {
+		return err
+	}
+	keychain, err := registry.NewKeychain(ctx, c, namespace, task.Registry.Secret)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Signing image %s@%s", ref.Context().Name(), digest)
+	if err := signature.Sign(ref.Context().Digest(digest), key,
+		remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)); err != nil {
+		return fmt.Errorf("cannot sign image %s: %w", task.Image, err)
+	}
+
+	return nil
+}
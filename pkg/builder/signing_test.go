@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+	"github.com/apache/camel-k/v2/pkg/util/signature"
+)
+
+func TestSignImage(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+	image := address + "/ns/camel-k-kit:1"
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	c, err := internal.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cosign"},
+		Data: map[string][]byte{
+			signature.PrivateKeyEntry: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+		},
+	})
+	require.NoError(t, err)
+
+	task := v1.PublishTask{
+		Image:    image,
+		Registry: v1.RegistrySpec{Address: address, Insecure: true},
+	}
+	// Nothing to do without signing
+	require.NoError(t, signImage(context.TODO(), c, "ns", task, digest.String()))
+	_, err = signature.Verify(ref, &key.PublicKey)
+	require.ErrorIs(t, err, signature.ErrUnsigned)
+
+	task.Signing = &v1.ImageSigningSpec{Secret: "cosign"}
+	require.NoError(t, signImage(context.TODO(), c, "ns", task, digest.String()+"\n"))
+	_, err = signature.Verify(ref, &key.PublicKey)
+	require.NoError(t, err)
+
+	task.Signing.Secret = "missing"
+	require.Error(t, signImage(context.TODO(), c, "ns", task, digest.String()))
+}
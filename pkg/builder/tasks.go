@@ -73,6 +73,12 @@ func (b *Build) Task(task v1.Task) Task {
 			build: b.build,
 			task:  task.Jib,
 		}
+	case task.OCILayout != nil:
+		return &ociLayoutTask{
+			c:     b.builder.client,
+			build: b.build,
+			task:  task.OCILayout,
+		}
 	}
 
 	return &emptyTask{
@@ -158,6 +164,12 @@ func (b *Build) TaskByName(name string) Task {
 				build: b.build,
 				task:  task.Jib,
 			}
+		case task.OCILayout != nil && task.OCILayout.Name == name:
+			return &ociLayoutTask{
+				c:     b.builder.client,
+				build: b.build,
+				task:  task.OCILayout,
+			}
 		}
 	}
 	return &missingTask{
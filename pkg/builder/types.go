@@ -23,6 +23,7 @@ import (
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/v2/pkg/client"
 	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/gradle"
 	"github.com/apache/camel-k/v2/pkg/util/log"
 	"github.com/apache/camel-k/v2/pkg/util/maven"
 )
@@ -75,14 +76,18 @@ type resource struct {
 //nolint:containedctx
 type builderContext struct {
 	client.Client
-	C                 context.Context
-	Catalog           *camel.RuntimeCatalog
-	Build             v1.BuilderTask
-	BaseImage         string
+	C         context.Context
+	Catalog   *camel.RuntimeCatalog
+	Build     v1.BuilderTask
+	BaseImage string
+	// Registry is the registry the image of the build is published to.
+	Registry          v1.RegistrySpec
 	Namespace         string
 	Path              string
+	GitCommit         string
 	Artifacts         []v1.Artifact
 	SelectedArtifacts []v1.Artifact
+	DependencyTree    []v1.DependencyTreeNode
 	Resources         []resource
 	Maven             struct {
 		Project          maven.Project
@@ -91,5 +96,13 @@ type builderContext struct {
 		SettingsSecurity []byte
 		TrustStoreName   string
 		TrustStorePass   string
+		// OfflineRepository is the directory of the offline bundle the dependencies are resolved from, if any.
+		OfflineRepository string
+		// PomGenerated is set once the pom.xml has been generated ahead of the build, e.g. to be patched by a hook.
+		PomGenerated bool
+	}
+	Gradle struct {
+		Project gradle.Project
+		Context gradle.Context
 	}
 }
@@ -89,7 +89,7 @@ func TestBindOutputUnknownFormat(t *testing.T) {
 	output, _ := ExecuteCommand(bindCmd, cmdBind, "my:src", "my:dst", "-o", "fail")
 	assert.Equal(t, "fail", buildCmdOptions.OutputFormat)
 
-	assert.Equal(t, "invalid output format option 'fail', should be one of: yaml|json\n", output)
+	assert.Equal(t, "invalid output format option 'fail', should be one of: yaml|json|name\n", output)
 }
 
 func TestBindErrorHandlerDLCKamelet(t *testing.T) {
@@ -59,6 +59,11 @@ func newCmdConfig(rootCmdOptions *RootCmdOptions) (*cobra.Command, *configCmdOpt
 	cmd.Flags().String("folder", "used", "The type of folder containing the configuration file to read/write. The supported values are 'env', 'home', 'sub', 'working' and 'used' for respectively $KAMEL_CONFIG_PATH, $HOME/.kamel, .kamel, . and the folder used by kamel")
 	cmd.Flags().String("default-namespace", "", "The name of the namespace to use by default")
 	cmd.Flags().BoolP("list", "l", false, "List all existing settings")
+
+	cmd.AddCommand(cmdOnly(newCmdConfigUseContext(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newCmdConfigGetContexts(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newCmdConfigSetContext(rootCmdOptions)))
+
 	return &cmd, &options
 }
 
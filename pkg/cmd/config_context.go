@@ -0,0 +1,289 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// ContextsConfigKey is the node of the kamel configuration file holding the named contexts.
+	ContextsConfigKey = "kamel.contexts"
+	// CurrentContextConfigKey is the kamel configuration key holding the name of the context in use.
+	CurrentContextConfigKey = "kamel.config.current-context"
+	// DefaultNamespaceConfigKey is the kamel configuration key holding the default namespace.
+	DefaultNamespaceConfigKey = "kamel.config.default-namespace"
+
+	contextNamespace  = "namespace"
+	contextOperatorID = "operator-id"
+	contextTraits     = "traits"
+)
+
+const configContextLongDescription = `Named contexts hold the settings of an environment in the kamel configuration file, e.g.:
+
+kamel:
+  config:
+    current-context: staging
+  contexts:
+    staging:
+      namespace: integrations-staging
+      operator-id: camel-k-staging
+      traits:
+      - logging.level=DEBUG
+      run:
+        properties:
+        - env=staging
+
+The namespace and the operator id apply to every command, the traits are added to the ones of the run, bind
+and kit create commands, and any other entry provides default flags for the command of the same name.
+The current context can be overridden with the --context flag, and flags always take precedence over contexts.
+`
+
+func newCmdConfigUseContext(rootCmdOptions *RootCmdOptions) (*cobra.Command, *configContextCmdOptions) {
+	options := configContextCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:         "use-context [name]",
+		Short:       "Set the current context of the kamel configuration file",
+		Long:        configContextLongDescription,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{offlineCommandLabel: "true"},
+		RunE:        options.useContext,
+	}
+
+	return &cmd, &options
+}
+
+func newCmdConfigGetContexts(rootCmdOptions *RootCmdOptions) (*cobra.Command, *configContextCmdOptions) {
+	options := configContextCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:         "get-contexts",
+		Short:       "List the contexts of the kamel configuration file",
+		Long:        configContextLongDescription,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{offlineCommandLabel: "true"},
+		RunE:        options.getContexts,
+	}
+
+	return &cmd, &options
+}
+
+func newCmdConfigSetContext(rootCmdOptions *RootCmdOptions) (*cobra.Command, *configContextCmdOptions) {
+	options := configContextCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:         "set-context [name]",
+		Short:       "Create or update a context of the kamel configuration file",
+		Long:        configContextLongDescription,
+		Example:     "kamel config set-context prod -n integrations -x camel-k-prod -t jvm.options=-Xmx1g",
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{offlineCommandLabel: "true"},
+		PreRunE:     decode(&options, options.Flags),
+		RunE:        options.setContext,
+	}
+
+	cmd.Flags().StringP("operator-id", "x", "", "Operator id used by the commands run in the context")
+	cmd.Flags().StringArrayP("trait", "t", nil, "Default trait configuration of the context. E.g. \"-t service.enabled=false\"")
+
+	return &cmd, &options
+}
+
+type configContextCmdOptions struct {
+	*RootCmdOptions
+	OperatorID string   `mapstructure:"operator-id"`
+	Traits     []string `mapstructure:"traits"`
+}
+
+func (o *configContextCmdOptions) load() (*Config, error) {
+	return LoadConfigurationFrom(o.Flags.ConfigFileUsed())
+}
+
+func (o *configContextCmdOptions) useContext(cmd *cobra.Command, args []string) error {
+	cfg, err := o.load()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	if cfg.Get(ContextsConfigKey+"."+name) == nil {
+		return fmt.Errorf("context %s not found in %s", name, cfg.location)
+	}
+	cfg.SetValue(CurrentContextConfigKey, name)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %s\n", name)
+
+	return nil
+}
+
+func (o *configContextCmdOptions) getContexts(cmd *cobra.Command, _ []string) error {
+	cfg, err := o.load()
+	if err != nil {
+		return err
+	}
+	contexts := cast.ToStringMap(cfg.Get(ContextsConfigKey))
+	if len(contexts) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No contexts could be found in %s\n", cfg.location)
+		return nil
+	}
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	current := cast.ToString(cfg.Get(CurrentContextConfigKey))
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tNAMESPACE\tOPERATOR ID\tTRAITS")
+	for _, name := range names {
+		settings := cast.ToStringMap(contexts[name])
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, name, cast.ToString(settings[contextNamespace]),
+			cast.ToString(settings[contextOperatorID]), strings.Join(cast.ToStringSlice(settings[contextTraits]), ","))
+	}
+
+	return w.Flush()
+}
+
+func (o *configContextCmdOptions) setContext(cmd *cobra.Command, args []string) error {
+	cfg, err := o.load()
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	node := ContextsConfigKey + "." + name
+	cfg.Merge(cmd, node, o, true)
+	// The namespace is the global flag, which is not resolved for offline commands
+	if namespace := cmd.Flag("namespace").Value.String(); namespace != "" {
+		cfg.SetValue(node+"."+contextNamespace, namespace)
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Context %s saved in %s\n", name, cfg.location)
+
+	return nil
+}
+
+// withConfigContext makes the persistent pre-run hooks of the command hierarchy apply the configuration context
+// first, so that it is taken into account by all the commands, including those decoding their options in a
+// persistent pre-run hook. Cobra only runs the closest hook, so the context is applied once.
+func withConfigContext(cmd *cobra.Command, v *viper.Viper) {
+	if hook := cmd.PersistentPreRunE; hook != nil {
+		cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+			if err := applyConfigContext(c, v); err != nil {
+				return err
+			}
+			return hook(c, args)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		withConfigContext(sub, v)
+	}
+}
+
+// applyConfigContext overlays the settings of the context selected with the --context flag, or of the current
+// context, on the kamel configuration, so that they take precedence over the global settings of the configuration
+// file, but not over flags and environment variables.
+func applyConfigContext(cmd *cobra.Command, v *viper.Viper) error {
+	explicit := ""
+	if flag := cmd.Flag("context"); flag != nil {
+		explicit = flag.Value.String()
+	}
+	name := explicit
+	if name == "" {
+		name = v.GetString(CurrentContextConfigKey)
+	}
+	if name == "" {
+		return nil
+	}
+	key := ContextsConfigKey + "." + name
+	if !v.IsSet(key) {
+		if explicit != "" {
+			return fmt.Errorf("context %s not found in the kamel configuration", name)
+		}
+		// Do not prevent from fixing the configuration with a broken current context
+		fmt.Fprintf(cmd.ErrOrStderr(), "Current context %s not found in the kamel configuration\n", name)
+		return nil
+	}
+
+	path := pathToRoot(cmd)
+	settings := v.GetStringMap(key)
+	defaults := make(map[string]interface{})
+	commands := make(map[string]interface{})
+	for k, v := range settings {
+		switch k {
+		case contextNamespace:
+			setConfigValue(defaults, DefaultNamespaceConfigKey, v)
+		case contextOperatorID:
+			if cmd.Flags().Lookup("operator-id") != nil {
+				setConfigValue(defaults, path+".operator-id", v)
+			}
+		case contextTraits:
+			// handled below, as they are combined with the ones of the command
+		default:
+			commands[k] = v
+		}
+	}
+	// The default flags of a command in the context win over the context wide settings
+	for _, overlay := range []map[string]interface{}{defaults, {"kamel": commands}} {
+		if err := v.MergeConfigMap(overlay); err != nil {
+			return err
+		}
+	}
+
+	if traits := cast.ToStringSlice(settings[contextTraits]); len(traits) > 0 && cmd.Flags().Lookup("trait") != nil {
+		// The traits of the context are defaults, that the ones of the command can override
+		var commandTraits []string
+		for _, t := range v.GetStringSlice(path + "." + contextTraits) {
+			if t != "" {
+				commandTraits = append(commandTraits, t)
+			}
+		}
+		v.Set(path+"."+contextTraits, mergeTraits(traits, commandTraits))
+	}
+
+	return nil
+}
+
+func setConfigValue(settings map[string]interface{}, path string, value interface{}) {
+	nodes := strings.Split(path, ".")
+	for _, node := range nodes[:len(nodes)-1] {
+		child, ok := settings[node].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			settings[node] = child
+		}
+		settings = child
+	}
+	settings[nodes[len(nodes)-1]] = value
+}
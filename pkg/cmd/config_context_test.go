@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const contextsConfig = `kamel:
+  config:
+    current-context: dev
+  run:
+    properties:
+    - global=true
+  contexts:
+    dev:
+      namespace: dev-ns
+    staging:
+      namespace: staging-ns
+      operator-id: camel-k-staging
+      traits:
+      - logging.level=DEBUG
+      run:
+        properties:
+        - env=staging
+`
+
+func writeContextsConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	file := filepath.Join(dir, DefaultConfigLocation)
+	require.NoError(t, os.WriteFile(file, []byte(contextsConfig), 0o600))
+	t.Setenv("KAMEL_CONFIG_PATH", dir)
+	return file
+}
+
+func TestRunWithContext(t *testing.T) {
+	writeContextsConfig(t)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+
+	_, err := ExecuteCommand(rootCmd, "run", "route.java", "--context", "staging", "-t", "service.enabled=false")
+	require.NoError(t, err)
+	assert.Equal(t, "camel-k-staging", runCmdOptions.OperatorID)
+	assert.Equal(t, []string{"logging.level=DEBUG", "service.enabled=false"}, runCmdOptions.Traits)
+	assert.Equal(t, []string{"env=staging"}, runCmdOptions.Properties)
+	assert.Equal(t, "staging-ns", rootCmd.Flag("namespace").Value.String())
+}
+
+func TestRunWithContextOverriddenTrait(t *testing.T) {
+	writeContextsConfig(t)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+
+	_, err := ExecuteCommand(rootCmd, "run", "route.java", "--context", "staging", "-t", "logging.level=INFO")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logging.level=INFO"}, runCmdOptions.Traits)
+}
+
+func TestRunWithCurrentContext(t *testing.T) {
+	writeContextsConfig(t)
+	runCmdOptions, rootCmd, _ := initializeRunCmdOptions(t)
+
+	_, err := ExecuteCommand(rootCmd, "run", "route.java", "-x", "my-op")
+	require.NoError(t, err)
+	assert.Equal(t, "my-op", runCmdOptions.OperatorID)
+	assert.Equal(t, []string{"global=true"}, runCmdOptions.Properties)
+	assert.Equal(t, "dev-ns", rootCmd.Flag("namespace").Value.String())
+}
+
+func TestRunWithUnknownContext(t *testing.T) {
+	writeContextsConfig(t)
+	_, rootCmd, _ := initializeRunCmdOptions(t)
+
+	_, err := ExecuteCommand(rootCmd, "run", "route.java", "--context", "prod")
+	require.EqualError(t, err, "context prod not found in the kamel configuration")
+}
+
+func TestConfigContexts(t *testing.T) {
+	file := writeContextsConfig(t)
+	_, rootCmd, _ := initializeConfigCmdOptions(t, false)
+
+	output, err := ExecuteCommand(rootCmd, cmdConfig, "set-context", "prod", "-n", "prod-ns", "-x", "camel-k-prod", "-t", "jvm.options=-Xmx1g")
+	require.NoError(t, err)
+	assert.Equal(t, "Context prod saved in "+file+"\n", output)
+
+	output, err = ExecuteCommand(rootCmd, cmdConfig, "use-context", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "Switched to context prod\n", output)
+
+	_, err = ExecuteCommand(rootCmd, cmdConfig, "use-context", "missing")
+	require.Error(t, err)
+
+	output, err = ExecuteCommand(rootCmd, cmdConfig, "get-contexts")
+	require.NoError(t, err)
+	assert.Regexp(t, `\*\s+prod\s+prod-ns\s+camel-k-prod\s+jvm.options=-Xmx1g\n`, output)
+	assert.Regexp(t, `\n\s+staging\s+staging-ns\s+camel-k-staging\s+logging.level=DEBUG\n`, output)
+
+	cfg, err := LoadConfigurationFrom(file)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.Get(CurrentContextConfigKey))
+	assert.NotNil(t, cfg.Get("kamel.run"))
+}
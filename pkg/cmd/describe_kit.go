@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -28,11 +30,12 @@ import (
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/v2/pkg/util/indentedwriter"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
 )
 
 func newDescribeKitCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *describeKitCommandOptions) {
 	options := describeKitCommandOptions{
-		rootCmdOptions,
+		RootCmdOptions: rootCmdOptions,
 	}
 
 	cmd := cobra.Command{
@@ -54,11 +57,16 @@ func newDescribeKitCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *describ
 		},
 	}
 
+	cmd.Flags().BoolVar(&options.dependencies, "dependencies", false, "Print the resolved dependencies of the kit and their version conflicts")
+	cmd.Flags().BoolVar(&options.tree, "tree", false, "Print the resolved dependencies as a tree (implies --dependencies)")
+
 	return &cmd, &options
 }
 
 type describeKitCommandOptions struct {
 	*RootCmdOptions
+	dependencies bool `mapstructure:"dependencies"`
+	tree         bool `mapstructure:"tree"`
 }
 
 func (command *describeKitCommandOptions) validate(_ *cobra.Command, args []string) error {
@@ -80,8 +88,13 @@ func (command *describeKitCommandOptions) run(cmd *cobra.Command, args []string)
 		Name:      args[0],
 	}
 
+	describe := command.describeIntegrationKit
+	if command.dependencies || command.tree {
+		describe = command.describeIntegrationKitDependencies
+	}
+
 	if err := c.Get(command.Context, kitKey, kit); err == nil {
-		if desc, err := command.describeIntegrationKit(cmd, kit); err == nil {
+		if desc, err := describe(cmd, kit); err == nil {
 			fmt.Fprint(cmd.OutOrStdout(), desc)
 		} else {
 			fmt.Fprintln(cmd.ErrOrStderr(), err)
@@ -136,3 +149,93 @@ func (command *describeKitCommandOptions) describeIntegrationKit(cmd *cobra.Comm
 		return describeTraits(w, kit.Spec.Traits)
 	})
 }
+
+func (command *describeKitCommandOptions) describeIntegrationKitDependencies(_ *cobra.Command, kit *v1.IntegrationKit) (string, error) {
+	tree := kit.Status.DependencyTree
+	if len(tree) == 0 {
+		return "", fmt.Errorf("no resolved dependency tree available for IntegrationKit '%s'", kit.Name)
+	}
+
+	// the resolved dependencies, by groupId:artifactId
+	resolved := make(map[string]v1.DependencyTreeNode)
+	for _, node := range tree {
+		if node.ConflictsWith == "" {
+			resolved[maven.DependencyGroupArtifact(node.ID)] = node
+		}
+	}
+
+	return indentedwriter.IndentedString(func(out io.Writer) error {
+		w := indentedwriter.NewWriter(out)
+
+		w.Writef(0, "Dependencies:\n")
+		if command.tree {
+			for _, node := range tree {
+				w.Writef(node.Depth+1, "%s\n", describeDependencyNode(node))
+			}
+		} else {
+			ids := make([]string, 0, len(resolved))
+			nodes := make(map[string]v1.DependencyTreeNode, len(resolved))
+			for _, node := range resolved {
+				ids = append(ids, node.ID)
+				nodes[node.ID] = node
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				w.Writef(1, "%s\n", describeDependencyNode(nodes[id]))
+			}
+		}
+
+		// the conflicts, with the dependency requiring the omitted version
+		parents := make([]string, 0)
+		conflicts := make([]string, 0)
+		for _, node := range tree {
+			depth := min(node.Depth, len(parents))
+			parents = append(parents[:depth], node.ID)
+			if node.ConflictsWith == "" {
+				continue
+			}
+			conflict := fmt.Sprintf("%s resolved to %s", node.ID, node.ConflictsWith)
+			if depth > 0 {
+				conflict = fmt.Sprintf("%s required by %s, resolved to %s", node.ID, parents[depth-1], node.ConflictsWith)
+			}
+			if winner, ok := resolved[maven.DependencyGroupArtifact(node.ID)]; ok && winner.Pinned {
+				conflict += ", pinned by the user"
+			}
+			conflicts = append(conflicts, conflict)
+		}
+		if len(conflicts) > 0 {
+			w.Writef(0, "Conflicts:\n")
+			for _, conflict := range conflicts {
+				w.Writef(1, "%s\n", conflict)
+			}
+		}
+
+		return nil
+	})
+}
+
+func describeDependencyNode(node v1.DependencyTreeNode) string {
+	notes := make([]string, 0)
+	if node.Scope != "" {
+		notes = append(notes, node.Scope)
+	}
+	if node.Pinned {
+		notes = append(notes, "pinned")
+	}
+	if node.ManagedFrom != "" {
+		notes = append(notes, "managed from "+node.ManagedFrom)
+	}
+	if node.ConflictsWith != "" {
+		notes = append(notes, "omitted for conflict with "+node.ConflictsWith)
+	}
+
+	desc := node.ID
+	if len(notes) > 0 {
+		desc += " (" + strings.Join(notes, ", ") + ")"
+	}
+	if node.ConflictsWith != "" {
+		desc += " [CONFLICT]"
+	}
+
+	return desc
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+func initializeDescribeKitCmd(t *testing.T, objs ...runtime.Object) (*cobra.Command, *describeKitCommandOptions) {
+	t.Helper()
+
+	fakeClient, err := internal.NewFakeClient(objs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	describeCmd, describeOptions := newDescribeKitCmd(options)
+	rootCmd.AddCommand(describeCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, describeOptions
+}
+
+func newDescribeKitTestKit() *v1.IntegrationKit {
+	kit := v1.NewIntegrationKit("default", "my-kit")
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	kit.Status.DependencyTree = []v1.DependencyTreeNode{
+		{ID: "org.apache.camel.k:camel-k-runtime:jar:3.8.1", Scope: "compile"},
+		{ID: "org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0", Depth: 1, Scope: "compile", ManagedFrom: "3.7.0"},
+		{ID: "com.fasterxml.jackson.core:jackson-core:jar:2.16.1", Depth: 2, Scope: "compile", ConflictsWith: "2.17.0"},
+		{ID: "com.fasterxml.jackson.core:jackson-core:jar:2.17.0", Scope: "compile", Pinned: true},
+	}
+
+	return kit
+}
+
+func TestDescribeKitDependenciesTree(t *testing.T) {
+	rootCmd, options := initializeDescribeKitCmd(t, newDescribeKitTestKit())
+	output, err := ExecuteCommand(rootCmd, "kit", "my-kit", "--dependencies", "--tree")
+	require.NoError(t, err)
+	assert.True(t, options.dependencies)
+	assert.True(t, options.tree)
+	assert.Contains(t, output, `Dependencies:
+  org.apache.camel.k:camel-k-runtime:jar:3.8.1 (compile)
+    org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0 (compile, managed from 3.7.0)
+      com.fasterxml.jackson.core:jackson-core:jar:2.16.1 (compile, omitted for conflict with 2.17.0) [CONFLICT]
+  com.fasterxml.jackson.core:jackson-core:jar:2.17.0 (compile, pinned)
+Conflicts:
+  com.fasterxml.jackson.core:jackson-core:jar:2.16.1 required by org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0, resolved to 2.17.0, pinned by the user
+`)
+}
+
+func TestDescribeKitDependencies(t *testing.T) {
+	rootCmd, _ := initializeDescribeKitCmd(t, newDescribeKitTestKit())
+	output, err := ExecuteCommand(rootCmd, "kit", "my-kit", "--dependencies")
+	require.NoError(t, err)
+	assert.Contains(t, output, `Dependencies:
+  com.fasterxml.jackson.core:jackson-core:jar:2.17.0 (compile, pinned)
+  org.apache.camel.k:camel-k-runtime:jar:3.8.1 (compile)
+  org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0 (compile, managed from 3.7.0)
+Conflicts:
+`)
+}
+
+func TestDescribeKitDependenciesNoTree(t *testing.T) {
+	kit := v1.NewIntegrationKit("default", "my-kit")
+	rootCmd, _ := initializeDescribeKitCmd(t, kit)
+	output, err := ExecuteCommand(rootCmd, "kit", "my-kit", "--tree")
+	require.NoError(t, err)
+	assert.Contains(t, output, "no resolved dependency tree available for IntegrationKit 'my-kit'")
+}
@@ -0,0 +1,602 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/resources"
+	"github.com/apache/camel-k/v2/pkg/util/knative"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+)
+
+const (
+	doctorPass = "PASS"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+
+	doctorDefaultServiceAccount = "camel-k-operator"
+	doctorMaxMissingFields      = 5
+)
+
+// doctorCRDs are the custom resource definitions required by the operator.
+var doctorCRDs = []struct {
+	kind     string
+	resource string
+}{
+	{"IntegrationPlatform", "integrationplatforms"},
+	{"IntegrationProfile", "integrationprofiles"},
+	{"IntegrationKit", "integrationkits"},
+	{"Integration", "integrations"},
+	{"CamelCatalog", "camelcatalogs"},
+	{"Build", "builds"},
+	{"Kamelet", "kamelets"},
+	{"Pipe", "pipes"},
+}
+
+// doctorPermissions are the permissions the operator requires in the namespace to build and run integrations.
+var doctorPermissions = []struct {
+	group    string
+	resource string
+	verbs    []string
+}{
+	{v1.SchemeGroupVersion.Group, "integrations", []string{"get", "list", "watch", "update", "patch"}},
+	{v1.SchemeGroupVersion.Group, "integrationkits", []string{"get", "list", "watch", "create", "update", "patch"}},
+	{v1.SchemeGroupVersion.Group, "integrationplatforms", []string{"get", "list", "watch", "update", "patch"}},
+	{v1.SchemeGroupVersion.Group, "builds", []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+	{v1.SchemeGroupVersion.Group, "pipes", []string{"get", "list", "watch", "update", "patch"}},
+	{v1.SchemeGroupVersion.Group, "kamelets", []string{"get", "list", "watch"}},
+	{"", "pods", []string{"get", "list", "watch", "create", "delete"}},
+	{"", "configmaps", []string{"get", "list", "watch", "create", "update", "delete"}},
+	{"", "secrets", []string{"get", "list", "watch"}},
+	{"", "services", []string{"get", "list", "watch", "create", "update", "delete"}},
+	{"", "events", []string{"create"}},
+	{"apps", "deployments", []string{"get", "list", "watch", "create", "update", "delete"}},
+	{"batch", "cronjobs", []string{"get", "list", "watch", "create", "update", "delete"}},
+}
+
+func newCmdDoctor(rootCmdOptions *RootCmdOptions) (*cobra.Command, *doctorCmdOptions) {
+	options := doctorCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "doctor",
+		Short: "Check if the namespace is able to build and run integrations",
+		Long: `Run a set of preflight checks on the cluster and on the IntegrationPlatform of the namespace:
+the installed CRDs, the operator permissions, the container registry, the Maven repositories,
+the availability of Knative and Strimzi and the IntegrationPlatform status.
+Each check is reported as PASS, WARN or FAIL, with an hint to fix the problem. The command fails if any check fails.`,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	cmd.Flags().String("operator-namespace", "", "The namespace of the operator, defaults to the current namespace")
+	cmd.Flags().Bool("skip-registry", false, "Skip the container registry checks")
+	cmd.Flags().Bool("skip-maven", false, "Skip the Maven repositories checks")
+	cmd.Flags().Duration("timeout", 10*time.Second, "The timeout of the network checks")
+
+	return &cmd, &options
+}
+
+type doctorCmdOptions struct {
+	*RootCmdOptions
+	OperatorNamespace string        `mapstructure:"operator-namespace" yaml:",omitempty"`
+	SkipRegistry      bool          `mapstructure:"skip-registry" yaml:",omitempty"`
+	SkipMaven         bool          `mapstructure:"skip-maven" yaml:",omitempty"`
+	Timeout           time.Duration `mapstructure:"timeout" yaml:",omitempty"`
+}
+
+// doctorResult is the outcome of a single check.
+type doctorResult struct {
+	Check   string
+	Status  string
+	Message string
+	Hint    string
+}
+
+func checkPassed(check string, format string, args ...interface{}) doctorResult {
+	return doctorResult{Check: check, Status: doctorPass, Message: fmt.Sprintf(format, args...)}
+}
+
+func checkWarning(check string, hint string, format string, args ...interface{}) doctorResult {
+	return doctorResult{Check: check, Status: doctorWarn, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func checkFailed(check string, hint string, format string, args ...interface{}) doctorResult {
+	return doctorResult{Check: check, Status: doctorFail, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func (o *doctorCmdOptions) run(cmd *cobra.Command, _ []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	operatorNamespace := o.OperatorNamespace
+	if operatorNamespace == "" {
+		operatorNamespace = o.Namespace
+	}
+
+	var results []doctorResult
+	results = append(results, o.checkCRDs(c)...)
+	results = append(results, o.checkOperator(c, operatorNamespace)...)
+
+	p, err := platform.GetOrFindLocal(o.Context, c, o.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	results = append(results, o.checkPlatform(p)...)
+	if p != nil {
+		if !o.SkipRegistry {
+			results = append(results, o.checkRegistry(c, p)...)
+		}
+		if !o.SkipMaven {
+			results = append(results, o.checkMaven(c, p)...)
+		}
+	}
+	results = append(results, o.checkOptionalComponents(c)...)
+
+	return printDoctorReport(cmd.OutOrStdout(), results)
+}
+
+func printDoctorReport(out io.Writer, results []doctorResult) error {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		fmt.Fprintf(out, "[%s] %s: %s\n", r.Status, r.Check, r.Message)
+		if r.Hint != "" && r.Status != doctorPass {
+			fmt.Fprintf(out, "       hint: %s\n", r.Hint)
+		}
+	}
+	fmt.Fprintf(out, "\n%d passed, %d warnings, %d failed\n", counts[doctorPass], counts[doctorWarn], counts[doctorFail])
+
+	if counts[doctorFail] > 0 {
+		return fmt.Errorf("%d checks failed", counts[doctorFail])
+	}
+	return nil
+}
+
+// checkCRDs verifies that the CRDs are installed, serve the v1 version and are not older than the ones shipped
+// with this client.
+func (o *doctorCmdOptions) checkCRDs(c client.Client) []doctorResult {
+	if err := apiextensionsv1.AddToScheme(c.GetScheme()); err != nil {
+		return []doctorResult{checkFailed("CRDs", "", "%v", err)}
+	}
+
+	results := make([]doctorResult, 0, len(doctorCRDs))
+	for _, crd := range doctorCRDs {
+		crdName := crd.resource + "." + v1.SchemeGroupVersion.Group
+		check := "CRD " + crd.kind
+		installed := apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(o.Context, ctrl.ObjectKey{Name: crdName}, &installed); err != nil {
+			if k8serrors.IsNotFound(err) {
+				results = append(results, checkFailed(check, "install the CRDs with `kamel install`, Helm or OLM", "%s not found", crdName))
+				continue
+			}
+			if k8serrors.IsForbidden(err) {
+				results = append(results, checkWarning(check, "run the command with a user allowed to read CustomResourceDefinitions", "cannot read %s: %v", crdName, err))
+				continue
+			}
+			results = append(results, checkFailed(check, "", "cannot read %s: %v", crdName, err))
+			continue
+		}
+		version := crdVersion(&installed, v1.SchemeGroupVersion.Version)
+		if version == nil || !version.Served || !version.Storage {
+			results = append(results, checkFailed(check, "upgrade the CRDs to the version of the operator",
+				"%s does not serve and store version %s", crdName, v1.SchemeGroupVersion.Version))
+			continue
+		}
+		missing, err := missingCRDFields(c, crd.resource, version)
+		if err != nil {
+			results = append(results, checkWarning(check, "", "cannot compare %s with the expected schema: %v", crdName, err))
+			continue
+		}
+		if len(missing) > 0 {
+			if len(missing) > doctorMaxMissingFields {
+				missing = append(missing[:doctorMaxMissingFields], "...")
+			}
+			results = append(results, checkWarning(check, "the CRDs are older than this client, upgrade them together with the operator",
+				"%s is missing the fields %s", crdName, strings.Join(missing, ", ")))
+			continue
+		}
+		results = append(results, checkPassed(check, "%s installed", crdName))
+	}
+
+	return results
+}
+
+func crdVersion(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
+// missingCRDFields returns the schema fields of the CRD shipped with the client which are not present in the
+// installed version.
+func missingCRDFields(c client.Client, resource string, installed *apiextensionsv1.CustomResourceDefinitionVersion) ([]string, error) {
+	content, err := resources.ResourceAsString(fmt.Sprintf("/config/crd/bases/%s_%s.yaml", v1.SchemeGroupVersion.Group, resource))
+	if err != nil {
+		return nil, err
+	}
+	obj, err := kubernetes.LoadResourceFromYaml(c.GetScheme(), content)
+	if err != nil {
+		return nil, err
+	}
+	expected, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+	if !ok {
+		return nil, fmt.Errorf("unexpected resource %T", obj)
+	}
+	version := crdVersion(expected, installed.Name)
+	if version == nil || version.Schema == nil || installed.Schema == nil {
+		return nil, nil
+	}
+
+	expectedFields := make(map[string]bool)
+	schemaFields("", version.Schema.OpenAPIV3Schema, expectedFields)
+	installedFields := make(map[string]bool)
+	schemaFields("", installed.Schema.OpenAPIV3Schema, installedFields)
+
+	var missing []string
+	for f := range expectedFields {
+		if !installedFields[f] {
+			missing = append(missing, f)
+		}
+	}
+	sort.Strings(missing)
+
+	return missing, nil
+}
+
+func schemaFields(prefix string, schema *apiextensionsv1.JSONSchemaProps, fields map[string]bool) {
+	if schema == nil {
+		return
+	}
+	for k := range schema.Properties {
+		p := schema.Properties[k]
+		field := prefix + "." + k
+		fields[field] = true
+		schemaFields(field, &p, fields)
+	}
+	if schema.Items != nil {
+		schemaFields(prefix+"[]", schema.Items.Schema, fields)
+	}
+}
+
+// checkOperator verifies that the operator is running and it has the permissions required in the namespace.
+func (o *doctorCmdOptions) checkOperator(c client.Client, operatorNamespace string) []doctorResult {
+	const check = "Operator"
+	serviceAccount := doctorDefaultServiceAccount
+
+	var results []doctorResult
+	pod := platform.GetOperatorPod(o.Context, c, operatorNamespace)
+	switch {
+	case pod == nil:
+		results = append(results, checkFailed(check, "install the operator, or set --operator-namespace to the namespace where it is installed",
+			"no operator pod found in namespace %s", operatorNamespace))
+	case !kubernetes.IsPodReady(pod):
+		results = append(results, checkFailed(check, fmt.Sprintf("check the operator logs with `kubectl logs -n %s %s`", pod.Namespace, pod.Name),
+			"operator pod %s is not ready (%s)", pod.Name, pod.Status.Phase))
+	default:
+		results = append(results, checkPassed(check, "operator pod %s is ready", pod.Name))
+	}
+	if pod != nil && pod.Spec.ServiceAccountName != "" {
+		serviceAccount = pod.Spec.ServiceAccountName
+	}
+
+	return append(results, o.checkPermissions(c, operatorNamespace, serviceAccount)...)
+}
+
+func (o *doctorCmdOptions) checkPermissions(c client.Client, operatorNamespace string, serviceAccount string) []doctorResult {
+	const check = "Operator RBAC"
+	var denied []string
+	for _, p := range doctorPermissions {
+		for _, verb := range p.verbs {
+			allowed, err := kubernetes.CheckServiceAccountPermission(o.Context, c, operatorNamespace, serviceAccount, p.group, p.resource, o.Namespace, verb)
+			if err != nil {
+				return []doctorResult{checkWarning(check, "run the command with a user allowed to create SubjectAccessReviews",
+					"cannot verify the permissions of service account %s/%s: %v", operatorNamespace, serviceAccount, err)}
+			}
+			if !allowed {
+				resource := p.resource
+				if p.group != "" {
+					resource += "." + p.group
+				}
+				denied = append(denied, verb+" "+resource)
+			}
+		}
+	}
+	if len(denied) > 0 {
+		return []doctorResult{checkFailed(check, "reinstall the operator as cluster-admin, or grant the missing permissions to the operator service account",
+			"service account %s/%s cannot %s in namespace %s", operatorNamespace, serviceAccount, strings.Join(denied, ", "), o.Namespace)}
+	}
+
+	return []doctorResult{checkPassed(check, "service account %s/%s has the required permissions in namespace %s", operatorNamespace, serviceAccount, o.Namespace)}
+}
+
+// checkPlatform verifies that the IntegrationPlatform is ready and has no failing conditions.
+func (o *doctorCmdOptions) checkPlatform(p *v1.IntegrationPlatform) []doctorResult {
+	const check = "IntegrationPlatform"
+	if p == nil {
+		return []doctorResult{checkFailed(check, "create an IntegrationPlatform, e.g. with `kamel install` or by applying the IntegrationPlatform custom resource",
+			"no IntegrationPlatform found in namespace %s", o.Namespace)}
+	}
+
+	var results []doctorResult
+	switch p.Status.Phase {
+	case v1.IntegrationPlatformPhaseReady:
+		results = append(results, checkPassed(check, "%s is %s", p.Name, p.Status.Phase))
+	case v1.IntegrationPlatformPhaseError:
+		results = append(results, checkFailed(check, fmt.Sprintf("inspect the platform with `kamel describe platform %s`", p.Name),
+			"%s is in phase %s", p.Name, p.Status.Phase))
+	default:
+		results = append(results, checkWarning(check, "wait for the operator to reconcile the platform, or check the operator logs",
+			"%s is in phase %q", p.Name, p.Status.Phase))
+	}
+	for _, condition := range p.Status.Conditions {
+		if condition.Status == corev1.ConditionFalse {
+			results = append(results, checkWarning(check+" condition "+string(condition.Type), condition.Message,
+				"%s=%s (%s)", condition.Type, condition.Status, condition.Reason))
+		}
+	}
+
+	return results
+}
+
+// checkRegistry verifies that the platform registry is reachable and writable with the configured secret.
+func (o *doctorCmdOptions) checkRegistry(c client.Client, p *v1.IntegrationPlatform) []doctorResult {
+	const check = "Registry"
+	reg := p.Status.Build.Registry
+	if reg.Address == "" {
+		if p.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyS2I {
+			return []doctorResult{checkPassed(check, "images are published with S2I to the OpenShift internal registry")}
+		}
+		return []doctorResult{checkFailed(check, "set the registry address in the IntegrationPlatform .spec.build.registry.address",
+			"no container registry configured")}
+	}
+
+	auth := authn.Anonymous
+	if reg.Secret != "" {
+		a, err := o.registryAuth(c, reg)
+		if err != nil {
+			return []doctorResult{checkFailed(check, fmt.Sprintf("make sure the secret %s exists and contains a valid docker config", reg.Secret),
+				"cannot read the registry secret: %v", err)}
+		}
+		auth = a
+	}
+
+	repository := reg.Address
+	if reg.Organization != "" {
+		repository += "/" + reg.Organization
+	}
+	var opts []name.Option
+	if reg.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(repository+"/camel-k-doctor:latest", opts...)
+	if err != nil {
+		return []doctorResult{checkFailed(check, "fix the registry address and organization in the IntegrationPlatform", "invalid registry address %s: %v", repository, err)}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if reg.Insecure {
+		// nolint: gosec
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	ctx, cancel := context.WithTimeout(o.Context, o.Timeout)
+	defer cancel()
+	if err := remote.CheckPushPermission(ref, staticKeychain{auth}, &contextTransport{ctx: ctx, rt: transport}); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || isNetworkError(err) {
+			return []doctorResult{checkWarning(check, "the registry may be reachable only from within the cluster, check the build logs if the builds fail to push",
+				"registry %s is not reachable from this machine: %v", reg.Address, err)}
+		}
+		return []doctorResult{checkFailed(check, "check the registry credentials in secret "+reg.Secret+" and the organization permissions",
+			"cannot push to %s: %v", repository, err)}
+	}
+
+	return []doctorResult{checkPassed(check, "%s is reachable and writable", repository)}
+}
+
+func (o *doctorCmdOptions) registryAuth(c client.Client, reg v1.RegistrySpec) (authn.Authenticator, error) {
+	secret, err := c.CoreV1().Secrets(o.Namespace).Get(o.Context, reg.Secret, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no %s key", reg.Secret, corev1.DockerConfigJsonKey)
+	}
+	config := registry.DockerConfigList{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	for server, auth := range config.Auths {
+		if !strings.Contains(server, strings.Split(reg.Address, "/")[0]) {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, err
+			}
+			user, password, _ := strings.Cut(string(decoded), ":")
+			return &authn.Basic{Username: user, Password: password}, nil
+		}
+		return &authn.Basic{Username: auth.Username, Password: auth.Password}, nil
+	}
+
+	return nil, fmt.Errorf("secret %s has no credentials for %s", reg.Secret, reg.Address)
+}
+
+// checkMaven verifies that the Maven settings are valid and the configured repositories are reachable.
+func (o *doctorCmdOptions) checkMaven(c client.Client, p *v1.IntegrationPlatform) []doctorResult {
+	const check = "Maven"
+	mvn := p.Status.Build.Maven
+	settings := maven.Settings{}
+	if mvn.Settings.ConfigMapKeyRef != nil || mvn.Settings.SecretKeyRef != nil {
+		content, err := kubernetes.ResolveValueSource(o.Context, c, p.Namespace, &mvn.Settings)
+		if err != nil {
+			return []doctorResult{checkFailed(check, "make sure the Maven settings ConfigMap or Secret exists", "cannot read the Maven settings: %v", err)}
+		}
+		if err := xml.Unmarshal([]byte(content), &settings); err != nil {
+			return []doctorResult{checkFailed(check, "fix the Maven settings.xml syntax", "invalid Maven settings: %v", err)}
+		}
+	} else {
+		defaults, err := maven.NewSettings(maven.DefaultRepositories)
+		if err != nil {
+			return []doctorResult{checkFailed(check, "", "%v", err)}
+		}
+		settings = defaults
+	}
+
+	results := []doctorResult{checkPassed(check, "Maven settings are valid")}
+	for _, url := range mavenRepositoryURLs(settings) {
+		if err := o.checkURL(url); err != nil {
+			results = append(results, checkWarning("Maven repository", "make sure the repository is reachable from the cluster, or configure a mirror in the Maven settings",
+				"%s is not reachable from this machine: %v", url, err))
+			continue
+		}
+		results = append(results, checkPassed("Maven repository", "%s is reachable", url))
+	}
+
+	return results
+}
+
+// mavenRepositoryURLs returns the URLs of the repositories used by the build, that are the mirrors when defined.
+func mavenRepositoryURLs(settings maven.Settings) []string {
+	var urls []string
+	if len(settings.Mirrors) > 0 {
+		for _, m := range settings.Mirrors {
+			urls = append(urls, m.URL)
+		}
+		return urls
+	}
+	for _, p := range settings.Profiles {
+		if p.Repositories == nil {
+			continue
+		}
+		for _, r := range *p.Repositories {
+			urls = append(urls, r.URL)
+		}
+	}
+	return urls
+}
+
+func (o *doctorCmdOptions) checkURL(url string) error {
+	ctx, cancel := context.WithTimeout(o.Context, o.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Any response but a server error means the repository is reachable, possibly requiring authentication
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// checkOptionalComponents reports the availability of the optional components integrations can use.
+func (o *doctorCmdOptions) checkOptionalComponents(c client.Client) []doctorResult {
+	var results []doctorResult
+
+	if ok, err := knative.IsServingInstalled(c); err != nil {
+		results = append(results, checkWarning("Knative Serving", "", "cannot discover Knative Serving: %v", err))
+	} else if ok {
+		results = append(results, checkPassed("Knative Serving", "installed"))
+	} else {
+		results = append(results, checkWarning("Knative Serving", "install Knative Serving to run integrations as Knative services",
+			"not installed"))
+	}
+	if ok, err := knative.IsEventingInstalled(c); err != nil {
+		results = append(results, checkWarning("Knative Eventing", "", "cannot discover Knative Eventing: %v", err))
+	} else if ok {
+		results = append(results, checkPassed("Knative Eventing", "installed"))
+	} else {
+		results = append(results, checkWarning("Knative Eventing", "install Knative Eventing to bind integrations to Knative brokers and channels",
+			"not installed"))
+	}
+	if ok, err := kubernetes.IsAPIResourceInstalled(c, "kafka.strimzi.io/v1beta2", "Kafka"); err != nil {
+		results = append(results, checkWarning("Strimzi", "", "cannot discover Strimzi: %v", err))
+	} else if ok {
+		results = append(results, checkPassed("Strimzi", "installed"))
+	} else {
+		results = append(results, checkWarning("Strimzi", "install Strimzi to bind pipes to Kafka topics using their Kubernetes references",
+			"not installed"))
+	}
+
+	return results
+}
+
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// staticKeychain resolves the same authenticator for every registry.
+type staticKeychain struct {
+	auth authn.Authenticator
+}
+
+func (k staticKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return k.auth, nil
+}
+
+// contextTransport bounds the registry requests to the context deadline.
+type contextTransport struct {
+	ctx context.Context
+	rt  http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.rt.RoundTrip(req.WithContext(t.ctx))
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+const cmdDoctor = "doctor"
+
+// nolint: unparam
+func initializeDoctorCmdOptions(t *testing.T) (*doctorCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	doctorCmdOptions := addTestDoctorCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return doctorCmdOptions, rootCmd, *options
+}
+
+func addTestDoctorCmd(options RootCmdOptions, rootCmd *cobra.Command) *doctorCmdOptions {
+	// add a testing version of doctor Command
+	doctorCmd, doctorOptions := newCmdDoctor(&options)
+	doctorCmd.RunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	doctorCmd.PostRunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	doctorCmd.Args = ArbitraryArgs
+	rootCmd.AddCommand(doctorCmd)
+	return doctorOptions
+}
+
+func TestDoctorFlags(t *testing.T) {
+	doctorCmdOptions, rootCmd, _ := initializeDoctorCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdDoctor, "--operator-namespace", "camel-k", "--skip-registry", "--skip-maven", "--timeout", "3s")
+	require.NoError(t, err)
+	assert.Equal(t, "camel-k", doctorCmdOptions.OperatorNamespace)
+	assert.True(t, doctorCmdOptions.SkipRegistry)
+	assert.True(t, doctorCmdOptions.SkipMaven)
+	assert.Equal(t, 3*time.Second, doctorCmdOptions.Timeout)
+}
+
+func TestDoctorCheckPlatform(t *testing.T) {
+	doctorCmdOptions, _, _ := initializeDoctorCmdOptions(t)
+	doctorCmdOptions.Namespace = "default"
+
+	results := doctorCmdOptions.checkPlatform(nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, doctorFail, results[0].Status)
+	assert.NotEmpty(t, results[0].Hint)
+
+	p := v1.IntegrationPlatform{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "camel-k"},
+		Status: v1.IntegrationPlatformStatus{
+			Phase: v1.IntegrationPlatformPhaseReady,
+			Conditions: []v1.IntegrationPlatformCondition{
+				{Type: v1.IntegrationPlatformConditionTypeCreated, Status: corev1.ConditionTrue},
+				{Type: v1.IntegrationPlatformConditionTypeRegistryAvailable, Status: corev1.ConditionFalse, Reason: "NoRegistry", Message: "set a registry"},
+			},
+		},
+	}
+	results = doctorCmdOptions.checkPlatform(&p)
+	require.Len(t, results, 2)
+	assert.Equal(t, doctorPass, results[0].Status)
+	assert.Equal(t, doctorWarn, results[1].Status)
+	assert.Equal(t, "set a registry", results[1].Hint)
+
+	p.Status.Phase = v1.IntegrationPlatformPhaseError
+	results = doctorCmdOptions.checkPlatform(&p)
+	assert.Equal(t, doctorFail, results[0].Status)
+}
+
+func TestDoctorMavenRepositoryURLs(t *testing.T) {
+	settings, err := maven.NewSettings(maven.DefaultRepositories)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://repo.maven.apache.org/maven2"}, mavenRepositoryURLs(settings))
+
+	settings.Mirrors = []maven.Mirror{{ID: "nexus", URL: "https://nexus.example.com/maven", MirrorOf: "*"}}
+	assert.Equal(t, []string{"https://nexus.example.com/maven"}, mavenRepositoryURLs(settings))
+}
+
+func TestDoctorReport(t *testing.T) {
+	out := bytes.Buffer{}
+	err := printDoctorReport(&out, []doctorResult{
+		checkPassed("CRDs", "installed"),
+		checkWarning("Strimzi", "install Strimzi", "not installed"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `[PASS] CRDs: installed
+[WARN] Strimzi: not installed
+       hint: install Strimzi
+
+1 passed, 1 warnings, 0 failed
+`, out.String())
+
+	err = printDoctorReport(&out, []doctorResult{
+		checkFailed("Registry", "set the registry", "no container registry configured"),
+	})
+	require.Error(t, err)
+}
@@ -42,9 +42,13 @@ func newCmdDump(rootCmdOptions *RootCmdOptions) (*cobra.Command, *dumpCmdOptions
 		RootCmdOptions: rootCmdOptions,
 	}
 	cmd := cobra.Command{
-		Use:     "dump [filename]",
-		Short:   "Dump the state of namespace",
-		Long:    `Dump the state of currently used namespace. If no filename will be specified, the output will be on stdout`,
+		Use:   "dump [filename]",
+		Short: "Dump the state of namespace",
+		Long: `Dump the state of currently used namespace. If no filename will be specified, the output will be on stdout.
+With --bundle, a compressed diagnostic bundle is created instead, organized by kind and including the builds status,
+the events, the pods and operator logs and a snapshot of the operator metrics. Secret values and credential like
+property values are redacted.`,
+		Example: `  kamel dump --bundle out.tar.gz`,
 		PreRunE: decode(&options, options.Flags),
 		RunE:    options.dump,
 		// Once we moved from the deprecation this should be hidden and only used internally for E2E test execution.
@@ -54,13 +58,17 @@ func newCmdDump(rootCmdOptions *RootCmdOptions) (*cobra.Command, *dumpCmdOptions
 
 	cmd.Flags().Int("logLines", 100, "Number of log lines to dump")
 	cmd.Flags().Bool("compressed", false, "If the log file must be compressed in a tar.")
+	cmd.Flags().String("bundle", "", "Write a redacted diagnostic bundle to the given tar.gz file")
+	cmd.Flags().String("operator-namespace", "", "The namespace of the operator to collect the logs and metrics from, defaults to the current namespace")
 	return &cmd, &options
 }
 
 type dumpCmdOptions struct {
 	*RootCmdOptions
-	LogLines   int  `mapstructure:"logLines"`
-	Compressed bool `mapstructure:"compressed" yaml:",omitempty"`
+	LogLines          int    `mapstructure:"logLines"`
+	Compressed        bool   `mapstructure:"compressed" yaml:",omitempty"`
+	Bundle            string `mapstructure:"bundle" yaml:",omitempty"`
+	OperatorNamespace string `mapstructure:"operator-namespace" yaml:",omitempty"`
 }
 
 func (o *dumpCmdOptions) dump(cmd *cobra.Command, args []string) error {
@@ -69,6 +77,20 @@ func (o *dumpCmdOptions) dump(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if o.Bundle != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("the filename argument cannot be used together with --bundle")
+		}
+		err = util.WithFile(o.Bundle, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644, func(file *os.File) error {
+			return o.dumpBundle(cmd, c, file)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Diagnostic bundle written to %s\n", o.Bundle)
+		return nil
+	}
+
 	if len(args) == 1 {
 		err = util.WithFile(args[0], os.O_RDWR|os.O_CREATE, 0o644, func(file *os.File) error {
 			if !o.Compressed {
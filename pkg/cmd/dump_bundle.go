@@ -0,0 +1,378 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/redact"
+	"github.com/apache/camel-k/v2/pkg/util/tar"
+)
+
+const (
+	// lastAppliedConfigAnnotation may contain the whole unredacted resource, it's always removed from the bundle.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	operatorMetricsPath         = "/metrics"
+)
+
+// bundleResource defines a kind of resource collected in the diagnostic bundle.
+type bundleResource struct {
+	dir  string
+	list func() ctrl.ObjectList
+}
+
+var bundleResources = []bundleResource{
+	{"integrations", func() ctrl.ObjectList { return &v1.IntegrationList{} }},
+	{"integrationkits", func() ctrl.ObjectList { return &v1.IntegrationKitList{} }},
+	{"integrationplatforms", func() ctrl.ObjectList { return &v1.IntegrationPlatformList{} }},
+	{"integrationprofiles", func() ctrl.ObjectList { return &v1.IntegrationProfileList{} }},
+	{"builds", func() ctrl.ObjectList { return &v1.BuildList{} }},
+	{"pipes", func() ctrl.ObjectList { return &v1.PipeList{} }},
+	{"kamelets", func() ctrl.ObjectList { return &v1.KameletList{} }},
+	{"camelcatalogs", func() ctrl.ObjectList { return &v1.CamelCatalogList{} }},
+	{"configmaps", func() ctrl.ObjectList { return &corev1.ConfigMapList{} }},
+	{"secrets", func() ctrl.ObjectList { return &corev1.SecretList{} }},
+	{"deployments", func() ctrl.ObjectList { return &appsv1.DeploymentList{} }},
+	{"cronjobs", func() ctrl.ObjectList { return &batchv1.CronJobList{} }},
+	{"pods", func() ctrl.ObjectList { return &corev1.PodList{} }},
+	{"services", func() ctrl.ObjectList { return &corev1.ServiceList{} }},
+}
+
+// dumpBundle writes a compressed archive containing the redacted resources of the namespace, organized by kind,
+// the status of the builds, the namespace events, the pod logs and the operator logs and metrics.
+func (o *dumpCmdOptions) dumpBundle(cmd *cobra.Command, c client.Client, out io.Writer) error {
+	archive := tar.NewArchiveWriter(out)
+	b := bundle{
+		ctx:      o.Context,
+		c:        c,
+		archive:  archive,
+		logLines: o.LogLines,
+		errOut:   cmd.ErrOrStderr(),
+	}
+
+	for _, r := range bundleResources {
+		if err := b.addResources(o.Namespace, r); err != nil {
+			return err
+		}
+	}
+	if err := b.addBuildsSummary(o.Namespace); err != nil {
+		return err
+	}
+	if err := b.addEvents(o.Namespace); err != nil {
+		return err
+	}
+	if err := b.addPodLogs(o.Namespace, "logs"); err != nil {
+		return err
+	}
+	operatorNamespace := o.OperatorNamespace
+	if operatorNamespace == "" {
+		operatorNamespace = o.Namespace
+	}
+	if err := b.addOperator(operatorNamespace); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+type bundle struct {
+	ctx      context.Context
+	c        client.Client
+	archive  *tar.ArchiveWriter
+	logLines int
+	errOut   io.Writer
+}
+
+func (b *bundle) addResources(ns string, r bundleResource) error {
+	list := r.list()
+	if err := b.c.List(b.ctx, list, ctrl.InNamespace(ns)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The CRD is not installed, e.g. an older operator version
+			return nil
+		}
+		return fmt.Errorf("cannot list %s: %w", r.dir, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		data, err := redactedYAML(item)
+		if err != nil {
+			return err
+		}
+		obj, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		if err := b.archive.AddEntry(fmt.Sprintf("%s/%s.yaml", r.dir, obj.GetName()), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redactedYAML marshals the resource to YAML, removing the managed fields and the last applied configuration,
+// and redacting all the Secret values and the credential like values of the other resources.
+func redactedYAML(obj runtime.Object) ([]byte, error) {
+	data, err := kubernetes.ToJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	m, err := util.JSONToMap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata, ok := m["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, lastAppliedConfigAnnotation)
+		}
+	}
+	if _, ok := obj.(*corev1.Secret); ok {
+		for _, field := range []string{"data", "stringData"} {
+			if values, ok := m[field].(map[string]interface{}); ok {
+				for k := range values {
+					values[k] = redact.Placeholder
+				}
+			}
+		}
+	} else {
+		redact.Object(m)
+	}
+
+	return util.MapToYAML(m)
+}
+
+// addBuildsSummary writes a human readable summary of the builds, including the status of each build step.
+func (b *bundle) addBuildsSummary(ns string) error {
+	builds := v1.BuildList{}
+	if err := b.c.List(b.ctx, &builds, ctrl.InNamespace(ns)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	sort.Slice(builds.Items, func(i, j int) bool {
+		return builds.Items[i].CreationTimestamp.Before(&builds.Items[j].CreationTimestamp)
+	})
+
+	buf := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buf, 0, 8, 1, '\t', 0)
+	for _, build := range builds.Items {
+		fmt.Fprintf(w, "Build:\t%s\n", build.Name)
+		fmt.Fprintf(w, "Phase:\t%s\n", build.Status.Phase)
+		if build.Status.StartedAt != nil {
+			fmt.Fprintf(w, "Started At:\t%s\n", build.Status.StartedAt.Format(time.RFC3339))
+		}
+		if build.Status.Duration != "" {
+			fmt.Fprintf(w, "Duration:\t%s\n", build.Status.Duration)
+		}
+		if build.Status.Image != "" {
+			fmt.Fprintf(w, "Image:\t%s\n", build.Status.Image)
+		}
+		if build.Status.Error != "" {
+			fmt.Fprintf(w, "Error:\t%s\n", redact.Text(build.Status.Error))
+		}
+		if failure := build.Status.Failure; failure != nil {
+			fmt.Fprintf(w, "Failure:\t%s (attempt %d/%d)\n", redact.Text(failure.Reason), failure.Recovery.Attempt, failure.Recovery.AttemptMax)
+		}
+		for _, condition := range build.Status.Conditions {
+			fmt.Fprintf(w, "Condition:\t%s=%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, redact.Text(condition.Message))
+		}
+		if err := b.writeBuildSteps(w, &build); err != nil {
+			fmt.Fprintf(w, "Steps:\tunable to retrieve the builder pod: %v\n", err)
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return b.archive.AddEntry("builds/summary.txt", buf.Bytes())
+}
+
+// writeBuildSteps writes the status of the builder pod containers, one for each build task.
+func (b *bundle) writeBuildSteps(w io.Writer, build *v1.Build) error {
+	pods := corev1.PodList{}
+	if err := b.c.List(b.ctx, &pods, ctrl.InNamespace(build.BuilderPodNamespace()), ctrl.MatchingLabels{
+		"camel.apache.org/build": build.Name,
+	}); err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			fmt.Fprintf(w, "Step:\t%s\t%s\n", status.Name, containerState(status.State))
+		}
+	}
+
+	return nil
+}
+
+func containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Terminated != nil:
+		t := state.Terminated
+		return fmt.Sprintf("Terminated (reason=%s, exitCode=%d, duration=%s)", t.Reason, t.ExitCode, t.FinishedAt.Sub(t.StartedAt.Time))
+	case state.Running != nil:
+		return fmt.Sprintf("Running (since %s)", state.Running.StartedAt.Format(time.RFC3339))
+	case state.Waiting != nil:
+		return fmt.Sprintf("Waiting (reason=%s)", state.Waiting.Reason)
+	default:
+		return "Unknown"
+	}
+}
+
+func (b *bundle) addEvents(ns string) error {
+	events := corev1.EventList{}
+	if err := b.c.List(b.ctx, &events, ctrl.InNamespace(ns)); err != nil {
+		return err
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+
+	buf := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buf, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE")
+	for _, e := range events.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%d\t%s\n", e.LastTimestamp.Format(time.RFC3339), e.Type, e.Reason,
+			strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name, e.Count, redact.Text(e.Message))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return b.archive.AddEntry("events/events.txt", buf.Bytes())
+}
+
+// addPodLogs writes the logs of all the containers of the namespace pods.
+func (b *bundle) addPodLogs(ns string, dir string, selector ...ctrl.ListOption) error {
+	pods := corev1.PodList{}
+	if err := b.c.List(b.ctx, &pods, append(selector, ctrl.InNamespace(ns))...); err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		containers := make([]corev1.Container, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+		containers = append(containers, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+		for _, container := range containers {
+			buf := bytes.Buffer{}
+			if err := bundleLogs(b.ctx, b.c, ns, pod.Name, container.Name, &buf, b.logLines); err != nil {
+				fmt.Fprintf(&buf, "ERROR while reading the logs: %v\n", err)
+			}
+			if err := b.archive.AddEntry(fmt.Sprintf("%s/%s/%s.log", dir, pod.Name, container.Name), buf.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// bundleLogs writes the last log lines of the container, redacting the values of the sensitive pairs they contain.
+func bundleLogs(ctx context.Context, c client.Client, ns string, pod string, container string, out io.Writer, logLines int) error {
+	lines := int64(logLines)
+	stream, err := c.CoreV1().Pods(ns).GetLogs(pod, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &lines,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		fmt.Fprintln(out, redact.Text(scanner.Text()))
+	}
+
+	return scanner.Err()
+}
+
+// addOperator writes the logs and a snapshot of the metrics of the operator.
+func (b *bundle) addOperator(ns string) error {
+	pod := platform.GetOperatorPod(b.ctx, b.c, ns)
+	if pod == nil {
+		fmt.Fprintf(b.errOut, "No operator pod found in namespace %s, skipping operator logs and metrics\n", ns)
+		return nil
+	}
+	if err := b.addPodLogs(ns, "operator/logs", ctrl.MatchingLabels{"camel.apache.org/component": "operator"}); err != nil {
+		return err
+	}
+
+	metrics, err := b.scrapeOperatorMetrics(pod)
+	if err != nil {
+		fmt.Fprintf(b.errOut, "Unable to collect the operator metrics: %v\n", err)
+		return nil
+	}
+
+	return b.archive.AddEntry("operator/metrics.txt", metrics)
+}
+
+func (b *bundle) scrapeOperatorMetrics(pod *corev1.Pod) ([]byte, error) {
+	ctx, cancel := context.WithCancel(b.ctx)
+	defer cancel()
+
+	address, err := kubernetes.PortForwardPod(ctx, b.c, pod.Namespace, pod.Name, defaultMonitoringPort, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+operatorMetricsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/internal"
+	"github.com/apache/camel-k/v2/pkg/util/redact"
+)
+
+const cmdDump = "dump"
+
+// nolint: unparam
+func initializeDumpCmdOptions(t *testing.T, initObjs ...runtime.Object) (*cobra.Command, *dumpCmdOptions) {
+	t.Helper()
+	fakeClient, err := internal.NewFakeClient(initObjs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	dumpCmd, dumpOptions := newCmdDump(options)
+	dumpCmd.Args = ArbitraryArgs
+	rootCmd.AddCommand(dumpCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, dumpOptions
+}
+
+func TestDumpBundleFlags(t *testing.T) {
+	rootCmd, dumpOptions := initializeDumpCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdDump, "--bundle", "out.tar.gz", "--operator-namespace", "camel-k", "extra")
+	require.Error(t, err)
+	assert.Equal(t, "out.tar.gz", dumpOptions.Bundle)
+	assert.Equal(t, "camel-k", dumpOptions.OperatorNamespace)
+}
+
+func TestDumpBundle(t *testing.T) {
+	secret := corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: corev1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-secret"},
+		Data:       map[string][]byte{"user": []byte("admin")},
+	}
+	it := v1.Integration{
+		TypeMeta:   metav1.TypeMeta{Kind: v1.IntegrationKind, APIVersion: v1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-it"},
+		Spec: v1.IntegrationSpec{
+			Traits: v1.Traits{
+				Camel: &traitv1.CamelTrait{Properties: []string{"db.url=jdbc:foo", "db.password=changeme"}},
+			},
+		},
+	}
+	build := v1.Build{
+		TypeMeta:   metav1.TypeMeta{Kind: v1.BuildKind, APIVersion: v1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kit-123"},
+		Status: v1.BuildStatus{
+			Phase: v1.BuildPhaseFailed,
+			Error: "boom: cannot connect with db.password=changeme",
+		},
+	}
+	cm := corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: corev1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-cm"},
+		Data:       map[string]string{"application.yaml": "db:\n  user: admin\n  password: changeme\n"},
+	}
+	event := corev1.Event{
+		TypeMeta:       metav1.TypeMeta{Kind: "Event", APIVersion: corev1.SchemeGroupVersion.String()},
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "my-it.123"},
+		InvolvedObject: corev1.ObjectReference{Kind: v1.IntegrationKind, Name: "my-it"},
+		Message:        "Integration failed: api.token=changeme",
+	}
+
+	rootCmd, _ := initializeDumpCmdOptions(t, &secret, &it, &build, &cm, &event)
+	bundle := filepath.Join(t.TempDir(), "out.tar.gz")
+	_, err := ExecuteCommand(rootCmd, cmdDump, "--bundle", bundle)
+	require.NoError(t, err)
+
+	entries := readBundle(t, bundle)
+	require.Contains(t, entries, "secrets/my-secret.yaml")
+	assert.Contains(t, entries["secrets/my-secret.yaml"], "user: '"+redact.Placeholder+"'")
+	assert.NotContains(t, entries["secrets/my-secret.yaml"], "YWRtaW4=")
+	require.Contains(t, entries, "integrations/my-it.yaml")
+	assert.Contains(t, entries["integrations/my-it.yaml"], "db.url=jdbc:foo")
+	assert.Contains(t, entries["integrations/my-it.yaml"], "db.password="+redact.Placeholder)
+	assert.NotContains(t, entries["integrations/my-it.yaml"], "changeme")
+	require.Contains(t, entries, "builds/summary.txt")
+	assert.Contains(t, entries["builds/summary.txt"], "kit-123")
+	assert.Contains(t, entries["builds/summary.txt"], "boom: cannot connect with db.password="+redact.Placeholder)
+	assert.NotContains(t, entries["builds/summary.txt"], "changeme")
+	require.Contains(t, entries, "configmaps/my-cm.yaml")
+	assert.Contains(t, entries["configmaps/my-cm.yaml"], "user: admin")
+	assert.NotContains(t, entries["configmaps/my-cm.yaml"], "changeme")
+	require.Contains(t, entries, "events/events.txt")
+	assert.Contains(t, entries["events/events.txt"], "api.token="+redact.Placeholder)
+	assert.NotContains(t, entries["events/events.txt"], "changeme")
+}
+
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	entries := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = string(data)
+	}
+
+	return entries
+}
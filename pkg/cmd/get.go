@@ -19,19 +19,31 @@ package cmd
 
 import (
 	"fmt"
-	"text/tabwriter"
+	"io"
+	"strings"
 
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
 
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/spf13/cobra"
-
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/watch"
 )
 
 type getCmdOptions struct {
 	*RootCmdOptions
+	OutputFormat  string `mapstructure:"output" yaml:",omitempty"`
+	Watch         bool   `mapstructure:"watch" yaml:",omitempty"`
+	Selector      string `mapstructure:"selector" yaml:",omitempty"`
+	AllNamespaces bool   `mapstructure:"all-namespaces" yaml:",omitempty"`
+	NoHeaders     bool   `mapstructure:"no-headers" yaml:",omitempty"`
 }
 
 func newCmdGet(rootCmdOptions *RootCmdOptions) (*cobra.Command, *getCmdOptions) {
@@ -39,17 +51,31 @@ func newCmdGet(rootCmdOptions *RootCmdOptions) (*cobra.Command, *getCmdOptions)
 		RootCmdOptions: rootCmdOptions,
 	}
 	cmd := cobra.Command{
-		Use:     "get [integration]",
-		Short:   "Get integrations deployed on Kubernetes",
-		Long:    `Get the status of integrations deployed on Kubernetes.`,
+		Use:   "get [integration]",
+		Short: "Get integrations deployed on Kubernetes",
+		Long:  `Get the status of integrations deployed on Kubernetes.`,
+		Example: `  kamel get -o wide
+  kamel get -w -l app=orders
+  kamel get -A -o custom-columns=NAME:.metadata.name,IMAGE:.status.image`,
+		Args:    cobra.MaximumNArgs(1),
 		PreRunE: decode(&options, options.Flags),
 		RunE:    options.run,
 	}
 
+	cmd.Flags().StringP("output", "o", "", "Output format. One of: wide|json|yaml|name|custom-columns=<header>:<json-path>,...")
+	cmd.Flags().BoolP("watch", "w", false, "After listing the integrations, watch for their phase changes")
+	cmd.Flags().StringP("selector", "l", "", "Label selector to filter the integrations, e.g. -l app=orders")
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List the integrations of all namespaces")
+	cmd.Flags().Bool("no-headers", false, "Do not print the table headers")
+
 	return &cmd, &options
 }
 
 func (o *getCmdOptions) run(cmd *cobra.Command, args []string) error {
+	printer, err := o.printer()
+	if err != nil {
+		return err
+	}
 	c, err := o.GetCmdClient()
 	if err != nil {
 		return err
@@ -58,11 +84,14 @@ func (o *getCmdOptions) run(cmd *cobra.Command, args []string) error {
 	integrationList := v1.IntegrationList{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: v1.SchemeGroupVersion.String(),
-			Kind:       "Integration",
+			Kind:       "IntegrationList",
 		},
 	}
 
 	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = ""
+	}
 
 	options := []k8sclient.ListOption{
 		k8sclient.InNamespace(namespace),
@@ -72,22 +101,161 @@ func (o *getCmdOptions) run(cmd *cobra.Command, args []string) error {
 			"metadata.name": args[0],
 		})
 	}
+	if o.Selector != "" {
+		selector, err := labels.Parse(o.Selector)
+		if err != nil {
+			return err
+		}
+		options = append(options, k8sclient.MatchingLabelsSelector{Selector: selector})
+	}
 
 	err = c.List(o.Context, &integrationList, options...)
 	if err != nil {
 		return err
 	}
+	for i := range integrationList.Items {
+		integrationList.Items[i].TypeMeta = metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationKind}
+	}
+
+	if err := printer.PrintObj(&integrationList, cmd.OutOrStdout()); err != nil {
+		return err
+	}
+	if !o.Watch {
+		return nil
+	}
+
+	// Stream the changes of the integrations, skipping the events that do not change the printed state
+	states := make(map[string]string, len(integrationList.Items))
+	for i := range integrationList.Items {
+		it := &integrationList.Items[i]
+		states[it.Namespace+"/"+it.Name] = integrationState(it)
+	}
+	watchOptions := metav1.ListOptions{
+		LabelSelector:   o.Selector,
+		ResourceVersion: integrationList.ResourceVersion,
+	}
+	if len(args) == 1 {
+		watchOptions.FieldSelector = "metadata.name=" + args[0]
+	}
+	var printErr error
+	err = watch.HandleIntegrationListChanges(o.Context, c, namespace, watchOptions, func(eventType k8swatch.EventType, it *v1.Integration) bool {
+		key := it.Namespace + "/" + it.Name
+		if eventType == k8swatch.Deleted {
+			delete(states, key)
+			return true
+		}
+		state := integrationState(it)
+		if states[key] == state {
+			return true
+		}
+		states[key] = state
+		it.TypeMeta = metav1.TypeMeta{APIVersion: v1.SchemeGroupVersion.String(), Kind: v1.IntegrationKind}
+		if o.OutputFormat == "yaml" {
+			fmt.Fprintln(cmd.OutOrStdout(), "---")
+		}
+		printErr = printer.PrintObj(it, cmd.OutOrStdout())
+		return printErr == nil
+	})
+	if err != nil {
+		return err
+	}
 
-	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 1, '\t', 0)
-	fmt.Fprintln(w, "NAME\tPHASE\tKIT")
-	for _, integration := range integrationList.Items {
-		kit := ""
-		if integration.Status.IntegrationKit != nil {
-			ns := integration.GetIntegrationKitNamespace(nil)
-			kit = fmt.Sprintf("%s/%s", ns, integration.Status.IntegrationKit.Name)
+	return printErr
+}
+
+// printer returns the printer of the requested output format.
+func (o *getCmdOptions) printer() (getPrinter, error) {
+	switch {
+	case o.OutputFormat == "" || o.OutputFormat == "wide":
+		return &kubernetes.TablePrinter{
+			Columns:   integrationColumns(o.AllNamespaces),
+			Wide:      o.OutputFormat == "wide",
+			NoHeaders: o.NoHeaders,
+		}, nil
+	case strings.HasPrefix(o.OutputFormat, kubernetes.OutputFormatCustomColumns):
+		columns, err := kubernetes.NewCustomColumns(o.OutputFormat)
+		if err != nil {
+			return nil, err
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\n", integration.Name, string(integration.Status.Phase), kit)
+		return &kubernetes.TablePrinter{Columns: columns, NoHeaders: o.NoHeaders}, nil
+	case o.OutputFormat == "json" || o.OutputFormat == "yaml" || o.OutputFormat == "name":
+		return &kubernetes.CLIPrinter{Format: o.OutputFormat}, nil
+	default:
+		return nil, fmt.Errorf("invalid output format option '%s', should be one of: wide|json|yaml|name|custom-columns=...", o.OutputFormat)
+	}
+}
+
+type getPrinter interface {
+	PrintObj(obj runtime.Object, output io.Writer) error
+}
+
+func integrationColumns(withNamespace bool) []kubernetes.TableColumn {
+	value := func(f func(it *v1.Integration) string) func(runtime.Object) (string, error) {
+		return func(obj runtime.Object) (string, error) {
+			it, ok := obj.(*v1.Integration)
+			if !ok {
+				return "", fmt.Errorf("unexpected object %T", obj)
+			}
+			return f(it), nil
+		}
+	}
+
+	columns := make([]kubernetes.TableColumn, 0)
+	if withNamespace {
+		columns = append(columns, kubernetes.TableColumn{Header: "NAMESPACE", Value: value(func(it *v1.Integration) string {
+			return it.Namespace
+		})})
+	}
+
+	return append(columns,
+		kubernetes.TableColumn{Header: "NAME", Value: value(func(it *v1.Integration) string {
+			return it.Name
+		})},
+		kubernetes.TableColumn{Header: "PHASE", Value: value(func(it *v1.Integration) string {
+			return string(it.Status.Phase)
+		})},
+		kubernetes.TableColumn{Header: "KIT", Value: value(func(it *v1.Integration) string {
+			if it.Status.IntegrationKit == nil {
+				return ""
+			}
+			return fmt.Sprintf("%s/%s", it.GetIntegrationKitNamespace(nil), it.Status.IntegrationKit.Name)
+		})},
+		kubernetes.TableColumn{Header: "READY", Wide: true, Value: value(func(it *v1.Integration) string {
+			if ready := it.Status.GetCondition(v1.IntegrationConditionReady); ready != nil {
+				return string(ready.Status)
+			}
+			return string(corev1.ConditionUnknown)
+		})},
+		kubernetes.TableColumn{Header: "REASON", Wide: true, Value: value(func(it *v1.Integration) string {
+			if ready := it.Status.GetCondition(v1.IntegrationConditionReady); ready != nil {
+				return ready.Reason
+			}
+			return ""
+		})},
+		kubernetes.TableColumn{Header: "REPLICAS", Wide: true, Value: value(func(it *v1.Integration) string {
+			if it.Status.Replicas == nil {
+				return ""
+			}
+			return fmt.Sprintf("%d", *it.Status.Replicas)
+		})},
+		kubernetes.TableColumn{Header: "RUNTIME", Wide: true, Value: value(func(it *v1.Integration) string {
+			return it.Status.RuntimeVersion
+		})},
+		kubernetes.TableColumn{Header: "IMAGE", Wide: true, Value: value(func(it *v1.Integration) string {
+			return it.Status.Image
+		})},
+	)
+}
+
+// integrationState returns the part of the integration status that is relevant to the watch mode.
+func integrationState(it *v1.Integration) string {
+	state := string(it.Status.Phase)
+	if ready := it.Status.GetCondition(v1.IntegrationConditionReady); ready != nil {
+		state += "/" + string(ready.Status) + "/" + ready.Reason
+	}
+	if it.Status.Replicas != nil {
+		state += fmt.Sprintf("/%d", *it.Status.Replicas)
 	}
 
-	return w.Flush()
+	return state
 }
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+const cmdGet = "get"
+
+// nolint: unparam
+func initializeGetCmd(t *testing.T, initObjs ...runtime.Object) *cobra.Command {
+	t.Helper()
+	fakeClient, err := internal.NewFakeClient(initObjs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	getCmd, _ := newCmdGet(options)
+	rootCmd.AddCommand(getCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd
+}
+
+func getTestIntegrations() []runtime.Object {
+	it1 := v1.NewIntegration("default", "orders")
+	it1.Labels = map[string]string{"app": "orders"}
+	it1.Status.Phase = v1.IntegrationPhaseRunning
+	it1.Status.IntegrationKit = &corev1.ObjectReference{Namespace: "default", Name: "kit-1"}
+	it1.Status.Image = "registry/kit-1:latest"
+	it1.Status.RuntimeVersion = "3.8.1"
+	it1.Status.Replicas = ptr.To(int32(2))
+	it1.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionTrue, v1.IntegrationConditionDeploymentReadyReason, "")
+	it2 := v1.NewIntegration("default", "payments")
+	it2.Status.Phase = v1.IntegrationPhaseError
+	it2.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionFalse, v1.IntegrationConditionErrorReason, "")
+	it3 := v1.NewIntegration("other", "audit")
+	it3.Status.Phase = v1.IntegrationPhaseBuildingKit
+
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+
+	return []runtime.Object{&it1, &it2, &it3, &platform}
+}
+
+func TestGetDefaultOutput(t *testing.T) {
+	rootCmd := initializeGetCmd(t, getTestIntegrations()...)
+	output, err := ExecuteCommand(rootCmd, cmdGet)
+	require.NoError(t, err)
+	assert.Equal(t, "NAME\t\tPHASE\tKIT\norders\t\tRunning\tdefault/kit-1\npayments\tError\t\n", output)
+}
+
+func TestGetWideOutput(t *testing.T) {
+	rootCmd := initializeGetCmd(t, getTestIntegrations()...)
+	output, err := ExecuteCommand(rootCmd, cmdGet, "-l", "app=orders", "-o", "wide")
+	require.NoError(t, err)
+	assert.Equal(t, "NAME\tPHASE\tKIT\t\tREADY\tREASON\t\tREPLICAS\tRUNTIME\tIMAGE\n"+
+		"orders\tRunning\tdefault/kit-1\tTrue\tDeploymentReady\t2\t\t3.8.1\tregistry/kit-1:latest\n", output)
+}
+
+func TestGetAllNamespacesAndSelector(t *testing.T) {
+	rootCmd := initializeGetCmd(t, getTestIntegrations()...)
+	output, err := ExecuteCommand(rootCmd, cmdGet, "-A", "--no-headers", "-o", "custom-columns=NAME:.metadata.name,NS:{.metadata.namespace}")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"audit\t\tother", "orders\t\tdefault", "payments\tdefault"}, strings.Split(strings.TrimSpace(output), "\n"))
+
+	output, err = ExecuteCommand(rootCmd, cmdGet, "-A", "-l", "app=orders", "-o", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "integration/orders\n", output)
+}
+
+func TestGetJSONOutput(t *testing.T) {
+	rootCmd := initializeGetCmd(t, getTestIntegrations()...)
+	output, err := ExecuteCommand(rootCmd, cmdGet, "-o", "json")
+	require.NoError(t, err)
+	list := v1.IntegrationList{}
+	require.NoError(t, json.Unmarshal([]byte(output), &list))
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, v1.IntegrationKind, list.Items[0].Kind)
+}
+
+func TestGetInvalidOutput(t *testing.T) {
+	rootCmd := initializeGetCmd(t)
+	_, err := ExecuteCommand(rootCmd, cmdGet, "-o", "table")
+	require.Error(t, err)
+	_, err = ExecuteCommand(rootCmd, cmdGet, "-o", "custom-columns=NAME")
+	require.Error(t, err)
+}
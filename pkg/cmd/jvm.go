@@ -0,0 +1,268 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/trait"
+	utilio "github.com/apache/camel-k/v2/pkg/util/io"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+const (
+	jvmDefaultContainer = "integration"
+	jvmDefaultPID       = "1"
+	jvmRemoteDir        = "/tmp"
+)
+
+func newCmdJVM(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "jvm",
+		Short: "Collect JVM diagnostics from a running integration",
+		Long: `Collect thread dumps, heap dumps and Java Flight Recorder recordings from a running integration.
+The diagnostics are collected running jcmd in the integration container, and the resulting files are copied locally.`,
+	}
+
+	cmd.AddCommand(cmdOnly(newCmdJVMThreadDump(rootCmdOptions)))
+	cmd.AddCommand(cmdOnly(newCmdJVMHeapDump(rootCmdOptions)))
+	cmd.AddCommand(newCmdJVMJFR(rootCmdOptions))
+
+	return &cmd
+}
+
+type jvmCmdOptions struct {
+	*RootCmdOptions
+	Pod       string `mapstructure:"pod" yaml:",omitempty"`
+	Container string `mapstructure:"container" yaml:",omitempty"`
+	PID       string `mapstructure:"pid" yaml:",omitempty"`
+	Output    string `mapstructure:"output" yaml:",omitempty"`
+	// JFR recording options
+	Name     string        `mapstructure:"name" yaml:",omitempty"`
+	Duration time.Duration `mapstructure:"duration" yaml:",omitempty"`
+	Settings string        `mapstructure:"settings" yaml:",omitempty"`
+}
+
+func newJVMCommand(rootCmdOptions *RootCmdOptions, use string, short string, run func(*jvmCmdOptions, *cobra.Command, *corev1.Pod) error) (*cobra.Command, *jvmCmdOptions) {
+	options := jvmCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:     use + " [integration name]",
+		Short:   short,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("%s expects 1 argument, received %d", cmd.CommandPath(), len(args))
+			}
+			c, err := options.GetCmdClient()
+			if err != nil {
+				return err
+			}
+			pods, err := lookupIntegrationPods(options.Context, c, options.Namespace, args[0], options.Pod)
+			if err != nil {
+				return err
+			}
+			return run(&options, cmd, &pods[0])
+		},
+	}
+
+	cmd.Flags().String("pod", "", "The integration pod to collect the diagnostics from, defaults to the first ready pod")
+	cmd.Flags().String("container", jvmDefaultContainer, "The container running the JVM")
+	cmd.Flags().String("pid", jvmDefaultPID, "The process id of the JVM in the container")
+
+	return &cmd, &options
+}
+
+func newCmdJVMThreadDump(rootCmdOptions *RootCmdOptions) (*cobra.Command, *jvmCmdOptions) {
+	cmd, options := newJVMCommand(rootCmdOptions, "threaddump", "Print a thread dump of the integration JVM", (*jvmCmdOptions).threadDump)
+	cmd.Flags().StringP("output", "o", "", "The local file to write the thread dump to, defaults to the standard output")
+
+	return cmd, options
+}
+
+func newCmdJVMHeapDump(rootCmdOptions *RootCmdOptions) (*cobra.Command, *jvmCmdOptions) {
+	cmd, options := newJVMCommand(rootCmdOptions, "heapdump", "Generate a heap dump of the integration JVM and copy it locally", (*jvmCmdOptions).heapDump)
+	cmd.Flags().StringP("output", "o", "", "The local file to write the heap dump to, defaults to <pod>-<timestamp>.hprof")
+
+	return cmd, options
+}
+
+func newCmdJVMJFR(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "jfr",
+		Short: "Manage Java Flight Recorder recordings of the integration JVM",
+	}
+
+	start, _ := newJVMCommand(rootCmdOptions, "start", "Start a Java Flight Recorder recording", (*jvmCmdOptions).jfrStart)
+	start.Flags().String("name", "kamel", "The name of the recording")
+	start.Flags().Duration("duration", 0, "The duration of the recording, unlimited by default")
+	start.Flags().String("settings", "default", "The JFR settings to use, either default or profile")
+
+	stop, _ := newJVMCommand(rootCmdOptions, "stop", "Stop a Java Flight Recorder recording and copy it locally", (*jvmCmdOptions).jfrStop)
+	stop.Flags().String("name", "kamel", "The name of the recording")
+	stop.Flags().StringP("output", "o", "", "The local file to write the recording to, defaults to <pod>-<timestamp>.jfr")
+
+	dump, _ := newJVMCommand(rootCmdOptions, "dump", "Dump a running Java Flight Recorder recording and copy it locally", (*jvmCmdOptions).jfrDump)
+	dump.Flags().String("name", trait.JFRRecordingName, "The name of the recording, defaults to the continuous recording enabled by the jvm trait")
+	dump.Flags().StringP("output", "o", "", "The local file to write the recording to, defaults to <pod>-<timestamp>.jfr")
+
+	cmd.AddCommand(start, stop, dump)
+
+	return &cmd
+}
+
+// jcmd runs a jcmd diagnostic command against the JVM of the pod and returns its output.
+func (o *jvmCmdOptions) jcmd(c client.Client, pod *corev1.Pod, command ...string) (string, error) {
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	args := append([]string{"jcmd", o.PID}, command...)
+	if err := kubernetes.PodExec(o.Context, c, pod.Namespace, pod.Name, o.Container, args, &stdout, &stderr); err != nil {
+		return "", fmt.Errorf("cannot execute %q in pod %q, make sure jcmd is available in the container image: %w: %s",
+			strings.Join(args, " "), pod.Name, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// copyFromPod copies a file from the pod container to the local output file, and removes it from the container.
+// The file is copied to a temporary file first, so that no partial output file is left behind on failure.
+func (o *jvmCmdOptions) copyFromPod(cmd *cobra.Command, c client.Client, pod *corev1.Pod, remote string, local string) error {
+	file, err := os.CreateTemp(filepath.Dir(local), "."+filepath.Base(local)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	stderr := bytes.Buffer{}
+	err = kubernetes.PodExec(o.Context, c, pod.Namespace, pod.Name, o.Container, []string{"cat", remote}, file, &stderr)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("cannot copy %s from pod %q: %w: %s", remote, pod.Name, err, stderr.String())
+	}
+	if err := os.Rename(file.Name(), local); err != nil {
+		return err
+	}
+	if err := kubernetes.PodExec(o.Context, c, pod.Namespace, pod.Name, o.Container, []string{"rm", "-f", remote}, io.Discard, io.Discard); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Unable to remove %s from pod %q: %s\n", remote, pod.Name, err.Error())
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s written\n", local)
+
+	return nil
+}
+
+func (o *jvmCmdOptions) outputFile(pod *corev1.Pod, extension string) string {
+	if o.Output != "" {
+		return o.Output
+	}
+	return fmt.Sprintf("%s-%s.%s", pod.Name, time.Now().Format("20060102150405"), extension)
+}
+
+func (o *jvmCmdOptions) threadDump(cmd *cobra.Command, pod *corev1.Pod) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	dump, err := o.jcmd(c, pod, "Thread.print", "-l")
+	if err != nil {
+		return err
+	}
+
+	if o.Output == "" {
+		fmt.Fprint(cmd.OutOrStdout(), dump)
+		return nil
+	}
+	if err := os.WriteFile(o.Output, []byte(dump), utilio.FilePerm644); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s written\n", o.Output)
+
+	return nil
+}
+
+func (o *jvmCmdOptions) heapDump(cmd *cobra.Command, pod *corev1.Pod) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	remote := fmt.Sprintf("%s/%s-%d.hprof", jvmRemoteDir, pod.Name, time.Now().Unix())
+	if _, err := o.jcmd(c, pod, "GC.heap_dump", remote); err != nil {
+		return err
+	}
+
+	return o.copyFromPod(cmd, c, pod, remote, o.outputFile(pod, "hprof"))
+}
+
+func (o *jvmCmdOptions) jfrStart(cmd *cobra.Command, pod *corev1.Pod) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"JFR.start", "name=" + o.Name, "settings=" + o.Settings}
+	if o.Duration > 0 {
+		args = append(args, fmt.Sprintf("duration=%ds", int64(o.Duration.Seconds())))
+	}
+	out, err := o.jcmd(c, pod, args...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), out)
+
+	return nil
+}
+
+func (o *jvmCmdOptions) jfrStop(cmd *cobra.Command, pod *corev1.Pod) error {
+	return o.jfrSave(cmd, pod, "JFR.stop")
+}
+
+func (o *jvmCmdOptions) jfrDump(cmd *cobra.Command, pod *corev1.Pod) error {
+	return o.jfrSave(cmd, pod, "JFR.dump")
+}
+
+// jfrSave stops or dumps the recording to a file in the container and copies it locally.
+func (o *jvmCmdOptions) jfrSave(cmd *cobra.Command, pod *corev1.Pod, command string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	remote := fmt.Sprintf("%s/%s-%d.jfr", jvmRemoteDir, o.Name, time.Now().Unix())
+	if _, err := o.jcmd(c, pod, command, "name="+o.Name, "filename="+remote); err != nil {
+		return err
+	}
+
+	return o.copyFromPod(cmd, c, pod, remote, o.outputFile(pod, "jfr"))
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func initializeJVMCmd(t *testing.T, newCmd func(*RootCmdOptions) (*cobra.Command, *jvmCmdOptions)) (*jvmCmdOptions, *cobra.Command) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	jvmCmd, jvmOptions := newCmd(options)
+	jvmCmd.RunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	rootCmd.AddCommand(jvmCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return jvmOptions, rootCmd
+}
+
+func TestJVMThreadDumpFlags(t *testing.T) {
+	options, rootCmd := initializeJVMCmd(t, newCmdJVMThreadDump)
+	_, err := ExecuteCommand(rootCmd, "threaddump", "my-it", "--pod", "my-it-1234", "-o", "dump.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "my-it-1234", options.Pod)
+	assert.Equal(t, "dump.txt", options.Output)
+	assert.Equal(t, jvmDefaultContainer, options.Container)
+	assert.Equal(t, jvmDefaultPID, options.PID)
+}
+
+func TestJVMHeapDumpNonExistingFlag(t *testing.T) {
+	_, rootCmd := initializeJVMCmd(t, newCmdJVMHeapDump)
+	_, err := ExecuteCommand(rootCmd, "heapdump", "--nonExistingFlag")
+	require.Error(t, err)
+}
+
+func TestJVMJFRStartFlags(t *testing.T) {
+	options, rootCmd := initializeJVMCmd(t, func(root *RootCmdOptions) (*cobra.Command, *jvmCmdOptions) {
+		cmd, o := newJVMCommand(root, "start", "", (*jvmCmdOptions).jfrStart)
+		cmd.Flags().String("name", "kamel", "")
+		cmd.Flags().Duration("duration", 0, "")
+		return cmd, o
+	})
+	_, err := ExecuteCommand(rootCmd, "start", "my-it", "--name", "rec", "--duration", "1m")
+	require.NoError(t, err)
+	assert.Equal(t, "rec", options.Name)
+	assert.Equal(t, time.Minute, options.Duration)
+}
+
+func TestJVMOutputFile(t *testing.T) {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-it-1234"}}
+
+	o := jvmCmdOptions{Output: "heap.hprof"}
+	assert.Equal(t, "heap.hprof", o.outputFile(&pod, "hprof"))
+
+	o = jvmCmdOptions{}
+	assert.Regexp(t, `^my-it-1234-\d{14}\.jfr$`, o.outputFile(&pod, "jfr"))
+}
@@ -0,0 +1,237 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/builder"
+	"github.com/apache/camel-k/v2/pkg/client"
+	platformutil "github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+const (
+	// kitExportContainer is the name of the container mounting the OCI image layout in the cluster.
+	kitExportContainer = "oci-layout"
+	kitExportVolume    = "camel-k-oci-layout"
+)
+
+func newKitExportCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kitExportCommandOptions) {
+	options := kitExportCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "export <name>",
+		Short: "Export the image of an Integration Kit",
+		Long: `Export the image of an Integration Kit built with the OCILayout publish strategy, as an OCI archive. The archive
+is read from the OCI image layout volume of the IntegrationPlatform, by a pod mounting it.`,
+		Example: `  kamel kit export my-kit -o image.tar`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			return options.run(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "The file the OCI archive of the image is written to")
+	cmd.Flags().String("image", defaults.ImageName+":"+defaults.Version, "The image of the pod reading the OCI image layout in the cluster")
+	cmd.Flags().Duration("timeout", 10*time.Minute, "The time to wait for the export to complete")
+
+	return &cmd, &options
+}
+
+type kitExportCommandOptions struct {
+	*RootCmdOptions
+	Output  string        `mapstructure:"output" yaml:",omitempty"`
+	Image   string        `mapstructure:"image" yaml:",omitempty"`
+	Timeout time.Duration `mapstructure:"timeout" yaml:",omitempty"`
+}
+
+func (command *kitExportCommandOptions) validate() error {
+	if command.Output == "" {
+		return errors.New("the output file must be set")
+	}
+	if command.Timeout <= 0 {
+		return errors.New("the timeout must be positive")
+	}
+	return nil
+}
+
+func (command *kitExportCommandOptions) run(cmd *cobra.Command, name string) error {
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	kit := v1.NewIntegrationKit(command.Namespace, name)
+	if err := c.Get(command.Context, k8sclient.ObjectKeyFromObject(kit), kit); err != nil {
+		return fmt.Errorf("unable to find the Integration Kit %s: %w", name, err)
+	}
+	platform, ociLayout, err := command.lookupOCILayout(c, kit)
+	if err != nil {
+		return err
+	}
+	format := ociLayout.Format
+	if format == "" {
+		format = v1.OCILayoutFormatTarball
+	}
+
+	pod := newKitExportPod(platform.Namespace, ociLayout.PersistentVolumeClaim, command.Image, command.Timeout)
+	if err := c.Create(command.Context, pod); err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.Delete(context.Background(), pod); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Unable to delete pod %s: %s\n", pod.Name, err.Error())
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(command.Context, command.Timeout)
+	defer cancel()
+	err = wait.PollUntilContextCancel(ctx, platformCachePolling, true, func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, k8sclient.ObjectKeyFromObject(pod), pod); err != nil {
+			return false, err
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return false, fmt.Errorf("pod %s terminated", pod.Name)
+		}
+		return pod.Status.Phase == corev1.PodRunning, nil
+	})
+	if err != nil {
+		return fmt.Errorf("pod %s is not running: %w", pod.Name, err)
+	}
+
+	file, err := os.Create(command.Output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	remote := filepath.Join(builder.OCILayoutDir, builder.OCILayoutPath(kit.Status.Image, format))
+	args := []string{"cat", remote}
+	if format == v1.OCILayoutFormatDirectory {
+		// An OCI archive is the tarball of an OCI image layout
+		args = []string{"tar", "-C", remote, "-cf", "-", "."}
+	}
+	stderr := bytes.Buffer{}
+	if err := kubernetes.PodExec(ctx, c, pod.Namespace, pod.Name, kitExportContainer, args, file, &stderr); err != nil {
+		_ = os.Remove(command.Output)
+		return fmt.Errorf("cannot export image %s from %s: %w: %s", kit.Status.Image, remote, err, stderr.String())
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Image %s of Integration Kit %s written to %s\n", kit.Status.Image, name, command.Output)
+
+	return nil
+}
+
+// lookupOCILayout returns the platform of the kit and the OCI image layout the image of the kit has been written to.
+func (command *kitExportCommandOptions) lookupOCILayout(c client.Client, kit *v1.IntegrationKit) (*v1.IntegrationPlatform, *v1.OCILayoutSpec, error) {
+	if kit.Status.Phase != v1.IntegrationKitPhaseReady || kit.Status.Image == "" {
+		return nil, nil, fmt.Errorf("the Integration Kit %s is not ready", kit.Name)
+	}
+	platform, err := platformutil.GetForResource(command.Context, c, kit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to find the IntegrationPlatform of the Integration Kit %s: %w", kit.Name, err)
+	}
+	if platform.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategyOCILayout {
+		return nil, nil, fmt.Errorf("the IntegrationPlatform %s does not use the %s publish strategy",
+			platform.Name, v1.IntegrationPlatformBuildPublishStrategyOCILayout)
+	}
+	ociLayout := platform.Status.Build.OCILayout
+	if ociLayout == nil {
+		ociLayout = platform.Spec.Build.OCILayout
+	}
+	if ociLayout == nil || ociLayout.PersistentVolumeClaim == "" {
+		return nil, nil, fmt.Errorf("no OCI image layout is configured on IntegrationPlatform %s", platform.Name)
+	}
+
+	return platform, ociLayout, nil
+}
+
+// newKitExportPod returns a pod mounting the OCI image layout, idle for the duration of the export.
+func newKitExportPod(namespace string, claim string, image string, timeout time.Duration) *corev1.Pod {
+	var ugfid int64 = 1001
+
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    namespace,
+			GenerateName: "camel-k-kit-export-",
+			Labels: map[string]string{
+				"camel.apache.org/component": "kit-export",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			// The same user as the builder pods writing the images
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &ugfid,
+				RunAsGroup: &ugfid,
+				FSGroup:    &ugfid,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            kitExportContainer,
+					Image:           image,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         []string{"sleep", strconv.FormatInt(int64(timeout.Seconds()), 10)},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      kitExportVolume,
+							MountPath: builder.OCILayoutDir,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: kitExportVolume,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claim,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+func initializeKitExportCmd(t *testing.T, objs ...runtime.Object) (*cobra.Command, *kitExportCommandOptions) {
+	t.Helper()
+
+	fakeClient, err := internal.NewFakeClient(objs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	exportCmd, exportOptions := newKitExportCmd(options)
+	rootCmd.AddCommand(exportCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, exportOptions
+}
+
+func newKitExportTestObjects(strategy v1.IntegrationPlatformBuildPublishStrategy) []runtime.Object {
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+	platform.Status.Phase = v1.IntegrationPlatformPhaseReady
+	platform.Status.Build.PublishStrategy = strategy
+	platform.Status.Build.OCILayout = &v1.OCILayoutSpec{PersistentVolumeClaim: "oci-layout"}
+
+	kit := v1.NewIntegrationKit("default", "my-kit")
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	kit.Status.Image = "default/camel-k-my-kit:1"
+	kit.Status.Platform = "camel-k"
+
+	return []runtime.Object{&platform, kit}
+}
+
+func TestKitExportFlags(t *testing.T) {
+	rootCmd, options := initializeKitExportCmd(t)
+	_, err := ExecuteCommand(rootCmd, "export", "my-kit", "-o", "image.tar", "--timeout", "1m", "--image", "my-image")
+	// the kit does not exist
+	require.Error(t, err)
+	assert.Equal(t, "image.tar", options.Output)
+	assert.Equal(t, time.Minute, options.Timeout)
+	assert.Equal(t, "my-image", options.Image)
+}
+
+func TestKitExportValidation(t *testing.T) {
+	rootCmd, _ := initializeKitExportCmd(t)
+	_, err := ExecuteCommand(rootCmd, "export", "my-kit")
+	require.EqualError(t, err, "the output file must be set")
+
+	rootCmd, _ = initializeKitExportCmd(t)
+	_, err = ExecuteCommand(rootCmd, "export", "-o", "image.tar")
+	require.Error(t, err)
+}
+
+func TestKitExportLookupOCILayout(t *testing.T) {
+	objs := newKitExportTestObjects(v1.IntegrationPlatformBuildPublishStrategyOCILayout)
+	_, options := initializeKitExportCmd(t, objs...)
+	c, err := options.GetCmdClient()
+	require.NoError(t, err)
+
+	kit, ok := objs[1].(*v1.IntegrationKit)
+	require.True(t, ok)
+	platform, ociLayout, err := options.lookupOCILayout(c, kit)
+	require.NoError(t, err)
+	assert.Equal(t, "camel-k", platform.Name)
+	assert.Equal(t, "oci-layout", ociLayout.PersistentVolumeClaim)
+
+	pod := newKitExportPod(platform.Namespace, ociLayout.PersistentVolumeClaim, "my-image", time.Minute)
+	assert.Equal(t, []string{"sleep", "60"}, pod.Spec.Containers[0].Command)
+	assert.Equal(t, "oci-layout", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "/etc/camel-k/oci-layout", pod.Spec.Containers[0].VolumeMounts[0].MountPath)
+
+	kit.Status.Phase = v1.IntegrationKitPhaseBuildRunning
+	_, _, err = options.lookupOCILayout(c, kit)
+	require.EqualError(t, err, "the Integration Kit my-kit is not ready")
+}
+
+func TestKitExportOtherStrategy(t *testing.T) {
+	objs := newKitExportTestObjects(v1.IntegrationPlatformBuildPublishStrategyJib)
+	_, options := initializeKitExportCmd(t, objs...)
+	c, err := options.GetCmdClient()
+	require.NoError(t, err)
+
+	kit, ok := objs[1].(*v1.IntegrationKit)
+	require.True(t, ok)
+	_, _, err = options.lookupOCILayout(c, kit)
+	require.EqualError(t, err, "the IntegrationPlatform camel-k does not use the OCILayout publish strategy")
+}
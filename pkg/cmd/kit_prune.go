@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/platform"
+)
+
+func newKitPruneCmd(rootCmdOptions *RootCmdOptions) (*cobra.Command, *kitPruneCommandOptions) {
+	options := kitPruneCommandOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "prune",
+		Short: "Garbage collect the unused platform integration kits",
+		Long: `Garbage collect the unused platform integration kits, according to the kit retention policy of the platform
+or to the one given with the flags. The kits created by the users and the external kits are never collected.`,
+		Example: `kamel kit prune --dry-run
+kamel kit prune --max-unused 5 --ttl 168h`,
+		Args:    cobra.NoArgs,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd)
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Only print the integration kits that would be garbage collected")
+	cmd.Flags().Int("max-unused", -1, "The maximum number of unused integration kits to retain, overriding the platform retention policy")
+	cmd.Flags().Duration("ttl", 0, "The time an unused integration kit is retained since its last use, overriding the platform retention policy")
+
+	return &cmd, &options
+}
+
+type kitPruneCommandOptions struct {
+	*RootCmdOptions
+	DryRun    bool          `mapstructure:"dry-run"`
+	MaxUnused int           `mapstructure:"max-unused"`
+	TTL       time.Duration `mapstructure:"ttl"`
+}
+
+func (command *kitPruneCommandOptions) validate() error {
+	if command.MaxUnused < -1 {
+		return errors.New("the maximum number of unused integration kits cannot be negative")
+	}
+	if command.TTL < 0 {
+		return errors.New("the integration kit time to live cannot be negative")
+	}
+
+	return nil
+}
+
+func (command *kitPruneCommandOptions) run(cmd *cobra.Command) error {
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	pl, err := platform.GetForName(command.Context, c, command.Namespace, "")
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	policy := command.retentionPolicy(pl)
+	if policy == nil {
+		return errors.New("no kit retention policy is set on the platform: set the --max-unused or --ttl flags")
+	}
+
+	kits, err := platform.PruneKits(command.Context, c, pl, policy, command.Namespace, command.DryRun)
+	for _, kit := range kits {
+		if command.DryRun {
+			fmt.Fprintf(cmd.OutOrStdout(), "integration kit \"%s\" would be deleted\n", kit.Name)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "integration kit \"%s\" has been deleted\n", kit.Name)
+		}
+	}
+	if len(kits) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "no integration kit to garbage collect")
+	}
+
+	return err
+}
+
+// retentionPolicy returns the retention policy of the platform, if any, overridden by the flags.
+func (command *kitPruneCommandOptions) retentionPolicy(pl *v1.IntegrationPlatform) *v1.KitRetentionPolicy {
+	var policy *v1.KitRetentionPolicy
+	if pl != nil && pl.Status.Build.KitRetention != nil {
+		policy = pl.Status.Build.KitRetention.DeepCopy()
+	}
+	if command.MaxUnused < 0 && command.TTL == 0 {
+		return policy
+	}
+
+	if policy == nil {
+		policy = &v1.KitRetentionPolicy{}
+	}
+	if command.MaxUnused >= 0 {
+		maxUnused := command.MaxUnused
+		policy.MaxUnusedKits = &maxUnused
+	}
+	if command.TTL > 0 {
+		policy.TTL = &metav1.Duration{Duration: command.TTL}
+	}
+
+	return policy
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+func initializeKitPruneCmd(t *testing.T, objs ...runtime.Object) (*cobra.Command, *kitPruneCommandOptions) {
+	t.Helper()
+
+	fakeClient, err := internal.NewFakeClient(objs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	pruneCmd, pruneOptions := newKitPruneCmd(options)
+	rootCmd.AddCommand(pruneCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, pruneOptions
+}
+
+func newKitPruneTestObjects() []runtime.Object {
+	maxUnused := 0
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+	platform.Status.Phase = v1.IntegrationPlatformPhaseReady
+	platform.Status.Build.KitRetention = &v1.KitRetentionPolicy{MaxUnusedKits: &maxUnused}
+
+	kit := v1.NewIntegrationKit("default", "my-kit")
+	kit.Labels = map[string]string{v1.IntegrationKitTypeLabel: v1.IntegrationKitTypePlatform}
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+
+	return []runtime.Object{&platform, kit}
+}
+
+func TestKitPruneFlags(t *testing.T) {
+	rootCmd, options := initializeKitPruneCmd(t)
+	_, err := ExecuteCommand(rootCmd, "prune", "--dry-run", "--max-unused", "5", "--ttl", "1h")
+	require.NoError(t, err)
+	assert.True(t, options.DryRun)
+	assert.Equal(t, 5, options.MaxUnused)
+	assert.Equal(t, time.Hour, options.TTL)
+}
+
+func TestKitPruneNoPolicy(t *testing.T) {
+	rootCmd, _ := initializeKitPruneCmd(t)
+	_, err := ExecuteCommand(rootCmd, "prune")
+	require.EqualError(t, err, "no kit retention policy is set on the platform: set the --max-unused or --ttl flags")
+}
+
+func TestKitPruneDryRun(t *testing.T) {
+	rootCmd, options := initializeKitPruneCmd(t, newKitPruneTestObjects()...)
+	output, err := ExecuteCommand(rootCmd, "prune", "--dry-run")
+	require.NoError(t, err)
+	assert.Equal(t, "integration kit \"my-kit\" would be deleted\n", output)
+
+	c, err := options.GetCmdClient()
+	require.NoError(t, err)
+	kits := v1.NewIntegrationKitList()
+	require.NoError(t, c.List(options.Context, &kits))
+	assert.Len(t, kits.Items, 1)
+}
+
+func TestKitPrune(t *testing.T) {
+	rootCmd, _ := initializeKitPruneCmd(t, newKitPruneTestObjects()...)
+	output, err := ExecuteCommand(rootCmd, "prune")
+	require.NoError(t, err)
+	assert.Equal(t, "integration kit \"my-kit\" has been deleted\n", output)
+}
+
+func TestKitPruneRetentionPolicy(t *testing.T) {
+	_, options := initializeKitPruneCmd(t)
+	options.MaxUnused = -1
+	assert.Nil(t, options.retentionPolicy(nil))
+
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+	platform.Status.Build.KitRetention = &v1.KitRetentionPolicy{TTL: &metav1.Duration{Duration: time.Hour}}
+	options.MaxUnused = 3
+	policy := options.retentionPolicy(&platform)
+	require.NotNil(t, policy)
+	assert.Equal(t, 3, *policy.MaxUnusedKits)
+	assert.Equal(t, time.Hour, policy.TTL.Duration)
+	assert.Nil(t, platform.Status.Build.KitRetention.MaxUnusedKits)
+}
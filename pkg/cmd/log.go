@@ -20,26 +20,54 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
 	k8slog "github.com/apache/camel-k/v2/pkg/util/kubernetes/log"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 	k8errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+	colorReset  = "\033[0m"
+)
+
+// logColors are the colors of the prefixes identifying the integrations.
+var logColors = []string{
+	"\033[32m", // green
+	"\033[33m", // yellow
+	"\033[34m", // blue
+	"\033[35m", // magenta
+	"\033[36m", // cyan
+	"\033[31m", // red
+}
+
 func newCmdLog(rootCmdOptions *RootCmdOptions) (*cobra.Command, *logCmdOptions) {
 	options := logCmdOptions{
 		RootCmdOptions: rootCmdOptions,
 	}
 
 	cmd := cobra.Command{
-		Use:     "log integration",
-		Short:   "Print the logs of an integration",
-		Long:    `Print the logs of an integration.`,
+		Use:   "log [integration...]",
+		Short: "Print the logs of integrations",
+		Long: `Print the logs of an integration, of several integrations, or of the integrations matching a label selector.
+The logs of several integrations are multiplexed, each line being prefixed with the name of its integration.`,
+		Example: `  kamel log my-it --since 10m --grep Exception
+  kamel log it1 it2 --timestamps
+  kamel log -l team=payments --json --level WARN --route-id payment-route`,
 		Aliases: []string{"logs"},
 		Args:    options.validate,
 		PreRunE: decode(&options, options.Flags),
@@ -47,6 +75,16 @@ func newCmdLog(rootCmdOptions *RootCmdOptions) (*cobra.Command, *logCmdOptions)
 	}
 
 	cmd.Flags().Int64("tail", -1, "The number of lines from the end of the logs to show. Defaults to -1 to show all the lines.")
+	cmd.Flags().StringP("selector", "l", "", "Print the logs of the integrations matching the label selector")
+	cmd.Flags().Duration("since", 0, "Only show the logs newer than a relative duration, e.g. 10s, 5m or 1h. Defaults to all the logs.")
+	cmd.Flags().Bool("timestamps", false, "Prefix each line with its timestamp")
+	cmd.Flags().String("grep", "", "Only show the lines matching the regular expression")
+	cmd.Flags().Bool("json", false, "Parse the logs in the JSON format of the logging trait, and print them as text")
+	cmd.Flags().String("level", "", "Only show the JSON logs of the given level or above, e.g. WARN")
+	cmd.Flags().String("route-id", "", "Only show the JSON logs of the given route")
+	cmd.Flags().String("exchange-id", "", "Only show the JSON logs of the given exchange")
+	cmd.Flags().StringArray("mdc", nil, "Only show the JSON logs having the given MDC value, e.g. \"--mdc camel.breadcrumbId=123\"")
+	cmd.Flags().String("color", colorAuto, "Color the prefixes identifying the integrations. One of: auto|always|never")
 
 	// completion support
 	configureKnownCompletions(&cmd)
@@ -56,22 +94,138 @@ func newCmdLog(rootCmdOptions *RootCmdOptions) (*cobra.Command, *logCmdOptions)
 
 type logCmdOptions struct {
 	*RootCmdOptions
-	Tail int64 `mapstructure:"tail"`
+	Tail       int64         `mapstructure:"tail"`
+	Selector   string        `mapstructure:"selector"`
+	Since      time.Duration `mapstructure:"since"`
+	Timestamps bool          `mapstructure:"timestamps"`
+	Grep       string        `mapstructure:"grep"`
+	JSON       bool          `mapstructure:"json"`
+	Level      string        `mapstructure:"level"`
+	RouteID    string        `mapstructure:"route-id"`
+	ExchangeID string        `mapstructure:"exchange-id"`
+	MDC        []string      `mapstructure:"mdc"`
+	Color      string        `mapstructure:"color"`
 }
 
-func (o *logCmdOptions) validate(_ *cobra.Command, args []string) error {
-	if len(args) != 1 {
+func (o *logCmdOptions) validate(cmd *cobra.Command, args []string) error {
+	// The options are not decoded yet
+	selector := cmd.Flag("selector").Value.String()
+	if len(args) == 0 && selector == "" {
 		return errors.New("log expects an integration name argument")
 	}
+	if len(args) > 0 && selector != "" {
+		return errors.New("log expects either integration names or a label selector")
+	}
 
 	return nil
 }
 
+// filter returns the filter of the log lines, if any.
+func (o *logCmdOptions) filter() (*k8slog.Filter, error) {
+	filter := k8slog.Filter{
+		JSON:       o.JSON,
+		Level:      o.Level,
+		RouteID:    o.RouteID,
+		ExchangeID: o.ExchangeID,
+	}
+	if o.Grep != "" {
+		grep, err := regexp.Compile(o.Grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --grep expression: %w", err)
+		}
+		filter.Grep = grep
+	}
+	for _, mdc := range o.MDC {
+		key, value, ok := strings.Cut(mdc, "=")
+		if !ok {
+			return nil, fmt.Errorf(`invalid MDC filter %s. Expected "<key>=<value>"`, mdc)
+		}
+		if filter.MDC == nil {
+			filter.MDC = make(map[string]string)
+		}
+		filter.MDC[key] = value
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if filter.Grep == nil && !filter.JSON {
+		return nil, nil
+	}
+
+	return &filter, nil
+}
+
+// logOptions returns the scraping options of the given integrations, prefixing the lines with the integration
+// names when several integrations are printed.
+func (o *logCmdOptions) logOptions(out io.Writer, integrations []v1.Integration) (func(*v1.Integration) k8slog.Options, error) {
+	filter, err := o.filter()
+	if err != nil {
+		return nil, err
+	}
+	options := k8slog.Options{
+		Timestamps: o.Timestamps,
+		Filter:     filter,
+	}
+	if o.Tail > 0 {
+		options.TailLines = &o.Tail
+	}
+	if o.Since > 0 {
+		since := int64(o.Since.Seconds())
+		options.SinceSeconds = &since
+	}
+
+	colored, err := o.colored(out)
+	if err != nil {
+		return nil, err
+	}
+	prefixes := make(map[string]string, len(integrations))
+	if len(integrations) > 1 || o.Selector != "" {
+		width := 0
+		for _, it := range integrations {
+			width = max(width, len(it.Name))
+		}
+		for i, it := range integrations {
+			prefix := fmt.Sprintf("%-*s", width, it.Name)
+			if colored {
+				prefix = logColors[i%len(logColors)] + prefix + colorReset
+			}
+			prefixes[it.Name] = prefix + " | "
+		}
+	}
+
+	return func(it *v1.Integration) k8slog.Options {
+		itOptions := options
+		itOptions.Prefix = prefixes[it.Name]
+		return itOptions
+	}, nil
+}
+
+func (o *logCmdOptions) colored(out io.Writer) (bool, error) {
+	switch o.Color {
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	case colorAuto, "":
+		f, ok := out.(*os.File)
+		return ok && term.IsTerminal(int(f.Fd())), nil
+	default:
+		return false, fmt.Errorf("invalid color mode %s, expected one of auto, always or never", o.Color)
+	}
+}
+
 func (o *logCmdOptions) run(cmd *cobra.Command, args []string) error {
+	// Report invalid options before waiting for the integration
+	if _, err := o.logOptions(cmd.OutOrStdout(), nil); err != nil {
+		return err
+	}
 	c, err := o.GetCmdClient()
 	if err != nil {
 		return err
 	}
+	if len(args) != 1 {
+		return o.printIntegrations(cmd, c, args)
+	}
 
 	integrationID := args[0]
 
@@ -134,11 +288,12 @@ func (o *logCmdOptions) run(cmd *cobra.Command, args []string) error {
 			// Found the running integration so step over to scraping its pod log
 			//
 			fmt.Fprintln(cmd.OutOrStdout(), "Integration '"+integrationID+"' is now running. Showing log ...")
-			var tailLines *int64
-			if o.Tail > 0 {
-				tailLines = &o.Tail
+			integrations := []v1.Integration{integration}
+			options, err := o.logOptions(cmd.OutOrStdout(), integrations)
+			if err != nil {
+				return false, err
 			}
-			if err := k8slog.Print(o.Context, cmd, c, &integration, tailLines, cmd.OutOrStdout()); err != nil {
+			if err := k8slog.PrintIntegrations(o.Context, cmd, c, integrations, options, cmd.OutOrStdout()); err != nil {
 				return false, err
 			}
 
@@ -210,3 +365,42 @@ func (o *logCmdOptions) run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printIntegrations prints the logs of the given integrations, or of the ones matching the selector, whatever their
+// phase, the pods being scraped as they come.
+func (o *logCmdOptions) printIntegrations(cmd *cobra.Command, c client.Client, names []string) error {
+	var integrations []v1.Integration
+	if o.Selector != "" {
+		selector, err := labels.Parse(o.Selector)
+		if err != nil {
+			return err
+		}
+		list := v1.NewIntegrationList()
+		if err := c.List(o.Context, &list, k8sclient.InNamespace(o.Namespace), k8sclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return err
+		}
+		if len(list.Items) == 0 {
+			return fmt.Errorf("no integration found matching selector %s", o.Selector)
+		}
+		integrations = list.Items
+	}
+	for _, name := range names {
+		it := v1.NewIntegration(o.Namespace, name)
+		if err := c.Get(o.Context, k8sclient.ObjectKeyFromObject(&it), &it); err != nil {
+			return err
+		}
+		integrations = append(integrations, it)
+	}
+
+	options, err := o.logOptions(cmd.OutOrStdout(), integrations)
+	if err != nil {
+		return err
+	}
+	names = make([]string, 0, len(integrations))
+	for _, it := range integrations {
+		names = append(names, it.Name)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Showing log of integrations %s ...\n", strings.Join(names, ", "))
+
+	return k8slog.PrintIntegrations(o.Context, cmd, c, integrations, options, cmd.OutOrStdout())
+}
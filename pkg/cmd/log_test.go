@@ -19,6 +19,16 @@ package cmd
 
 import (
 	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+	k8slog "github.com/apache/camel-k/v2/pkg/util/kubernetes/log"
 )
 
 func TestLogsAlias(t *testing.T) {
@@ -35,3 +45,74 @@ func TestLogsAlias(t *testing.T) {
 		t.Fatalf("Expected error result for invalid alias `logs`")
 	}
 }
+
+func initializeLogCmdOptions(t *testing.T, objs ...runtime.Object) (*logCmdOptions, *cobra.Command) {
+	t.Helper()
+
+	fakeClient, err := internal.NewFakeClient(objs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	logCmd, logOptions := newCmdLog(options)
+	rootCmd.AddCommand(logCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return logOptions, rootCmd
+}
+
+func TestLogNamesAndSelector(t *testing.T) {
+	_, rootCmd := initializeLogCmdOptions(t)
+
+	_, err := ExecuteCommand(rootCmd, "log", "it1", "-l", "team=payments")
+	require.EqualError(t, err, "log expects either integration names or a label selector")
+}
+
+func TestLogInvalidFilters(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{args: []string{"--level", "WARN"}, err: "filtering by level, route id, exchange id or MDC requires the JSON parsing of the logs"},
+		{args: []string{"--json", "--mdc", "tenant"}, err: `invalid MDC filter tenant. Expected "<key>=<value>"`},
+		{args: []string{"--grep", "("}, err: "invalid --grep expression: error parsing regexp: missing closing ): `(`"},
+		{args: []string{"--color", "rainbow"}, err: "invalid color mode rainbow, expected one of auto, always or never"},
+	}
+	for _, test := range tests {
+		_, rootCmd := initializeLogCmdOptions(t)
+		_, err := ExecuteCommand(rootCmd, append([]string{"log", "it1"}, test.args...)...)
+		require.EqualError(t, err, test.err)
+	}
+}
+
+func TestLogSelectorWithoutIntegration(t *testing.T) {
+	it := v1.NewIntegration("default", "it1")
+	it.Labels = map[string]string{"team": "orders"}
+	_, rootCmd := initializeLogCmdOptions(t, &it)
+
+	_, err := ExecuteCommand(rootCmd, "log", "-n", "default", "-l", "team=payments")
+	require.EqualError(t, err, "no integration found matching selector team=payments")
+}
+
+func TestLogOptions(t *testing.T) {
+	logOptions, rootCmd := initializeLogCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, "log", "-l", "team=payments", "--since", "10m", "--timestamps", "--json", "--level", "WARN",
+		"--route-id", "route1", "--color", "always")
+	require.EqualError(t, err, "no integration found matching selector team=payments")
+
+	integrations := []v1.Integration{v1.NewIntegration("default", "it1"), v1.NewIntegration("default", "long-it2")}
+	options, err := logOptions.logOptions(nil, integrations)
+	require.NoError(t, err)
+
+	it1 := options(&integrations[0])
+	assert.Equal(t, int64(600), *it1.SinceSeconds)
+	assert.Nil(t, it1.TailLines)
+	assert.True(t, it1.Timestamps)
+	assert.Equal(t, "\033[32mit1     \033[0m | ", it1.Prefix)
+	assert.Equal(t, k8slog.Filter{JSON: true, Level: "WARN", RouteID: "route1"}, *it1.Filter)
+	assert.Equal(t, "\033[33mlong-it2\033[0m | ", options(&integrations[1]).Prefix)
+
+	// A single integration is not prefixed
+	logOptions.Selector = ""
+	options, err = logOptions.logOptions(nil, integrations[:1])
+	require.NoError(t, err)
+	assert.Empty(t, options(&integrations[0]).Prefix)
+}
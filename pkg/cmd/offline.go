@@ -0,0 +1,298 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/builder"
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/gradle"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/offline"
+)
+
+func newCmdOffline(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "offline",
+		Short: "Prepare offline and air-gapped builds",
+		Long: `Prepare the bundles of pre-resolved dependencies that let the integrations be built without access to remote Maven repositories.
+A bundle is configured on the IntegrationPlatform with the spec.build.maven.offline.bundle field.`,
+	}
+
+	cmd.AddCommand(cmdOnly(newCmdOfflineBundle(rootCmdOptions)))
+
+	return &cmd
+}
+
+func newCmdOfflineBundle(rootCmdOptions *RootCmdOptions) (*cobra.Command, *offlineBundleCmdOptions) {
+	options := offlineBundleCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "bundle",
+		Short: "Resolve the dependencies of a runtime version into a bundle",
+		Long: `Resolve the dependencies of a runtime version, and of the given components, into a bundle usable by offline builds.
+The bundle is written either as a directory or as a .tar.gz archive, and can be pushed as an OCI image.`,
+		Example: `  kamel offline bundle --runtime-version 3.15.2 -d camel:kafka -d camel:http -o bundle.tar.gz
+  kamel offline bundle --all-components -o bundle.tar.gz --image registry.acme.org/camel-k/bundle:3.15.2`,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+		},
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			return options.run(cmd)
+		},
+	}
+
+	cmd.Flags().String("runtime-version", defaults.DefaultRuntimeVersion, "The version of the Camel K runtime to resolve the dependencies of")
+	cmd.Flags().String("runtime-provider", string(v1.RuntimeProviderQuarkus), "The provider of the Camel K runtime, either quarkus or plain-quarkus")
+	cmd.Flags().StringArrayP("dependency", "d", nil, "Add a dependency to the bundle, e.g. camel:kafka or mvn:org.acme:lib:1.0")
+	cmd.Flags().Bool("all-components", false, "Add all the components of the Camel catalog to the bundle")
+	cmd.Flags().StringP("output", "o", "camel-k-bundle.tar.gz", "The .tar.gz archive or the directory to write the bundle to")
+	cmd.Flags().String("image", "", "The OCI image to push the bundle to, e.g. registry.acme.org/camel-k/bundle:3.15.2")
+	cmd.Flags().StringArray("maven-repository", nil, "Add a Maven repository to resolve the dependencies from")
+	cmd.Flags().String("maven-settings", "", "The path of a Maven settings file to resolve the dependencies with")
+
+	return &cmd, &options
+}
+
+type offlineBundleCmdOptions struct {
+	*RootCmdOptions
+	RuntimeVersion    string   `mapstructure:"runtime-version"`
+	RuntimeProvider   string   `mapstructure:"runtime-provider"`
+	Dependencies      []string `mapstructure:"dependencies"`
+	AllComponents     bool     `mapstructure:"all-components"`
+	Output            string   `mapstructure:"output"`
+	Image             string   `mapstructure:"image"`
+	MavenRepositories []string `mapstructure:"maven-repositories"`
+	MavenSettings     string   `mapstructure:"maven-settings"`
+}
+
+func (o *offlineBundleCmdOptions) validate() error {
+	if o.RuntimeVersion == "" {
+		return errors.New("the runtime version must be set")
+	}
+	switch v1.RuntimeProvider(o.RuntimeProvider) {
+	case v1.RuntimeProviderQuarkus, v1.RuntimeProviderPlainQuarkus:
+	default:
+		return fmt.Errorf("unsupported runtime provider: %s. One of [%s, %s] is expected",
+			o.RuntimeProvider, v1.RuntimeProviderQuarkus, v1.RuntimeProviderPlainQuarkus)
+	}
+	if o.Output == "" {
+		return errors.New("the output of the bundle must be set")
+	}
+	if o.Image != "" && !o.isArchive() {
+		return errors.New("the bundle can only be pushed as an image when the output is a .tar.gz archive")
+	}
+	for _, d := range o.Dependencies {
+		if strings.HasPrefix(d, "file://") {
+			return fmt.Errorf("the local dependency %s cannot be added to a bundle", d)
+		}
+	}
+
+	return nil
+}
+
+func (o *offlineBundleCmdOptions) isArchive() bool {
+	return strings.HasSuffix(o.Output, ".tar.gz") || strings.HasSuffix(o.Output, ".tgz")
+}
+
+func (o *offlineBundleCmdOptions) run(cmd *cobra.Command) error {
+	if !o.isArchive() {
+		if err := os.MkdirAll(o.Output, os.ModePerm); err != nil {
+			return err
+		}
+		return o.resolve(cmd, o.Output)
+	}
+
+	return util.WithTempDir("camel-k-bundle", func(dir string) error {
+		return o.resolve(cmd, dir)
+	})
+}
+
+// resolve resolves the dependencies into the given directory, used as the local Maven repository, and archives or
+// pushes the resulting bundle.
+func (o *offlineBundleCmdOptions) resolve(cmd *cobra.Command, dir string) error {
+	repository, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	globalSettings, userSettings, err := o.settings()
+	if err != nil {
+		return err
+	}
+
+	mvn := v1.MavenSpec{LocalRepository: repository}
+	runtime := v1.RuntimeSpec{
+		Version:  o.RuntimeVersion,
+		Provider: v1.RuntimeProvider(o.RuntimeProvider),
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Generating the Camel catalog of runtime %s %s\n", runtime.Provider, runtime.Version)
+	// The catalog generation resolves the Maven plugin the operator uses to generate the catalog from the bundle
+	catalog, err := camel.GenerateCatalogCommon(o.Context, globalSettings, userSettings, nil, mvn, runtime)
+	if err != nil {
+		return fmt.Errorf("unable to generate the Camel catalog: %w", err)
+	}
+	if err := camel.ValidateDependenciesE(catalog, o.Dependencies); err != nil {
+		return err
+	}
+
+	dependencies := o.bundleDependencies(catalog)
+	project, err := builder.GenerateQuarkusProjectWithDependencies(catalog, dependencies)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Resolving %d dependencies into %s\n", len(dependencies), repository)
+	err = util.WithTempDir("camel-k-bundle-project", func(projectDir string) error {
+		mc := maven.NewContext(projectDir)
+		mc.GlobalSettings = globalSettings
+		mc.UserSettings = userSettings
+		mc.LocalRepository = repository
+		if err := project.Command(mc).DoSettings(o.Context); err != nil {
+			return err
+		}
+
+		if err := builder.BuildQuarkusRunnerCommon(o.Context, mc, project, nil); err != nil {
+			return err
+		}
+		// The Quarkus Gradle plugin is resolved too, so that the Gradle builds can also run from the bundle
+		if version := gradle.NewProjectFromMaven(project).QuarkusPluginVersion; version != "" {
+			mc.AdditionalArguments = append(mc.AdditionalArguments, gradle.QuarkusPluginGoal(version)...)
+			return project.Command(mc).Do(o.Context)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to resolve the dependencies: %w", err)
+	}
+
+	manifest := offline.Manifest{
+		RuntimeProvider: o.RuntimeProvider,
+		RuntimeVersion:  o.RuntimeVersion,
+		Dependencies:    o.Dependencies,
+		Created:         time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := offline.WriteManifest(repository, manifest); err != nil {
+		return err
+	}
+
+	if !o.isArchive() {
+		fmt.Fprintf(cmd.OutOrStdout(), "Bundle written to %s\n", o.Output)
+		return nil
+	}
+
+	return o.archive(cmd, repository)
+}
+
+func (o *offlineBundleCmdOptions) archive(cmd *cobra.Command, dir string) error {
+	f, err := os.Create(o.Output)
+	if err != nil {
+		return err
+	}
+	if err := offline.Archive(dir, f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Bundle written to %s\n", o.Output)
+
+	if o.Image == "" {
+		return nil
+	}
+	digest, err := offline.Push(o.Context, o.Output, o.Image)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Bundle pushed to %s@%s\n", strings.TrimPrefix(o.Image, offline.ImagePrefix), digest)
+
+	return nil
+}
+
+// settings returns the global and the user Maven settings used to resolve the dependencies.
+func (o *offlineBundleCmdOptions) settings() ([]byte, []byte, error) {
+	settings, err := maven.NewSettings(
+		maven.DefaultRepositories, maven.ProxyFromEnvironment, maven.Repositories(o.MavenRepositories...))
+	if err != nil {
+		return nil, nil, err
+	}
+	globalSettings, err := settings.MarshalBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.MavenSettings == "" {
+		return globalSettings, nil, nil
+	}
+	userSettings, err := os.ReadFile(o.MavenSettings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return globalSettings, userSettings, nil
+}
+
+// bundleDependencies returns the dependencies to resolve, i.e. the given ones, the runtime and the DSL ones, and all
+// the components of the catalog if requested.
+func (o *offlineBundleCmdOptions) bundleDependencies(catalog *camel.RuntimeCatalog) []string {
+	dependencies := make(map[string]bool)
+	for _, d := range o.Dependencies {
+		dependencies[d] = true
+	}
+	for _, d := range catalog.Runtime.Dependencies {
+		dependencies[d.GetDependencyID()] = true
+	}
+	for _, l := range catalog.Loaders {
+		dependencies[l.GetDependencyID()] = true
+		for _, d := range l.Dependencies {
+			dependencies[d.GetDependencyID()] = true
+		}
+	}
+	if o.AllComponents {
+		for _, a := range catalog.Artifacts {
+			dependencies[a.GetDependencyID()] = true
+		}
+	}
+
+	ids := make([]string, 0, len(dependencies))
+	for d := range dependencies {
+		ids = append(ids, d)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+)
+
+func initializeOfflineBundleCmd(t *testing.T) (*offlineBundleCmdOptions, *cobra.Command) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	bundleCmd, bundleOptions := newCmdOfflineBundle(options)
+	bundleCmd.RunE = func(c *cobra.Command, args []string) error {
+		return bundleOptions.validate()
+	}
+	rootCmd.AddCommand(bundleCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return bundleOptions, rootCmd
+}
+
+func TestOfflineBundleFlags(t *testing.T) {
+	options, rootCmd := initializeOfflineBundleCmd(t)
+	_, err := ExecuteCommand(rootCmd, "bundle", "-d", "camel:kafka", "-d", "camel:http", "--all-components",
+		"-o", "bundle.tgz", "--image", "registry.acme.org/bundle:1.0", "--maven-repository", "https://repo.acme.org/maven")
+	require.NoError(t, err)
+	assert.Equal(t, defaults.DefaultRuntimeVersion, options.RuntimeVersion)
+	assert.Equal(t, string(v1.RuntimeProviderQuarkus), options.RuntimeProvider)
+	assert.Equal(t, []string{"camel:kafka", "camel:http"}, options.Dependencies)
+	assert.True(t, options.AllComponents)
+	assert.Equal(t, "bundle.tgz", options.Output)
+	assert.Equal(t, "registry.acme.org/bundle:1.0", options.Image)
+	assert.Equal(t, []string{"https://repo.acme.org/maven"}, options.MavenRepositories)
+}
+
+func TestOfflineBundleInvalidRuntimeProvider(t *testing.T) {
+	_, rootCmd := initializeOfflineBundleCmd(t)
+	_, err := ExecuteCommand(rootCmd, "bundle", "--runtime-provider", "spring-boot")
+	require.EqualError(t, err, "unsupported runtime provider: spring-boot. One of [quarkus, plain-quarkus] is expected")
+}
+
+func TestOfflineBundleImageRequiresArchive(t *testing.T) {
+	_, rootCmd := initializeOfflineBundleCmd(t)
+	_, err := ExecuteCommand(rootCmd, "bundle", "-o", "bundle", "--image", "registry.acme.org/bundle:1.0")
+	require.EqualError(t, err, "the bundle can only be pushed as an image when the output is a .tar.gz archive")
+}
+
+func TestOfflineBundleLocalDependency(t *testing.T) {
+	_, rootCmd := initializeOfflineBundleCmd(t)
+	_, err := ExecuteCommand(rootCmd, "bundle", "-d", "file:///tmp/lib.jar")
+	require.EqualError(t, err, "the local dependency file:///tmp/lib.jar cannot be added to a bundle")
+}
+
+func TestOfflineBundleDependencies(t *testing.T) {
+	catalog, err := camel.DefaultCatalog()
+	require.NoError(t, err)
+
+	o := offlineBundleCmdOptions{Dependencies: []string{"camel:kafka"}}
+	dependencies := o.bundleDependencies(catalog)
+	assert.Contains(t, dependencies, "camel:kafka")
+	for _, d := range catalog.Runtime.Dependencies {
+		assert.Contains(t, dependencies, d.GetDependencyID())
+	}
+	assert.IsIncreasing(t, dependencies)
+
+	o.AllComponents = true
+	assert.Greater(t, len(o.bundleDependencies(catalog)), len(dependencies))
+}
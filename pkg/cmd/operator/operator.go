@@ -57,6 +57,8 @@ import (
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/apache/camel-k/v2/pkg/client"
 	"github.com/apache/camel-k/v2/pkg/controller"
+	"github.com/apache/camel-k/v2/pkg/controller/gitwebhook"
+	"github.com/apache/camel-k/v2/pkg/controller/integrationkit"
 	"github.com/apache/camel-k/v2/pkg/controller/synthetic"
 	"github.com/apache/camel-k/v2/pkg/install"
 	"github.com/apache/camel-k/v2/pkg/platform"
@@ -232,20 +234,56 @@ func Run(healthPort, monitoringPort int32, leaderElection bool, leaderElectionID
 	} else {
 		log.Info("Synthetic Integration manager not configured, skipping")
 	}
+	var namespaces []string
+	if !platform.IsCurrentOperatorGlobal() {
+		namespaces = getNamespaces(operatorNamespace, watchNamespace)
+	}
+	if port, ok := os.LookupEnv(gitwebhook.PortEnvVariable); ok && port != "" {
+		log.Infof("Configuring the Git webhook on port %s", port)
+		handler, err := gitwebhook.NewHandler(ctrlClient, os.Getenv(gitwebhook.SecretEnvVariable), namespaces...)
+		exitOnError(err, "cannot configure the Git webhook")
+		exitOnError(mgr.Add(&gitwebhook.Server{
+			Addr:    ":" + port,
+			Handler: handler,
+		}), "cannot add the Git webhook server")
+	} else {
+		log.Info("Git webhook not configured, skipping")
+	}
+	gcInterval := integrationkit.DefaultGCInterval
+	if interval, ok := os.LookupEnv(integrationkit.GCIntervalEnvVariable); ok && interval != "" {
+		gcInterval, err = time.ParseDuration(interval)
+		exitOnError(err, "cannot parse the kit garbage collection interval")
+	}
+	if gcInterval > 0 {
+		log.Infof("Configuring the kit garbage collection every %s", gcInterval)
+		exitOnError(mgr.Add(&integrationkit.GarbageCollector{
+			Client:     ctrlClient,
+			Interval:   gcInterval,
+			Namespaces: namespaces,
+		}), "cannot add the kit garbage collector")
+	} else {
+		log.Info("Kit garbage collection disabled, skipping")
+	}
 	log.Info("Starting the manager")
 	exitOnError(mgr.Start(ctx), "manager exited non-zero")
 }
 
 func getNamespacesSelector(operatorNamespace string, watchNamespace string) map[string]cache.Config {
-	namespacesSelector := map[string]cache.Config{
-		operatorNamespace: {},
-	}
-	if operatorNamespace != watchNamespace {
-		namespacesSelector[watchNamespace] = cache.Config{}
+	namespacesSelector := map[string]cache.Config{}
+	for _, ns := range getNamespaces(operatorNamespace, watchNamespace) {
+		namespacesSelector[ns] = cache.Config{}
 	}
 	return namespacesSelector
 }
 
+// getNamespaces returns the namespaces watched by a local operator.
+func getNamespaces(operatorNamespace string, watchNamespace string) []string {
+	if operatorNamespace == watchNamespace {
+		return []string{operatorNamespace}
+	}
+	return []string{operatorNamespace, watchNamespace}
+}
+
 // getWatchNamespace returns the Namespace the operator should be watching for changes.
 func getWatchNamespace() (string, error) {
 	ns, found := os.LookupEnv(platform.OperatorWatchNamespaceEnvVariable)
@@ -0,0 +1,351 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/builder"
+	"github.com/apache/camel-k/v2/pkg/client"
+	platformutil "github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	k8slog "github.com/apache/camel-k/v2/pkg/util/kubernetes/log"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+const (
+	// platformCacheContainer is the name of the container running the cache command in the cluster.
+	platformCacheContainer = "maven-cache"
+	platformCacheVolume    = "camel-k-maven-cache"
+	platformCachePolling   = 2 * time.Second
+)
+
+func newCmdPlatformCache(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and clean the persistent Maven cache of the builds",
+		Long: `Inspect and clean the persistent Maven cache shared by the builds running in pods, as configured on the
+IntegrationPlatform with the spec.build.maven.cache field. The commands run in a pod mounting the cache.`,
+	}
+
+	stats, _ := newPlatformCacheCommand(rootCmdOptions, "stats", "Show the size, the usage and the leases of the Maven cache",
+		(*platformCacheCmdOptions).stats)
+
+	prune, _ := newPlatformCacheCommand(rootCmdOptions, "prune", "Evict the least recently used artifacts from the Maven cache",
+		(*platformCacheCmdOptions).prune)
+	prune.Example = `  kamel platform cache prune --max-size 5Gi
+  kamel platform cache prune --unused-for 720h`
+	prune.Flags().String("max-size", "", "The size to prune the cache down to, e.g. 5Gi, defaults to the maximum size of the cache")
+	prune.Flags().Duration("unused-for", 0, "Also evict the artifacts that have not been used for the given duration, e.g. 720h")
+
+	cmd.AddCommand(stats, prune)
+
+	return &cmd
+}
+
+type platformCacheCmdOptions struct {
+	*RootCmdOptions
+	Platform  string        `mapstructure:"platform" yaml:",omitempty"`
+	Image     string        `mapstructure:"image" yaml:",omitempty"`
+	Timeout   time.Duration `mapstructure:"timeout" yaml:",omitempty"`
+	Directory string        `mapstructure:"directory" yaml:",omitempty"`
+	// Prune options
+	MaxSize   string        `mapstructure:"max-size" yaml:",omitempty"`
+	UnusedFor time.Duration `mapstructure:"unused-for" yaml:",omitempty"`
+}
+
+func newPlatformCacheCommand(rootCmdOptions *RootCmdOptions, use string, short string,
+	run func(*platformCacheCmdOptions, *cobra.Command, maven.Cache) error) (*cobra.Command, *platformCacheCmdOptions) {
+	options := platformCacheCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:               use,
+		Short:             short,
+		PersistentPreRunE: decode(&options, options.Flags),
+		PreRunE:           options.preRunE,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			if options.Directory == "" {
+				return options.runInPod(cmd)
+			}
+			holder, err := os.Hostname()
+			if err != nil {
+				holder = "kamel"
+			}
+
+			return run(&options, cmd, maven.NewCache(options.Directory, holder))
+		},
+		Annotations: make(map[string]string),
+	}
+
+	cmd.Flags().String("platform", "", "The IntegrationPlatform configuring the cache, defaults to the platform of the namespace")
+	cmd.Flags().String("image", defaults.ImageName+":"+defaults.Version, "The image of the pod running the command in the cluster")
+	cmd.Flags().Duration("timeout", 10*time.Minute, "The time to wait for the command to complete in the cluster")
+	cmd.Flags().String("directory", "", "The directory of the cache, when the command runs where the cache is mounted")
+	_ = cmd.Flags().MarkHidden("directory")
+
+	return &cmd, &options
+}
+
+func (o *platformCacheCmdOptions) preRunE(cmd *cobra.Command, args []string) error {
+	if o.Directory != "" {
+		// the command runs in the pod mounting the cache
+		cmd.Annotations[offlineCommandLabel] = "true"
+	}
+	return o.RootCmdOptions.preRun(cmd, args)
+}
+
+func (o *platformCacheCmdOptions) validate() error {
+	if o.MaxSize != "" {
+		if _, err := resource.ParseQuantity(o.MaxSize); err != nil {
+			return fmt.Errorf("invalid maximum size %s: %w", o.MaxSize, err)
+		}
+	}
+	if o.UnusedFor < 0 {
+		return errors.New("the unused duration must be positive")
+	}
+
+	return nil
+}
+
+func (o *platformCacheCmdOptions) stats(cmd *cobra.Command, cache maven.Cache) error {
+	stats, err := cache.Stats()
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(w, "Size:\t\t\t%s\n", formatCacheSize(stats.Size))
+	fmt.Fprintf(w, "Artifacts:\t\t%d\n", stats.Entries)
+	if stats.Entries > 0 {
+		fmt.Fprintf(w, "Least recently used:\t%s\n", stats.LeastRecentlyUsed.Format(time.RFC3339))
+		fmt.Fprintf(w, "Most recently used:\t%s\n", stats.MostRecentlyUsed.Format(time.RFC3339))
+	}
+	if stats.Writer != "" {
+		fmt.Fprintf(w, "Writer:\t\t\t%s\n", stats.Writer)
+	}
+	if len(stats.Readers) > 0 {
+		fmt.Fprintf(w, "Readers:\t\t%s\n", strings.Join(stats.Readers, ", "))
+	}
+
+	return nil
+}
+
+func (o *platformCacheCmdOptions) prune(cmd *cobra.Command, cache maven.Cache) error {
+	maxSize := int64(0)
+	if o.MaxSize != "" {
+		q := resource.MustParse(o.MaxSize)
+		maxSize = q.Value()
+	}
+	unusedSince := time.Time{}
+	if o.UnusedFor > 0 {
+		unusedSince = time.Now().Add(-o.UnusedFor)
+	}
+	if maxSize == 0 && unusedSince.IsZero() {
+		return errors.New("either the maximum size or the unused duration must be set")
+	}
+
+	release, err := cache.Lock(o.Context, true)
+	if err != nil {
+		return err
+	}
+	defer release()
+	evicted, err := cache.Prune(maxSize, unusedSince)
+	freed := int64(0)
+	for _, e := range evicted {
+		freed += e.Size
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Evicted %d artifacts, %s freed\n", len(evicted), formatCacheSize(freed))
+
+	return err
+}
+
+// runInPod runs the command in a pod mounting the cache of the platform, and prints its output.
+func (o *platformCacheCmdOptions) runInPod(cmd *cobra.Command) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+	platform, cache, err := o.lookupCache(o.Context, c)
+	if err != nil {
+		return err
+	}
+	args, err := o.podArgs(cmd, cache)
+	if err != nil {
+		return err
+	}
+
+	pod := newPlatformCachePod(platform.Namespace, cache.PersistentVolumeClaim, o.Image, args)
+	if err := c.Create(o.Context, pod); err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.Delete(context.Background(), pod); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Unable to delete pod %s: %s\n", pod.Name, err.Error())
+		}
+	}()
+
+	err = wait.PollUntilContextTimeout(o.Context, platformCachePolling, o.Timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, k8sclient.ObjectKeyFromObject(pod), pod); err != nil {
+			return false, err
+		}
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return fmt.Errorf("pod %s did not complete: %w", pod.Name, err)
+	}
+	logs, err := k8slog.DumpLog(o.Context, c, pod, corev1.PodLogOptions{Container: platformCacheContainer})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), logs)
+	if pod.Status.Phase == corev1.PodFailed {
+		return fmt.Errorf("the command failed on the Maven cache %s", cache.PersistentVolumeClaim)
+	}
+
+	return nil
+}
+
+// lookupCache returns the platform and its cache configuration.
+func (o *platformCacheCmdOptions) lookupCache(ctx context.Context, c client.Client) (*v1.IntegrationPlatform, *v1.MavenCacheSpec, error) {
+	var platform *v1.IntegrationPlatform
+	var err error
+	if o.Platform != "" {
+		platform, err = platformutil.GetForName(ctx, c, o.Namespace, o.Platform)
+	} else {
+		platform, err = platformutil.GetOrFindLocal(ctx, c, o.Namespace)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to find the IntegrationPlatform in namespace %s: %w", o.Namespace, err)
+	}
+	cache := platform.Status.Build.Maven.Cache
+	if cache == nil {
+		cache = platform.Spec.Build.Maven.Cache
+	}
+	if cache == nil {
+		return nil, nil, fmt.Errorf("no Maven cache is configured on IntegrationPlatform %s", platform.Name)
+	}
+
+	return platform, cache, nil
+}
+
+// podArgs returns the arguments of the command running in the pod mounting the cache.
+func (o *platformCacheCmdOptions) podArgs(cmd *cobra.Command, cache *v1.MavenCacheSpec) ([]string, error) {
+	args := []string{"kamel", "platform", "cache", cmd.Name(), "--directory", builder.MavenCacheDir}
+	if cmd.Name() != "prune" {
+		return args, nil
+	}
+	maxSize := o.MaxSize
+	if maxSize == "" {
+		size, err := cache.GetMaxSize()
+		if err != nil {
+			return nil, err
+		}
+		maxSize = strconv.FormatInt(size, 10)
+	}
+	args = append(args, "--max-size", maxSize)
+	if o.UnusedFor > 0 {
+		args = append(args, "--unused-for", o.UnusedFor.String())
+	}
+
+	return args, nil
+}
+
+func newPlatformCachePod(namespace string, claim string, image string, args []string) *corev1.Pod {
+	var ugfid int64 = 1001
+
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    namespace,
+			GenerateName: "camel-k-maven-cache-",
+			Labels: map[string]string{
+				"camel.apache.org/component": "maven-cache",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			// The same user as the builder pods writing into the cache
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:  &ugfid,
+				RunAsGroup: &ugfid,
+				FSGroup:    &ugfid,
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            platformCacheContainer,
+					Image:           image,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Command:         args,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      platformCacheVolume,
+							MountPath: builder.MavenCacheDir,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: platformCacheVolume,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claim,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// formatCacheSize returns the given size in bytes with a binary unit.
+func formatCacheSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+func initializePlatformCacheCmd(t *testing.T, platforms ...*v1.IntegrationPlatform) (*cobra.Command, *platformCacheCmdOptions, *platformCacheCmdOptions) {
+	t.Helper()
+
+	fakeClient, err := internal.NewFakeClient()
+	require.NoError(t, err)
+	for _, p := range platforms {
+		require.NoError(t, fakeClient.Create(context.TODO(), p))
+	}
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	statsCmd, statsOptions := newPlatformCacheCommand(options, "stats", "", (*platformCacheCmdOptions).stats)
+	pruneCmd, pruneOptions := newPlatformCacheCommand(options, "prune", "", (*platformCacheCmdOptions).prune)
+	pruneCmd.Flags().String("max-size", "", "")
+	pruneCmd.Flags().Duration("unused-for", 0, "")
+	rootCmd.AddCommand(statsCmd, pruneCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, statsOptions, pruneOptions
+}
+
+func newTestCacheDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, gav := range []string{"org/acme/a/1.0", "org/acme/b/1.0"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, gav), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, gav, "artifact.jar"), make([]byte, 2048), 0o600))
+	}
+
+	return dir
+}
+
+func TestPlatformCacheStats(t *testing.T) {
+	rootCmd, _, _ := initializePlatformCacheCmd(t)
+	output, err := ExecuteCommand(rootCmd, "stats", "--directory", newTestCacheDir(t))
+	require.NoError(t, err)
+	assert.Contains(t, output, "Size:\t\t\t4.0KiB\n")
+	assert.Contains(t, output, "Artifacts:\t\t2\n")
+	assert.NotContains(t, output, "Writer:")
+}
+
+func TestPlatformCachePrune(t *testing.T) {
+	dir := newTestCacheDir(t)
+	rootCmd, _, _ := initializePlatformCacheCmd(t)
+	output, err := ExecuteCommand(rootCmd, "prune", "--directory", dir, "--max-size", "3Ki")
+	require.NoError(t, err)
+	assert.Equal(t, "Evicted 1 artifacts, 2.0KiB freed\n", output)
+
+	rootCmd, _, _ = initializePlatformCacheCmd(t)
+	_, err = ExecuteCommand(rootCmd, "prune", "--directory", dir)
+	require.EqualError(t, err, "either the maximum size or the unused duration must be set")
+
+	rootCmd, _, _ = initializePlatformCacheCmd(t)
+	_, err = ExecuteCommand(rootCmd, "prune", "--directory", dir, "--max-size", "big")
+	require.ErrorContains(t, err, "invalid maximum size big")
+}
+
+func TestPlatformCachePodArgs(t *testing.T) {
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+	platform.Spec.Build.Maven.Cache = &v1.MavenCacheSpec{
+		PersistentVolumeClaim: "maven-cache",
+		Size:                  "1Gi",
+	}
+	rootCmd, _, options := initializePlatformCacheCmd(t, &platform)
+	pruneCmd, _, err := rootCmd.Find([]string{"prune"})
+	require.NoError(t, err)
+	options.UnusedFor = time.Hour
+
+	_, cache, err := options.lookupCache(context.TODO(), options._client)
+	require.NoError(t, err)
+	assert.Equal(t, "maven-cache", cache.PersistentVolumeClaim)
+	args, err := options.podArgs(pruneCmd, cache)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kamel", "platform", "cache", "prune", "--directory", "/etc/maven/cache",
+		"--max-size", "858993459", "--unused-for", "1h0m0s"}, args)
+
+	pod := newPlatformCachePod("default", cache.PersistentVolumeClaim, "camel-k:test", args)
+	assert.Equal(t, "maven-cache", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "/etc/maven/cache", pod.Spec.Containers[0].VolumeMounts[0].MountPath)
+	assert.Equal(t, args, pod.Spec.Containers[0].Command)
+
+	platform.Spec.Build.Maven.Cache = nil
+	require.NoError(t, options._client.Update(context.TODO(), &platform))
+	_, _, err = options.lookupCache(context.TODO(), options._client)
+	require.EqualError(t, err, "no Maven cache is configured on IntegrationPlatform camel-k")
+}
@@ -0,0 +1,207 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+)
+
+const (
+	// PluginPrefix is the prefix of the executables found on the PATH that are exposed as kamel commands.
+	PluginPrefix = "kamel-"
+
+	// PluginNamespaceEnv is the environment variable holding the namespace kamel resolved for the plugin.
+	PluginNamespaceEnv = "KAMEL_NAMESPACE"
+	// PluginKubeConfigEnv is the environment variable holding the kube config file kamel uses.
+	PluginKubeConfigEnv = "KAMEL_KUBECONFIG"
+	// PluginOperatorIDEnv is the environment variable holding the operator id kamel uses.
+	PluginOperatorIDEnv = "KAMEL_OPERATOR_ID"
+	// PluginVerboseEnv is the environment variable set to true when kamel runs in verbose mode.
+	PluginVerboseEnv = "KAMEL_VERBOSE"
+
+	pluginCommandLabel = "plugin"
+)
+
+// findPlugins returns the plugin executables found in the given PATH, indexed by command name.
+// As for the shell, the first executable found for a given name wins.
+func findPlugins(path string) map[string]string {
+	plugins := make(map[string]string)
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if _, found := plugins[name]; found {
+				continue
+			}
+			file := filepath.Join(dir, entry.Name())
+			if isExecutable(file) {
+				plugins[name] = file
+			}
+		}
+	}
+
+	return plugins
+}
+
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, PluginPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, PluginPrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	return name, name != ""
+}
+
+func isExecutable(file string) bool {
+	info, err := os.Stat(file)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(file))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+
+	return info.Mode()&0o111 != 0
+}
+
+// addKamelPlugins adds a command for each plugin found on the PATH. Plugins cannot override built-in commands.
+func addKamelPlugins(cmd *cobra.Command, options *RootCmdOptions) {
+	plugins := findPlugins(os.Getenv("PATH"))
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if sub, _, err := cmd.Find([]string{name}); err == nil && sub != cmd {
+			continue
+		}
+		cmd.AddCommand(newCmdPlugin(options, name, plugins[name]))
+	}
+}
+
+func newCmdPlugin(rootCmdOptions *RootCmdOptions, name string, path string) *cobra.Command {
+	options := pluginCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+		Path:           path,
+	}
+
+	return &cobra.Command{
+		Use:                name,
+		Short:              fmt.Sprintf("Run the %s%s plugin (%s)", PluginPrefix, name, path),
+		DisableFlagParsing: true,
+		SilenceErrors:      true,
+		Annotations: map[string]string{
+			offlineCommandLabel: "true",
+			pluginCommandLabel:  "true",
+		},
+		RunE: options.run,
+	}
+}
+
+type pluginCmdOptions struct {
+	*RootCmdOptions
+	Path string
+}
+
+func (o *pluginCmdOptions) run(cmd *cobra.Command, args []string) error {
+	plugin := exec.CommandContext(o.Context, o.Path, args...)
+	plugin.Env = append(os.Environ(), o.pluginEnv(args)...)
+	plugin.Stdin = cmd.InOrStdin()
+	plugin.Stdout = cmd.OutOrStdout()
+	plugin.Stderr = cmd.ErrOrStderr()
+
+	return plugin.Run()
+}
+
+// pluginEnv returns the environment passed to a plugin, resolving the global flags the plugin is invoked with
+// the same way built-in commands do.
+func (o *pluginCmdOptions) pluginEnv(args []string) []string {
+	flags := pflag.NewFlagSet(PluginPrefix, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.Usage = func() {}
+	kubeConfig := flags.String("kube-config", o.KubeConfig, "")
+	namespace := flags.StringP("namespace", "n", o.Namespace, "")
+	verbose := flags.BoolP("verbose", "V", o.Verbose, "")
+	operatorID := flags.StringP("operator-id", "x", defaults.OperatorID(), "")
+	// Plugin specific flags are ignored, the plugin parses the arguments on its own
+	_ = flags.Parse(args)
+
+	if *namespace == "" {
+		*namespace = o.Flags.GetString(DefaultNamespaceConfigKey)
+	}
+	if *namespace == "" {
+		if current, err := client.GetCurrentNamespace(*kubeConfig); err == nil {
+			*namespace = current
+		}
+	}
+
+	env := []string{
+		PluginNamespaceEnv + "=" + *namespace,
+		PluginKubeConfigEnv + "=" + *kubeConfig,
+		PluginVerboseEnv + "=" + strconv.FormatBool(*verbose),
+	}
+	if *operatorID != "" {
+		env = append(env, PluginOperatorIDEnv+"="+*operatorID)
+	}
+
+	return env
+}
+
+func isPluginCommand(cmd *cobra.Command) bool {
+	return cmd.Annotations[pluginCommandLabel] == "true"
+}
+
+func hasPluginCommands(cmd *cobra.Command) bool {
+	for _, c := range cmd.Commands() {
+		if isPluginCommand(c) {
+			return true
+		}
+	}
+
+	return false
+}
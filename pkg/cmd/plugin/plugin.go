@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin helps writing kamel plugins, that are executables named kamel-<name> found on the PATH,
+// which kamel invokes as "kamel <name>".
+package plugin
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/apache/camel-k/v2/pkg/cmd"
+)
+
+// NewRootCmdOptions returns the kamel root options, initialized from the environment kamel sets when it
+// invokes a plugin, so that the plugin can share the kamel client configuration.
+func NewRootCmdOptions(ctx context.Context) *cmd.RootCmdOptions {
+	childCtx, childCancel := context.WithCancel(ctx)
+	kubeConfig := os.Getenv(cmd.PluginKubeConfigEnv)
+	if kubeConfig == "" {
+		kubeConfig = os.Getenv("KUBECONFIG")
+	}
+	verbose, _ := strconv.ParseBool(os.Getenv(cmd.PluginVerboseEnv))
+
+	return &cmd.RootCmdOptions{
+		RootContext:   ctx,
+		Context:       childCtx,
+		ContextCancel: childCancel,
+		Flags:         viper.New(),
+		KubeConfig:    kubeConfig,
+		Namespace:     os.Getenv(cmd.PluginNamespaceEnv),
+		Verbose:       verbose,
+	}
+}
+
+// NewCommand creates the root command of a plugin, with the kamel global flags bound to the returned options.
+// The flags default to the values passed by kamel, so that they are honored whether they are set before or
+// after the plugin name.
+func NewCommand(ctx context.Context, name string, short string) (*cobra.Command, *cmd.RootCmdOptions) {
+	options := NewRootCmdOptions(ctx)
+	command := cobra.Command{
+		Use:          name,
+		Short:        short,
+		SilenceUsage: true,
+	}
+
+	command.PersistentFlags().StringVar(&options.KubeConfig, "kube-config", options.KubeConfig, "Path to the kube config file to use for CLI requests")
+	command.PersistentFlags().StringVarP(&options.Namespace, "namespace", "n", options.Namespace, "Namespace to use for all operations")
+	command.PersistentFlags().BoolVarP(&options.Verbose, "verbose", "V", options.Verbose, "Verbose logging")
+
+	return &command, options
+}
+
+// OperatorID returns the id of the operator kamel targets, if any.
+func OperatorID() string {
+	return os.Getenv(cmd.PluginOperatorIDEnv)
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/camel-k/v2/pkg/cmd"
+)
+
+func TestNewCommand(t *testing.T) {
+	t.Setenv(cmd.PluginNamespaceEnv, "my-ns")
+	t.Setenv(cmd.PluginKubeConfigEnv, "/tmp/kubeconfig")
+	t.Setenv(cmd.PluginOperatorIDEnv, "my-op")
+	t.Setenv(cmd.PluginVerboseEnv, "true")
+
+	command, options := NewCommand(context.Background(), "kamel-hello", "Say hello")
+	assert.Equal(t, "my-ns", options.Namespace)
+	assert.Equal(t, "/tmp/kubeconfig", options.KubeConfig)
+	assert.True(t, options.Verbose)
+	assert.Equal(t, "my-op", OperatorID())
+
+	command.SetArgs([]string{"-n", "other"})
+	command.Run = func(*cobra.Command, []string) {}
+	require.NoError(t, command.Execute())
+	assert.Equal(t, "other", options.Namespace)
+}
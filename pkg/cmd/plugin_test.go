@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePlugin(t *testing.T, dir string, name string, script string) string {
+	t.Helper()
+	file := filepath.Join(dir, PluginPrefix+name)
+	require.NoError(t, os.WriteFile(file, []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+	return file
+}
+
+func TestFindPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	first := t.TempDir()
+	second := t.TempDir()
+	foo := writePlugin(t, first, "foo", "true")
+	writePlugin(t, second, "foo", "false")
+	bar := writePlugin(t, second, "bar", "true")
+	require.NoError(t, os.WriteFile(filepath.Join(first, PluginPrefix+"data"), []byte("data"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(first, PluginPrefix+"dir"), 0o755))
+
+	plugins := findPlugins(first + string(os.PathListSeparator) + second)
+	assert.Equal(t, map[string]string{"foo": foo, "bar": bar}, plugins)
+}
+
+func TestPluginCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "hello", `echo "ns=$KAMEL_NAMESPACE id=$KAMEL_OPERATOR_ID kube=$KAMEL_KUBECONFIG args=$*"`)
+	writePlugin(t, dir, "fail", "exit 3")
+	writePlugin(t, dir, "run", "echo plugin")
+	t.Setenv("PATH", dir)
+	t.Setenv("KUBECONFIG", "/tmp/kubeconfig")
+
+	rootCmd, err := NewKamelCommand(context.Background())
+	require.NoError(t, err)
+
+	output, err := ExecuteCommand(rootCmd, "help")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Plugins:\n")
+	assert.Contains(t, output, "Run the kamel-hello plugin")
+	assert.NotContains(t, output, "Run the kamel-run plugin")
+
+	output, err = ExecuteCommand(rootCmd, "-n", "other", "hello", "-x", "my-op", "world", "--flag")
+	require.NoError(t, err)
+	assert.Equal(t, "ns=other id=my-op kube=/tmp/kubeconfig args=-n other -x my-op world --flag\n", output)
+
+	_, err = ExecuteCommand(rootCmd, "fail")
+	require.Error(t, err)
+}
@@ -65,6 +65,7 @@ func NewKamelCommand(ctx context.Context) (*cobra.Command, error) {
 
 	cmd := kamelPreAddCommandInit(&options)
 	addKamelSubcommands(cmd, &options)
+	addKamelPlugins(cmd, &options)
 
 	if err := addHelpSubCommands(cmd); err != nil {
 		return cmd, err
@@ -88,8 +89,11 @@ func kamelPreAddCommandInit(options *RootCmdOptions) *cobra.Command {
 	cmd.PersistentFlags().StringVar(&options.KubeConfig, "kube-config", os.Getenv("KUBECONFIG"), "Path to the kube config file to use for CLI requests")
 	cmd.PersistentFlags().StringVarP(&options.Namespace, "namespace", "n", "", "Namespace to use for all operations")
 	cmd.PersistentFlags().BoolVarP(&options.Verbose, "verbose", "V", false, "Verbose logging")
+	cmd.PersistentFlags().String("context", "", "Named context of the kamel configuration file to use, instead of the current one")
 
 	cobra.AddTemplateFunc("wrappedFlagUsages", wrappedFlagUsages)
+	cobra.AddTemplateFunc("isPlugin", isPluginCommand)
+	cobra.AddTemplateFunc("hasPlugins", hasPluginCommands)
 	cmd.SetUsageTemplate(usageTemplate)
 
 	return &cmd
@@ -130,6 +134,8 @@ func kamelPostAddCommandInit(cmd *cobra.Command, v *viper.Viper) error {
 		}
 	}
 
+	withConfigContext(cmd, v)
+
 	return nil
 }
 
@@ -154,6 +160,16 @@ func addKamelSubcommands(cmd *cobra.Command, options *RootCmdOptions) {
 	cmd.AddCommand(cmdOnly(newCmdPromote(options)))
 	cmd.AddCommand(newCmdKamelet(options))
 	cmd.AddCommand(cmdOnly(newCmdConfig(options)))
+	cmd.AddCommand(cmdOnly(newCmdTop(options)))
+	cmd.AddCommand(cmdOnly(newCmdSend(options)))
+	cmd.AddCommand(cmdOnly(newCmdRoutes(options)))
+	cmd.AddCommand(newCmdJVM(options))
+	cmd.AddCommand(newCmdOffline(options))
+	cmd.AddCommand(newCmdPlatform(options))
+	cmd.AddCommand(cmdOnly(newCmdDoctor(options)))
+	cmd.AddCommand(cmdOnly(newCmdSuspend(options)))
+	cmd.AddCommand(cmdOnly(newCmdResume(options)))
+	cmd.AddCommand(cmdOnly(newCmdWait(options)))
 }
 
 func addHelpSubCommands(cmd *cobra.Command) error {
@@ -182,7 +198,7 @@ func (command *RootCmdOptions) preRun(cmd *cobra.Command, _ []string) error {
 			return fmt.Errorf("cannot get command client: %w", err)
 		}
 		if command.Namespace == "" {
-			current := command.Flags.GetString("kamel.config.default-namespace")
+			current := command.Flags.GetString(DefaultNamespaceConfigKey)
 			if current == "" {
 				defaultNS, err := c.GetCurrentNamespace(command.KubeConfig)
 				if err != nil {
@@ -278,7 +294,10 @@ var usageTemplate = `Usage:{{if .Runnable}}
   {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
   {{.CommandPath}} [command]{{end}}{{if .HasAvailableSubCommands}}
 
-Available Commands:{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+Available Commands:{{range .Commands}}{{if (and (not (isPlugin .)) (or .IsAvailableCommand (eq .Name "help")))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if hasPlugins .}}
+
+Plugins:{{range .Commands}}{{if isPlugin .}}
   {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
 
 Flags:
@@ -0,0 +1,234 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/jolokia"
+)
+
+// routeAttributes are the Camel ManagedRoute MBean attributes displayed by the routes command.
+var routeAttributes = []string{
+	"RouteId",
+	"EndpointUri",
+	"State",
+	"Uptime",
+	"ExchangesTotal",
+	"ExchangesFailed",
+	"ExchangesInflight",
+	"MeanProcessingTime",
+}
+
+// routeInfo holds the ManagedRoute MBean attributes of a route running in a pod.
+type routeInfo struct {
+	Pod                string `json:"-"`
+	RouteID            string `json:"RouteId"`
+	EndpointURI        string `json:"EndpointUri"`
+	State              string `json:"State"`
+	Uptime             string `json:"Uptime"`
+	ExchangesTotal     int64  `json:"ExchangesTotal"`
+	ExchangesFailed    int64  `json:"ExchangesFailed"`
+	ExchangesInflight  int64  `json:"ExchangesInflight"`
+	MeanProcessingTime int64  `json:"MeanProcessingTime"`
+}
+
+func newCmdRoutes(rootCmdOptions *RootCmdOptions) (*cobra.Command, *routesCmdOptions) {
+	options := routesCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "routes [integration name]",
+		Short: "Inspect and control the Camel routes of an integration",
+		Long: `List the Camel routes running in every pod of an integration, along with their state and exchange statistics.
+The routes are inspected through the Jolokia agent, which requires the jolokia trait to be enabled.`,
+		Args:    options.validateArgs,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	addRoutesFlags(&cmd)
+
+	cmd.AddCommand(cmdOnly(newCmdRouteAction(rootCmdOptions, "start", "Start a route in every pod of an integration")))
+	cmd.AddCommand(cmdOnly(newCmdRouteAction(rootCmdOptions, "stop", "Stop a route in every pod of an integration")))
+	cmd.AddCommand(cmdOnly(newCmdRouteAction(rootCmdOptions, "suspend", "Suspend a route in every pod of an integration")))
+	cmd.AddCommand(cmdOnly(newCmdRouteAction(rootCmdOptions, "resume", "Resume a suspended route in every pod of an integration")))
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd, &options
+}
+
+func addRoutesFlags(cmd *cobra.Command) {
+	cmd.Flags().String("pod", "", "Restrict the operation to the given integration pod")
+	addJolokiaFlags(cmd)
+}
+
+type routesCmdOptions struct {
+	*RootCmdOptions
+	Pod               string `mapstructure:"pod" yaml:",omitempty"`
+	JolokiaPort       uint   `mapstructure:"jolokia-port" yaml:",omitempty"`
+	JolokiaClientCert string `mapstructure:"jolokia-client-cert" yaml:",omitempty"`
+	JolokiaClientKey  string `mapstructure:"jolokia-client-key" yaml:",omitempty"`
+}
+
+func (o *routesCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("routes expects 1 argument, received %d", len(args))
+	}
+	return nil
+}
+
+func (o *routesCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	pods, err := lookupIntegrationPods(o.Context, c, o.Namespace, args[0], o.Pod)
+	if err != nil {
+		return err
+	}
+
+	routes := make([]routeInfo, 0)
+	for i := range pods {
+		podRoutes, err := o.listRoutes(c, &pods[i])
+		if err != nil {
+			return err
+		}
+		routes = append(routes, podRoutes...)
+	}
+
+	return printRoutes(cmd.OutOrStdout(), routes)
+}
+
+func (o *routesCmdOptions) listRoutes(c client.Client, pod *corev1.Pod) ([]routeInfo, error) {
+	ctx, cancel := context.WithCancel(o.Context)
+	defer cancel()
+
+	jc, err := newJolokiaClient(ctx, c, pod, o.JolokiaPort, o.JolokiaClientCert, o.JolokiaClientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := jc.Search(ctx, jolokia.CamelRouteMBeanPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]routeInfo, 0, len(names))
+	for _, name := range names {
+		route := routeInfo{}
+		if err := jc.Read(ctx, name, &route, routeAttributes...); err != nil {
+			return nil, fmt.Errorf("cannot read route %q of pod %q: %w", jolokia.MBeanProperty(name, "name"), pod.Name, err)
+		}
+		route.Pod = pod.Name
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// routeAction invokes the given ManagedRoute MBean operation on the route in every selected pod.
+func (o *routesCmdOptions) routeAction(cmd *cobra.Command, integration string, routeID string, operation string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	pods, err := lookupIntegrationPods(o.Context, c, o.Namespace, integration, o.Pod)
+	if err != nil {
+		return err
+	}
+
+	for i := range pods {
+		if err := o.podRouteAction(c, &pods[i], routeID, operation); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Route %q of pod %q: %s executed\n", routeID, pods[i].Name, operation)
+	}
+
+	return nil
+}
+
+func (o *routesCmdOptions) podRouteAction(c client.Client, pod *corev1.Pod, routeID string, operation string) error {
+	ctx, cancel := context.WithCancel(o.Context)
+	defer cancel()
+
+	jc, err := newJolokiaClient(ctx, c, pod, o.JolokiaPort, o.JolokiaClientCert, o.JolokiaClientKey)
+	if err != nil {
+		return err
+	}
+
+	names, err := jc.Search(ctx, jolokia.CamelRouteMBeanPattern)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if jolokia.MBeanProperty(name, "name") == routeID {
+			return jc.Exec(ctx, name, operation+"()", nil)
+		}
+	}
+
+	return fmt.Errorf("route %q not found in pod %q", routeID, pod.Name)
+}
+
+func printRoutes(out io.Writer, routes []routeInfo) error {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "POD\tID\tFROM\tSTATE\tUPTIME\tTOTAL\tFAILED\tINFLIGHT\tMEAN TIME")
+	for _, r := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n", r.Pod, r.RouteID, r.EndpointURI, r.State, r.Uptime,
+			r.ExchangesTotal, r.ExchangesFailed, r.ExchangesInflight, time.Duration(r.MeanProcessingTime)*time.Millisecond)
+	}
+
+	return w.Flush()
+}
+
+func newCmdRouteAction(rootCmdOptions *RootCmdOptions, operation string, description string) (*cobra.Command, *routesCmdOptions) {
+	options := routesCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:     operation + " [integration name] [route id]",
+		Short:   description,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("routes %s expects 2 arguments, received %d", operation, len(args))
+			}
+			return options.routeAction(cmd, args[0], args[1], operation)
+		},
+	}
+
+	addRoutesFlags(&cmd)
+
+	return &cmd, &options
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cmdRoutes = "routes"
+
+// nolint: unparam
+func initializeRoutesCmdOptions(t *testing.T) (*routesCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	routesCmdOptions := addTestRoutesCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return routesCmdOptions, rootCmd, *options
+}
+
+func addTestRoutesCmd(options RootCmdOptions, rootCmd *cobra.Command) *routesCmdOptions {
+	// add a testing version of routes Command
+	routesCmd, routesOptions := newCmdRoutes(&options)
+	routesCmd.RunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	routesCmd.PostRunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	routesCmd.Args = ArbitraryArgs
+	rootCmd.AddCommand(routesCmd)
+	return routesOptions
+}
+
+func TestRoutesNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeRoutesCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdRoutes, "--nonExistingFlag")
+	require.Error(t, err)
+}
+
+func TestRoutesFlags(t *testing.T) {
+	routesCmdOptions, rootCmd, _ := initializeRoutesCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdRoutes, "my-it", "--pod", "my-it-1234", "--jolokia-port", "8779",
+		"--jolokia-client-cert", "tls.crt", "--jolokia-client-key", "tls.key")
+	require.NoError(t, err)
+	assert.Equal(t, "my-it-1234", routesCmdOptions.Pod)
+	assert.Equal(t, uint(8779), routesCmdOptions.JolokiaPort)
+	assert.Equal(t, "tls.crt", routesCmdOptions.JolokiaClientCert)
+	assert.Equal(t, "tls.key", routesCmdOptions.JolokiaClientKey)
+}
+
+func TestJolokiaPort(t *testing.T) {
+	// The port of the jolokia trait
+	assert.Equal(t, uint(9000), jolokiaPort(0, map[string]string{"port": "9000", "protocol": "https"}))
+	// The port set explicitly
+	assert.Equal(t, uint(8779), jolokiaPort(8779, map[string]string{"port": "9000"}))
+	// The default port
+	assert.Equal(t, uint(8778), jolokiaPort(0, map[string]string{}))
+	assert.Equal(t, uint(8778), jolokiaPort(0, map[string]string{"port": "invalid"}))
+}
+
+func TestRoutesActionMissingRoute(t *testing.T) {
+	_, rootCmd, _ := initializeRoutesCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdRoutes, "stop", "my-it")
+	require.EqualError(t, err, "routes stop expects 2 arguments, received 1")
+}
+
+func TestPrintRoutes(t *testing.T) {
+	buf := bytes.Buffer{}
+	require.NoError(t, printRoutes(&buf, []routeInfo{
+		{
+			Pod:                "my-it-1234",
+			RouteID:            "route1",
+			EndpointURI:        "timer://tick",
+			State:              "Started",
+			Uptime:             "1m2s",
+			ExchangesTotal:     10,
+			ExchangesFailed:    1,
+			MeanProcessingTime: 1500,
+		},
+	}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"my-it-1234", "route1", "timer://tick", "Started", "1m2s", "10", "1", "0", "1.5s"}, strings.Fields(lines[1]))
+}
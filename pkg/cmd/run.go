@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"path"
+	"path/filepath"
 
 	// this is needed to generate an SHA1 sum for Jars
 	// #nosec G501
@@ -70,9 +71,12 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 	}
 
 	cmd := cobra.Command{
-		Use:               "run [file to run]",
-		Short:             "Run a integration on Kubernetes",
-		Long:              `Deploys and execute a integration pod on Kubernetes.`,
+		Use:   "run [file to run]",
+		Short: "Run a integration on Kubernetes",
+		Long: `Deploys and execute a integration pod on Kubernetes.
+A directory can be run as an integration, its route files being the sources, its .properties files the properties
+files, its *.openapi.(yaml|yml|json) files the OpenAPI specs, its kamel.yaml file the manifest holding traits and
+dependencies, and any other file a resource.`,
 		Args:              options.validateArgs,
 		PersistentPreRunE: options.decode,
 		PreRunE:           options.preRun,
@@ -117,6 +121,11 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) (*cobra.Command, *runCmdOptions)
 	cmd.Flags().String("service-account", "", "The SA to use to run this Integration")
 	cmd.Flags().Bool("force", false, "Force creation of integration regardless of potential misconfiguration.")
 	cmd.Flags().String("git", "", "A Git repository containing the project to build.")
+	cmd.Flags().String("git-branch", "", "The branch of the Git repository to build")
+	cmd.Flags().String("git-tag", "", "The tag of the Git repository to build")
+	cmd.Flags().String("git-commit", "", "The commit of the Git repository to build")
+	cmd.Flags().String("git-path", "", "The directory of the project to build within the Git repository")
+	cmd.Flags().String("git-secret", "", "The secret holding the token, or the SSH private key, to access the Git repository")
 	cmd.Flags().Bool("save", false, "Save the run parameters into the default kamel configuration file (kamel-config.yaml)")
 
 	// completion support
@@ -138,6 +147,11 @@ type runCmdOptions struct {
 	IntegrationName    string   `mapstructure:"name" yaml:",omitempty"`
 	ContainerImage     string   `mapstructure:"image" yaml:",omitempty"`
 	GitRepo            string   `mapstructure:"git" yaml:",omitempty"`
+	GitBranch          string   `mapstructure:"git-branch" yaml:",omitempty"`
+	GitTag             string   `mapstructure:"git-tag" yaml:",omitempty"`
+	GitCommit          string   `mapstructure:"git-commit" yaml:",omitempty"`
+	GitPath            string   `mapstructure:"git-path" yaml:",omitempty"`
+	GitSecret          string   `mapstructure:"git-secret" yaml:",omitempty"`
 	Profile            string   `mapstructure:"profile" yaml:",omitempty"`
 	IntegrationProfile string   `mapstructure:"integration-profile" yaml:",omitempty"`
 	OperatorID         string   `mapstructure:"operator-id" yaml:",omitempty"`
@@ -161,6 +175,10 @@ type runCmdOptions struct {
 	// Deprecated: registry parameter no longer in use.
 	RegistryOptions url.Values
 	Force           bool `mapstructure:"force" yaml:",omitempty"`
+	// directory is the directory run as an integration, if any
+	directory *integrationDirectory
+	// directoryConfigMaps are the names of the ConfigMaps uploaded from the directory
+	directoryConfigMaps map[string]bool
 }
 
 func (o *runCmdOptions) decode(cmd *cobra.Command, args []string) error {
@@ -231,11 +249,17 @@ func (o *runCmdOptions) decode(cmd *cobra.Command, args []string) error {
 		})
 	}
 
+	if err := o.loadDirectory(args); err != nil {
+		return err
+	}
+
 	return o.validate(cmd)
 }
 
 func (o *runCmdOptions) validateArgs(cmd *cobra.Command, args []string) error {
-	if _, err := source.Resolve(context.Background(), args, false, cmd); err != nil {
+	// Directories are validated when loaded
+	files, _ := splitDirectories(args)
+	if _, err := source.Resolve(context.Background(), files, false, cmd); err != nil {
 		return fmt.Errorf("one of the provided sources is not reachable: %w", err)
 	}
 
@@ -265,6 +289,16 @@ func (o *runCmdOptions) validate(cmd *cobra.Command) error {
 		return fmt.Errorf("cannot use --dev with -o/--output option")
 	}
 
+	if o.GitRepo == "" && (o.GitBranch != "" || o.GitTag != "" || o.GitCommit != "" || o.GitPath != "" || o.GitSecret != "") {
+		return errors.New("the --git-branch, --git-tag, --git-commit, --git-path and --git-secret options require --git")
+	}
+	if o.GitBranch != "" && o.GitTag != "" {
+		return errors.New("cannot use --git-branch with --git-tag")
+	}
+	if o.GitTag != "" && o.GitCommit != "" {
+		return errors.New("cannot use --git-tag with --git-commit")
+	}
+
 	for _, label := range o.Labels {
 		parts := strings.Split(label, "=")
 		if len(parts) != 2 {
@@ -470,7 +504,19 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 	files = append(files, filterFileLocation(o.BuildProperties)...)
 	files = append(files, filterFileLocation(o.OpenAPIs)...)
 
+	if o.directory != nil {
+		// The whole tree is watched, so that added and removed files are taken into account
+		changes, err := sync.Dir(o.Context, o.directory.root)
+		if err != nil {
+			return err
+		}
+		go o.syncOnChanges(cmd, c, sources, changes)
+	}
+
 	for _, s := range files {
+		if o.directory != nil && (s == o.directory.root || o.directory.contains(s)) {
+			continue
+		}
 		ok, err := source.IsLocalAndFileExists(s)
 		if err != nil {
 			return err
@@ -480,39 +526,7 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 			if err != nil {
 				return err
 			}
-			go func() {
-				for {
-					select {
-					case <-o.Context.Done():
-						return
-					case <-changes:
-						// let's create a new command to parse modeline changes and update our integration
-						newCmd, _, err := createKamelWithModelineCommand(o.RootContext, os.Args[1:])
-						newCmd.SetOut(cmd.OutOrStdout())
-						newCmd.SetErr(cmd.ErrOrStderr())
-						if err != nil {
-							fmt.Fprintln(newCmd.ErrOrStderr(), "Unable to sync integration: ", err.Error())
-
-							continue
-						}
-						newCmd.Args = o.validateArgs
-						newCmd.PreRunE = o.decode
-						newCmd.RunE = func(cmd *cobra.Command, args []string) error {
-							_, err := o.createOrUpdateIntegration(cmd, c, sources)
-							return err
-						}
-						newCmd.PostRunE = nil
-
-						// cancel the existing command to release watchers
-						o.ContextCancel()
-						// run the new one
-						err = newCmd.Execute()
-						if err != nil {
-							fmt.Fprintln(newCmd.ErrOrStderr(), "Unable to sync integration: ", err.Error())
-						}
-					}
-				}
-			}()
+			go o.syncOnChanges(cmd, c, sources, changes)
 		} else {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the following URL will not be watched for changes: %s\n", s)
 		}
@@ -521,6 +535,41 @@ func (o *runCmdOptions) syncIntegration(cmd *cobra.Command, c client.Client, sou
 	return nil
 }
 
+// syncOnChanges updates the integration each time a change is notified, until the command context is done.
+func (o *runCmdOptions) syncOnChanges(cmd *cobra.Command, c client.Client, sources []string, changes <-chan bool) {
+	for {
+		select {
+		case <-o.Context.Done():
+			return
+		case <-changes:
+			// let's create a new command to parse modeline changes and update our integration
+			newCmd, _, err := createKamelWithModelineCommand(o.RootContext, os.Args[1:])
+			newCmd.SetOut(cmd.OutOrStdout())
+			newCmd.SetErr(cmd.ErrOrStderr())
+			if err != nil {
+				fmt.Fprintln(newCmd.ErrOrStderr(), "Unable to sync integration: ", err.Error())
+
+				continue
+			}
+			newCmd.Args = o.validateArgs
+			newCmd.PreRunE = o.decode
+			newCmd.RunE = func(cmd *cobra.Command, args []string) error {
+				_, err := o.createOrUpdateIntegration(cmd, c, sources)
+				return err
+			}
+			newCmd.PostRunE = nil
+
+			// cancel the existing command to release watchers
+			o.ContextCancel()
+			// run the new one
+			err = newCmd.Execute()
+			if err != nil {
+				fmt.Fprintln(newCmd.ErrOrStderr(), "Unable to sync integration: ", err.Error())
+			}
+		}
+	}
+}
+
 func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.Client, sources []string) (*v1.Integration, error) {
 	namespace := o.Namespace
 	name, err := o.GetIntegrationName(sources)
@@ -566,7 +615,12 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		o.Traits = append(o.Traits, fmt.Sprintf("container.image=%s", o.ContainerImage))
 	} else if o.GitRepo != "" {
 		integration.Spec.Git = &v1.GitConfigSpec{
-			URL: o.GitRepo,
+			URL:    o.GitRepo,
+			Secret: o.GitSecret,
+			Branch: o.GitBranch,
+			Tag:    o.GitTag,
+			Commit: o.GitCommit,
+			Path:   o.GitPath,
 		}
 	} else {
 		return nil, errors.New("you must provide a source, an image or a git repository parameters")
@@ -576,6 +630,10 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		return nil, err
 	}
 
+	if err := o.applyDirectory(cmd, c, integration); err != nil {
+		return nil, err
+	}
+
 	if err := o.convertOptionsToTraits(cmd, c, integration); err != nil {
 		return nil, err
 	}
@@ -614,6 +672,9 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 
 		if string(d) == "{}" {
 			fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" unchanged`)
+			if err := o.ownDirectoryConfigMaps(c, integration); err != nil {
+				return nil, err
+			}
 			return integration, nil
 		}
 		err = c.Patch(o.Context, integration, patch)
@@ -623,6 +684,10 @@ func (o *runCmdOptions) createOrUpdateIntegration(cmd *cobra.Command, c client.C
 		fmt.Fprintln(cmd.OutOrStdout(), `Integration "`+name+`" updated`)
 	}
 
+	if err := o.ownDirectoryConfigMaps(c, integration); err != nil {
+		return nil, err
+	}
+
 	return integration, nil
 }
 
@@ -706,9 +771,13 @@ func (o *runCmdOptions) applyAnnotations(it *v1.Integration) {
 }
 
 func (o *runCmdOptions) resolveSources(cmd *cobra.Command, sources []string, it *v1.Integration) error {
-	srcs := make([]string, 0, len(sources)+len(o.Sources))
-	srcs = append(srcs, sources...)
+	files, _ := splitDirectories(sources)
+	srcs := make([]string, 0, len(files)+len(o.Sources))
+	srcs = append(srcs, files...)
 	srcs = append(srcs, o.Sources...)
+	if o.directory != nil {
+		srcs = append(srcs, o.directory.sources...)
+	}
 
 	resolvedSources, err := source.Resolve(context.Background(), srcs, o.Compression, cmd)
 	if err != nil {
@@ -871,6 +940,13 @@ func (o *runCmdOptions) GetIntegrationName(sources []string) (string, error) {
 	case o.IntegrationName != "":
 		name = o.IntegrationName
 		name = kubernetes.SanitizeName(name)
+	case len(sources) == 1 && isDirectory(sources[0]):
+		// The directory name, that may be "." or ".."
+		dir, err := filepath.Abs(sources[0])
+		if err != nil {
+			return "", err
+		}
+		name = kubernetes.SanitizeName(dir)
 	case len(sources) == 1:
 		name = kubernetes.SanitizeName(sources[0])
 	case o.ContainerImage != "":
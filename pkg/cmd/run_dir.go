@@ -0,0 +1,457 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+// integrationManifestFile is the file of an integration directory holding the traits and the dependencies.
+const integrationManifestFile = "kamel.yaml"
+
+// integrationManifest is the content of the kamel.yaml file of an integration directory.
+type integrationManifest struct {
+	Dependencies []string `yaml:"dependencies,omitempty"`
+	Traits       []string `yaml:"traits,omitempty"`
+}
+
+// integrationDirectory is a directory run as an integration, whose files are sorted by type.
+type integrationDirectory struct {
+	root       string
+	sources    []string
+	properties []string
+	openAPIs   []string
+	// resources holds the resources paths, relative to the root
+	resources []string
+	manifest  integrationManifest
+}
+
+// xmlRouteElements are the root elements of the XML DSL files.
+var xmlRouteElements = map[string]bool{
+	"camel":              true,
+	"routes":             true,
+	"route":              true,
+	"rests":              true,
+	"rest":               true,
+	"routeConfiguration": true,
+	"routeTemplates":     true,
+	"templatedRoutes":    true,
+	"beans":              true,
+}
+
+// yamlRouteKeys are the keys of the top level elements of the YAML DSL files.
+var yamlRouteKeys = map[string]bool{
+	"from":                true,
+	"route":               true,
+	"rest":                true,
+	"rests":               true,
+	"routeConfiguration":  true,
+	"route-configuration": true,
+	"routeTemplate":       true,
+	"route-template":      true,
+	"templatedRoute":      true,
+	"templated-route":     true,
+	"beans":               true,
+	"errorHandler":        true,
+	"error-handler":       true,
+	"onException":         true,
+	"on-exception":        true,
+	"intercept":           true,
+}
+
+// splitDirectories separates the directories from the other locations.
+func splitDirectories(locations []string) ([]string, []string) {
+	var files, dirs []string
+	for _, location := range locations {
+		if isDirectory(location) {
+			dirs = append(dirs, location)
+		} else {
+			files = append(files, location)
+		}
+	}
+
+	return files, dirs
+}
+
+func isDirectory(location string) bool {
+	info, err := os.Stat(location)
+	return err == nil && info.IsDir()
+}
+
+// loadIntegrationDirectory walks the directory tree, ignoring hidden files, and sorts the files by type:
+// route files are sources, .properties files are property files, *.openapi.(yaml|yml|json) files are
+// OpenAPI specs, the kamel.yaml file at the root is the manifest, and any other file is a resource.
+func loadIntegrationDirectory(root string) (*integrationDirectory, error) {
+	dir := integrationDirectory{root: root}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		name := strings.ToLower(d.Name())
+		switch {
+		case rel == integrationManifestFile:
+			return dir.loadManifest(path)
+		case isOpenAPIFile(name):
+			dir.openAPIs = append(dir.openAPIs, path)
+		case filepath.Ext(name) == ".properties":
+			dir.properties = append(dir.properties, path)
+		default:
+			route, err := isRouteFile(path)
+			if err != nil {
+				return err
+			}
+			if route {
+				dir.sources = append(dir.sources, path)
+			} else {
+				dir.resources = append(dir.resources, rel)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(dir.sources) == 0 {
+		return nil, fmt.Errorf("no route file could be found in directory %s", root)
+	}
+
+	return &dir, nil
+}
+
+func (d *integrationDirectory) loadManifest(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.UnmarshalStrict(content, &d.manifest); err != nil {
+		return fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// contains returns true if the given path belongs to the directory tree.
+func (d *integrationDirectory) contains(path string) bool {
+	rel, err := filepath.Rel(d.root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func isOpenAPIFile(name string) bool {
+	for _, ext := range []string{".openapi.yaml", ".openapi.yml", ".openapi.json"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRouteFile tells whether the file is a Camel DSL file. YAML and XML files are routes only when their
+// content looks like a Camel DSL, so that they can be used as resources otherwise.
+func isRouteFile(path string) (bool, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".java", ".groovy", ".js", ".kts", ".jsh":
+		return true, nil
+	case ".yaml", ".yml":
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return false, err
+		}
+		// The YAML DSL is a sequence of routes, rests, beans...
+		var elements []map[string]interface{}
+		if yaml.Unmarshal(content, &elements) != nil {
+			return false, nil
+		}
+		for _, element := range elements {
+			for key := range element {
+				if yamlRouteKeys[key] {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case ".xml":
+		file, err := os.Open(path)
+		if err != nil {
+			return false, err
+		}
+		defer file.Close()
+		decoder := xml.NewDecoder(file)
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return false, nil
+			}
+			if element, ok := token.(xml.StartElement); ok {
+				return xmlRouteElements[element.Name.Local], nil
+			}
+		}
+	default:
+		return false, nil
+	}
+}
+
+// resourcesConfigMapName returns the name of the ConfigMap holding the resources of the given sub-directory.
+func resourcesConfigMapName(integration string, dir string) string {
+	if dir == "." {
+		return integration + "-resources"
+	}
+
+	return integration + "-resources-" + kubernetes.SanitizeLabel(strings.ReplaceAll(filepath.ToSlash(dir), "/", "-"))
+}
+
+// openAPIConfigMapName returns the name of the ConfigMap holding the OpenAPI specs of the directory.
+func openAPIConfigMapName(integration string) string {
+	return integration + "-openapi"
+}
+
+// isDirectoryConfigMap returns true if the ConfigMap is one of the ConfigMaps uploaded from the directory of the integration.
+func isDirectoryConfigMap(name string, integration string) bool {
+	return name == resourcesConfigMapName(integration, ".") ||
+		strings.HasPrefix(name, integration+"-resources-") ||
+		name == openAPIConfigMapName(integration)
+}
+
+// configMaps returns the ConfigMaps holding the resources, grouped by sub-directory as ConfigMap keys cannot
+// contain a path, and the OpenAPI specs of the directory, along with the --resource and --open-api values
+// referencing them. The resources are mounted with the same layout as in the directory.
+func (d *integrationDirectory) configMaps(namespace string, integration string) ([]*corev1.ConfigMap, []string, []string, error) {
+	var configMaps []*corev1.ConfigMap
+	var resources, openAPIs []string
+
+	byDir := make(map[string]*corev1.ConfigMap)
+	for _, rel := range d.resources {
+		dir := filepath.Dir(rel)
+		cm, ok := byDir[dir]
+		if !ok {
+			name := resourcesConfigMapName(integration, dir)
+			cm = newDirectoryConfigMap(namespace, name, integration)
+			byDir[dir] = cm
+			configMaps = append(configMaps, cm)
+			mountPath := filepath.ToSlash(filepath.Join(camel.ResourcesConfigmapsMountPath, integration+"-resources", dir))
+			resources = append(resources, fmt.Sprintf("configmap:%s@%s", name, mountPath))
+		}
+		if err := addConfigMapFile(cm, filepath.Join(d.root, rel)); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if len(d.openAPIs) > 0 {
+		name := openAPIConfigMapName(integration)
+		cm := newDirectoryConfigMap(namespace, name, integration)
+		for _, spec := range d.openAPIs {
+			if err := addConfigMapFile(cm, spec); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		configMaps = append(configMaps, cm)
+		openAPIs = append(openAPIs, "configmap:"+name)
+	}
+
+	return configMaps, resources, openAPIs, nil
+}
+
+func newDirectoryConfigMap(namespace string, name string, integration string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				v1.IntegrationLabel: integration,
+			},
+		},
+	}
+}
+
+func addConfigMapFile(cm *corev1.ConfigMap, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	key := filepath.Base(path)
+	if _, ok := cm.Data[key]; ok {
+		return fmt.Errorf("duplicate file %s in ConfigMap %s", key, cm.Name)
+	}
+	if _, ok := cm.BinaryData[key]; ok {
+		return fmt.Errorf("duplicate file %s in ConfigMap %s", key, cm.Name)
+	}
+	if utf8.Valid(content) {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[key] = string(content)
+	} else {
+		if cm.BinaryData == nil {
+			cm.BinaryData = make(map[string][]byte)
+		}
+		cm.BinaryData[key] = content
+	}
+
+	return nil
+}
+
+// loadDirectory loads the directory given as argument, if any.
+func (o *runCmdOptions) loadDirectory(args []string) error {
+	_, dirs := splitDirectories(args)
+	switch len(dirs) {
+	case 0:
+		o.directory = nil
+		return nil
+	case 1:
+		dir, err := loadIntegrationDirectory(dirs[0])
+		if err != nil {
+			return err
+		}
+		o.directory = dir
+		return nil
+	default:
+		return errors.New("only one directory can be run as an integration")
+	}
+}
+
+// applyDirectory adds the content of the directory to the options, the flags taking precedence over the
+// directory files. The resources and the OpenAPI specs are uploaded as ConfigMaps.
+func (o *runCmdOptions) applyDirectory(cmd *cobra.Command, c client.Client, it *v1.Integration) error {
+	d := o.directory
+	if d == nil {
+		return nil
+	}
+
+	properties := make([]string, 0, len(d.properties)+len(o.Properties))
+	for _, p := range d.properties {
+		properties = append(properties, "file:"+p)
+	}
+	o.Properties = append(properties, o.Properties...)
+	o.Dependencies = append(o.Dependencies, d.manifest.Dependencies...)
+	o.Traits = mergeTraits(d.manifest.Traits, o.Traits)
+
+	configMaps, resources, openAPIs, err := d.configMaps(it.Namespace, it.Name)
+	if err != nil {
+		return err
+	}
+	o.Resources = append(o.Resources, resources...)
+	o.OpenAPIs = append(o.OpenAPIs, openAPIs...)
+	o.directoryConfigMaps = make(map[string]bool, len(configMaps))
+	for _, cm := range configMaps {
+		o.directoryConfigMaps[cm.Name] = true
+	}
+	if o.OutputFormat != "" {
+		// The ConfigMaps are printed before the Integration, so that they are created first when the output is applied
+		printer := printers.NewTypeSetter(scheme.Scheme)
+		printer.Delegate = &kubernetes.CLIPrinter{
+			Format: o.OutputFormat,
+		}
+		for _, cm := range configMaps {
+			if err := printer.PrintObj(cm, cmd.OutOrStdout()); err != nil {
+				return err
+			}
+			if o.OutputFormat == "yaml" {
+				fmt.Fprintln(cmd.OutOrStdout(), "---")
+			}
+		}
+		return nil
+	}
+
+	for _, cm := range configMaps {
+		if _, err := kubernetes.ReplaceResource(o.Context, c, cm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ownDirectoryConfigMaps sets the Integration as the owner of the ConfigMaps uploaded from the directory, so that they
+// are deleted along with it, and deletes the ones left by a previous run, e.g. for a sub-directory that is gone.
+// The owner references are set on every run, as replacing the ConfigMaps drops them.
+func (o *runCmdOptions) ownDirectoryConfigMaps(c client.Client, it *v1.Integration) error {
+	if o.directory == nil {
+		return nil
+	}
+
+	configMaps := corev1.ConfigMapList{}
+	if err := c.List(o.Context, &configMaps, ctrl.InNamespace(it.Namespace), ctrl.MatchingLabels{v1.IntegrationLabel: it.Name}); err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		cm := &configMaps.Items[i]
+		if !isDirectoryConfigMap(cm.Name, it.Name) {
+			continue
+		}
+		if !o.directoryConfigMaps[cm.Name] {
+			if err := c.Delete(o.Context, cm); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		cm.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKind,
+				Name:       it.Name,
+				UID:        it.UID,
+			},
+		}
+		if err := c.Update(o.Context, cm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
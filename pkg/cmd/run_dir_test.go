@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func createIntegrationDirectory(t *testing.T) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "my-integration")
+	files := map[string]string{
+		"route.yaml":             yamlIntegration,
+		"application.properties": "greeting=Hello",
+		"kamel.yaml":             "dependencies:\n- mvn:org.my:app:1.0\ntraits:\n- service.enabled=false\n",
+		"api.openapi.yaml":       "openapi: 3.0.0\n",
+		"data/greetings.txt":     "Hello",
+		"data/logback.xml":       "<configuration/>",
+		".git/config":            "ignored",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestLoadIntegrationDirectory(t *testing.T) {
+	dir := createIntegrationDirectory(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "routes.xml"), []byte("<routes><route/></routes>"), 0o600))
+
+	d, err := loadIntegrationDirectory(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "route.yaml"), filepath.Join(dir, "routes.xml")}, d.sources)
+	assert.Equal(t, []string{filepath.Join(dir, "application.properties")}, d.properties)
+	assert.Equal(t, []string{filepath.Join(dir, "api.openapi.yaml")}, d.openAPIs)
+	assert.Equal(t, []string{filepath.Join("data", "greetings.txt"), filepath.Join("data", "logback.xml")}, d.resources)
+	assert.Equal(t, []string{"mvn:org.my:app:1.0"}, d.manifest.Dependencies)
+	assert.Equal(t, []string{"service.enabled=false"}, d.manifest.Traits)
+	assert.True(t, d.contains(filepath.Join(dir, "data", "greetings.txt")))
+	assert.False(t, d.contains(filepath.Join(filepath.Dir(dir), "other.yaml")))
+}
+
+func TestIsRouteFile(t *testing.T) {
+	dir := t.TempDir()
+	files := []struct {
+		name    string
+		content string
+		route   bool
+	}{
+		{"route.yaml", yamlIntegration, true},
+		{"rest.yaml", "- rest:\n    path: /api\n", true},
+		{"beans.yml", "- beans:\n  - name: myBean\n    type: org.my.Bean\n", true},
+		{"users.yaml", "- name: alice\n- name: bob\n", false},
+		{"config.yaml", "server:\n  port: 8080\n", false},
+		{"camel.xml", "<camel><route/></camel>", true},
+		{"pom.xml", "<project/>", false},
+		{"Route.java", "class Route {}", true},
+		{"greetings.txt", "Hello", false},
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		require.NoError(t, os.WriteFile(path, []byte(f.content), 0o600))
+		route, err := isRouteFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, f.route, route, f.name)
+	}
+}
+
+func TestLoadIntegrationDirectoryWithoutRoutes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "application.properties"), []byte("a=b"), 0o600))
+
+	_, err := loadIntegrationDirectory(dir)
+	require.EqualError(t, err, "no route file could be found in directory "+dir)
+}
+
+func TestRunDirectoryOutput(t *testing.T) {
+	dir := createIntegrationDirectory(t)
+
+	_, runCmd, _ := initializeRunCmdOptionsWithOutput(t)
+	output, err := ExecuteCommand(runCmd, cmdRun, dir, "-o", "yaml", "-t", "service.enabled=true")
+	require.NoError(t, err)
+
+	// The generated ConfigMaps are printed along with the Integration
+	assert.Contains(t, output, `  greetings.txt: Hello
+  logback.xml: <configuration/>
+kind: ConfigMap
+`)
+	assert.Contains(t, output, "  name: my-integration-resources-data\n---\n")
+	assert.Contains(t, output, `  api.openapi.yaml: |
+    openapi: 3.0.0
+kind: ConfigMap
+`)
+	assert.Contains(t, output, "  name: my-integration-openapi\n")
+	assert.Equal(t, 2, strings.Count(output, "---\n"))
+	assert.Contains(t, output, `  name: my-integration
+spec:
+  dependencies:
+  - mvn:org.my:app:1.0
+  flows:
+  - from:`)
+	assert.Contains(t, output, `    camel:
+      properties:
+      - greeting = Hello
+`)
+	assert.Contains(t, output, `    mount:
+      resources:
+      - configmap:my-integration-resources-data@/etc/camel/resources.d/_configmaps/my-integration-resources/data
+    openapi:
+      configmaps:
+      - my-integration-openapi
+`)
+	// The flags take precedence over the manifest
+	assert.Contains(t, output, `    service:
+      enabled: true
+`)
+}
+
+func TestRunDirectoryCreatesConfigMaps(t *testing.T) {
+	dir := createIntegrationDirectory(t)
+
+	runCmdOptions, runCmd, _ := initializeRunCmdOptionsWithOutput(t)
+	output, err := ExecuteCommand(runCmd, cmdRun, dir)
+	require.NoError(t, err)
+	assert.Contains(t, output, `Integration "my-integration" created`)
+
+	c, err := runCmdOptions.GetCmdClient()
+	require.NoError(t, err)
+	resources := corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: runCmdOptions.Namespace, Name: "my-integration-resources-data"}, &resources))
+	assert.Equal(t, map[string]string{"greetings.txt": "Hello", "logback.xml": "<configuration/>"}, resources.Data)
+	assert.Equal(t, "my-integration", resources.Labels[v1.IntegrationLabel])
+	openAPI := corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: runCmdOptions.Namespace, Name: "my-integration-openapi"}, &openAPI))
+	assert.Equal(t, map[string]string{"api.openapi.yaml": "openapi: 3.0.0\n"}, openAPI.Data)
+
+	it := v1.Integration{}
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: runCmdOptions.Namespace, Name: "my-integration"}, &it))
+	assert.Len(t, it.Spec.Flows, 1)
+	assert.Equal(t, []string{"mvn:org.my:app:1.0"}, it.Spec.Dependencies)
+}
+
+func TestRunDirectoryOwnsConfigMaps(t *testing.T) {
+	dir := createIntegrationDirectory(t)
+
+	runCmdOptions, runCmd, _ := initializeRunCmdOptionsWithOutput(t)
+	_, err := ExecuteCommand(runCmd, cmdRun, dir)
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(filepath.Join(dir, "data"), filepath.Join(dir, "assets")))
+	output, err := ExecuteCommand(runCmd, cmdRun, dir)
+	require.NoError(t, err)
+	assert.Contains(t, output, `Integration "my-integration" updated`)
+
+	c, err := runCmdOptions.GetCmdClient()
+	require.NoError(t, err)
+	configMaps := corev1.ConfigMapList{}
+	require.NoError(t, c.List(context.TODO(), &configMaps, ctrl.InNamespace(runCmdOptions.Namespace)))
+	names := make([]string, 0, len(configMaps.Items))
+	for _, cm := range configMaps.Items {
+		names = append(names, cm.Name)
+		require.Len(t, cm.OwnerReferences, 1)
+		assert.Equal(t, v1.IntegrationKind, cm.OwnerReferences[0].Kind)
+		assert.Equal(t, "my-integration", cm.OwnerReferences[0].Name)
+	}
+	assert.ElementsMatch(t, []string{"my-integration-resources-assets", "my-integration-openapi"}, names)
+}
+
+func TestRunMultipleDirectories(t *testing.T) {
+	dir := createIntegrationDirectory(t)
+
+	_, runCmd, _ := initializeRunCmdOptionsWithOutput(t)
+	_, err := ExecuteCommand(runCmd, cmdRun, dir, filepath.Join(dir, "data"), "-o", "yaml")
+	require.EqualError(t, err, "only one directory can be run as an integration")
+}
@@ -169,3 +169,21 @@ func extractTraitNames(traitProps []string) []string {
 	}
 	return traitNameProps
 }
+
+// mergeTraits returns the default traits whose property is not configured by the given traits, followed by
+// the given traits, so that the latter take precedence.
+func mergeTraits(defaults []string, traits []string) []string {
+	set := make(map[string]bool, len(traits))
+	for _, t := range traits {
+		key, _, _ := strings.Cut(t, "=")
+		set[key] = true
+	}
+	merged := make([]string, 0, len(defaults)+len(traits))
+	for _, t := range defaults {
+		if key, _, _ := strings.Cut(t, "="); !set[key] {
+			merged = append(merged, t)
+		}
+	}
+
+	return append(merged, traits...)
+}
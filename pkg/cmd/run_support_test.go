@@ -47,3 +47,11 @@ func TestExtractTraitNames(t *testing.T) {
 	assert.Equal(t, "no-trait", tn[1])
 	assert.Equal(t, "nothing", tn[2])
 }
+
+func TestMergeTraits(t *testing.T) {
+	merged := mergeTraits(
+		[]string{"service.enabled=false", "logging.level=DEBUG"},
+		[]string{"service.enabled=true", "mount.configs=configmap:my-cm"},
+	)
+	assert.Equal(t, []string{"logging.level=DEBUG", "service.enabled=true", "mount.configs=configmap:my-cm"}, merged)
+}
@@ -978,3 +978,38 @@ spec:
 status: {}
 `, output)
 }
+
+func TestGitRepoRefIntegration(t *testing.T) {
+	_, runCmd, _ := initializeRunCmdOptionsWithOutput(t)
+	output, err := ExecuteCommand(runCmd, cmdRun, "--git", "git@my-git:my-org/my-repo.git", "--git-branch", "main",
+		"--git-commit", "4bb9ed2", "--git-path", "apps/my-it", "--git-secret", "my-ssh-key", "--name", "my-it", "-o", "yaml")
+
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: camel.apache.org/v1
+kind: Integration
+metadata:
+  annotations:
+    camel.apache.org/operator.id: camel-k
+  creationTimestamp: null
+  name: my-it
+spec:
+  git:
+    branch: main
+    commit: 4bb9ed2
+    path: apps/my-it
+    secret: my-ssh-key
+    url: git@my-git:my-org/my-repo.git
+  traits: {}
+status: {}
+`, output)
+}
+
+func TestGitRepoRefValidation(t *testing.T) {
+	_, runCmd, _ := initializeRunCmdOptionsWithOutput(t)
+	_, err := ExecuteCommand(runCmd, cmdRun, "--git", "http://my-git/my-org/my-it.git", "--git-branch", "main", "--git-tag", "v1")
+	require.EqualError(t, err, "cannot use --git-branch with --git-tag")
+
+	_, runCmd, _ = initializeRunCmdOptionsWithOutput(t)
+	_, err = ExecuteCommand(runCmd, cmdRun, "--git-tag", "v1", "--image", "my-image")
+	require.EqualError(t, err, "the --git-branch, --git-tag, --git-commit, --git-path and --git-secret options require --git")
+}
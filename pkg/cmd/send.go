@@ -0,0 +1,278 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+const (
+	sendHTTPScheme = "http:"
+
+	camelRequestBodyAndHeaders = "requestBodyAndHeaders(java.lang.String,java.lang.Object,java.util.Map)"
+	camelSendBodyAndHeaders    = "sendBodyAndHeaders(java.lang.String,java.lang.Object,java.util.Map)"
+)
+
+func newCmdSend(rootCmdOptions *RootCmdOptions) (*cobra.Command, *sendCmdOptions) {
+	options := sendCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "send [integration name]",
+		Short: "Send a test message to a running integration",
+		Long: `Send a test message to an endpoint of a running integration and print the reply.
+HTTP endpoints (http:/path) are called through a port-forward to the integration container port,
+any other Camel endpoint (i.e. direct:foo or seda:bar) is called through the Jolokia agent, which requires the jolokia trait to be enabled.`,
+		Example: `  kamel send my-it --endpoint http:/hello --body @payload.json --header Content-Type=application/json
+  kamel send my-it --endpoint direct:foo --body hello --header CamelFileName=test.txt`,
+		Args:    options.validateArgs,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringP("endpoint", "e", "", "The endpoint to send the message to, either http:/path or a Camel endpoint URI (i.e. direct:foo)")
+	cmd.Flags().StringP("body", "b", "", "The message body, or @file to read it from a file")
+	cmd.Flags().StringArrayP("header", "H", nil, "A message header, in the form key=value")
+	cmd.Flags().String("method", "", "The HTTP method, defaults to POST when a body is provided and GET otherwise")
+	cmd.Flags().Bool("in-only", false, "Send the message to a Camel endpoint without waiting for a reply")
+	cmd.Flags().String("pod", "", "The integration pod to send the message to, defaults to the first ready pod")
+	cmd.Flags().Uint("port", 8080, "The integration container port HTTP endpoints are called on")
+	addJolokiaFlags(&cmd)
+	cmd.Flags().Duration("timeout", 30*time.Second, "The time to wait for the reply")
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd, &options
+}
+
+type sendCmdOptions struct {
+	*RootCmdOptions
+	Endpoint          string        `mapstructure:"endpoint" yaml:",omitempty"`
+	Body              string        `mapstructure:"body" yaml:",omitempty"`
+	Headers           []string      `mapstructure:"headers" yaml:",omitempty"`
+	Method            string        `mapstructure:"method" yaml:",omitempty"`
+	InOnly            bool          `mapstructure:"in-only" yaml:",omitempty"`
+	Pod               string        `mapstructure:"pod" yaml:",omitempty"`
+	Port              uint          `mapstructure:"port" yaml:",omitempty"`
+	JolokiaPort       uint          `mapstructure:"jolokia-port" yaml:",omitempty"`
+	JolokiaClientCert string        `mapstructure:"jolokia-client-cert" yaml:",omitempty"`
+	JolokiaClientKey  string        `mapstructure:"jolokia-client-key" yaml:",omitempty"`
+	Timeout           time.Duration `mapstructure:"timeout" yaml:",omitempty"`
+}
+
+func (o *sendCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("send expects 1 argument, received %d", len(args))
+	}
+	return nil
+}
+
+func (o *sendCmdOptions) validate() error {
+	if o.Endpoint == "" {
+		return errors.New("the endpoint to send the message to must be set with --endpoint")
+	}
+	if !strings.Contains(o.Endpoint, ":") {
+		return fmt.Errorf("invalid endpoint %q, must be either http:/path or a Camel endpoint URI", o.Endpoint)
+	}
+	if _, err := o.headers(); err != nil {
+		return err
+	}
+	if o.Method != "" && !o.isHTTP() {
+		return errors.New("the --method flag is only supported for http endpoints")
+	}
+	if o.InOnly && o.isHTTP() {
+		return errors.New("the --in-only flag is only supported for Camel endpoints")
+	}
+	return nil
+}
+
+func (o *sendCmdOptions) isHTTP() bool {
+	return strings.HasPrefix(o.Endpoint, sendHTTPScheme)
+}
+
+func (o *sendCmdOptions) headers() (map[string]string, error) {
+	headers := make(map[string]string, len(o.Headers))
+	for _, h := range o.Headers {
+		k, v, ok := strings.Cut(h, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid header %q, must be in the form key=value", h)
+		}
+		headers[k] = v
+	}
+	return headers, nil
+}
+
+func (o *sendCmdOptions) body() (string, error) {
+	if file, ok := strings.CutPrefix(o.Body, "@"); ok {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("cannot read the body from file %q: %w", file, err)
+		}
+		return string(data), nil
+	}
+	return o.Body, nil
+}
+
+func (o *sendCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	pods, err := lookupIntegrationPods(o.Context, c, o.Namespace, args[0], o.Pod)
+	if err != nil {
+		return err
+	}
+
+	body, err := o.body()
+	if err != nil {
+		return err
+	}
+	headers, err := o.headers()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(o.Context, o.Timeout)
+	defer cancel()
+
+	var reply string
+	if o.isHTTP() {
+		reply, err = o.sendHTTP(ctx, c, &pods[0], body, headers)
+	} else {
+		reply, err = o.sendCamel(ctx, c, &pods[0], body, headers)
+	}
+	if err != nil {
+		return err
+	}
+
+	if reply != "" {
+		fmt.Fprintln(cmd.OutOrStdout(), reply)
+	}
+
+	return nil
+}
+
+func (o *sendCmdOptions) sendHTTP(ctx context.Context, c client.Client, pod *corev1.Pod, body string, headers map[string]string) (string, error) {
+	address, err := kubernetes.PortForwardPod(ctx, c, pod.Namespace, pod.Name, o.Port, io.Discard, io.Discard)
+	if err != nil {
+		return "", err
+	}
+
+	path := strings.TrimPrefix(o.Endpoint, sendHTTPScheme)
+	path = "/" + strings.TrimLeft(path, "/")
+
+	method := o.Method
+	if method == "" {
+		method = http.MethodGet
+		if body != "" {
+			method = http.MethodPost
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "http://"+address+path, strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("%s %s returned %q: %s", method, path, resp.Status, string(data))
+	}
+
+	return formatHTTPReply(resp, data), nil
+}
+
+func formatHTTPReply(resp *http.Response, data []byte) string {
+	keys := make([]string, 0, len(resp.Header))
+	for k := range resp.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	reply := strings.Builder{}
+	reply.WriteString(resp.Proto + " " + resp.Status + "\n")
+	for _, k := range keys {
+		reply.WriteString(k + ": " + strings.Join(resp.Header[k], ",") + "\n")
+	}
+	reply.WriteString("\n")
+	reply.Write(data)
+
+	return reply.String()
+}
+
+func (o *sendCmdOptions) sendCamel(ctx context.Context, c client.Client, pod *corev1.Pod, body string, headers map[string]string) (string, error) {
+	jc, err := newJolokiaClient(ctx, c, pod, o.JolokiaPort, o.JolokiaClientCert, o.JolokiaClientKey)
+	if err != nil {
+		return "", err
+	}
+
+	mbean, err := lookupCamelContextMBean(ctx, jc)
+	if err != nil {
+		return "", err
+	}
+
+	if o.InOnly {
+		return "", jc.Exec(ctx, mbean, camelSendBodyAndHeaders, nil, o.Endpoint, body, headers)
+	}
+
+	var reply interface{}
+	if err := jc.Exec(ctx, mbean, camelRequestBodyAndHeaders, &reply, o.Endpoint, body, headers); err != nil {
+		return "", err
+	}
+	if reply == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%v", reply), nil
+}
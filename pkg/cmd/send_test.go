@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cmdSend = "send"
+
+// nolint: unparam
+func initializeSendCmdOptions(t *testing.T) (*sendCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	sendCmdOptions := addTestSendCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return sendCmdOptions, rootCmd, *options
+}
+
+func addTestSendCmd(options RootCmdOptions, rootCmd *cobra.Command) *sendCmdOptions {
+	// add a testing version of send Command
+	sendCmd, sendOptions := newCmdSend(&options)
+	sendCmd.RunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	sendCmd.PostRunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	sendCmd.Args = ArbitraryArgs
+	rootCmd.AddCommand(sendCmd)
+	return sendOptions
+}
+
+func TestSendNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeSendCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdSend, "--nonExistingFlag")
+	require.Error(t, err)
+}
+
+func TestSendFlags(t *testing.T) {
+	sendCmdOptions, rootCmd, _ := initializeSendCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdSend, "my-it",
+		"--endpoint", "direct:foo",
+		"--body", "hello",
+		"--header", "k1=v1",
+		"-H", "k2=a=b",
+		"--in-only")
+	require.NoError(t, err)
+	require.NoError(t, sendCmdOptions.validate())
+	assert.Equal(t, "direct:foo", sendCmdOptions.Endpoint)
+	assert.True(t, sendCmdOptions.InOnly)
+	assert.False(t, sendCmdOptions.isHTTP())
+
+	headers, err := sendCmdOptions.headers()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"k1": "v1", "k2": "a=b"}, headers)
+}
+
+func TestSendValidation(t *testing.T) {
+	o := sendCmdOptions{}
+	require.EqualError(t, o.validate(), "the endpoint to send the message to must be set with --endpoint")
+
+	o = sendCmdOptions{Endpoint: "http:/hello", InOnly: true}
+	require.EqualError(t, o.validate(), "the --in-only flag is only supported for Camel endpoints")
+
+	o = sendCmdOptions{Endpoint: "direct:foo", Method: "PUT"}
+	require.EqualError(t, o.validate(), "the --method flag is only supported for http endpoints")
+
+	o = sendCmdOptions{Endpoint: "direct:foo", Headers: []string{"invalid"}}
+	require.EqualError(t, o.validate(), `invalid header "invalid", must be in the form key=value`)
+}
+
+func TestSendBodyFromFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"foo":"bar"}`), 0o600))
+
+	o := sendCmdOptions{Body: "@" + file}
+	body, err := o.body()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo":"bar"}`, body)
+
+	o = sendCmdOptions{Body: "plain"}
+	body, err = o.body()
+	require.NoError(t, err)
+	assert.Equal(t, "plain", body)
+}
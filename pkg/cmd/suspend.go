@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+)
+
+func newCmdSuspend(rootCmdOptions *RootCmdOptions) (*cobra.Command, *suspendCmdOptions) {
+	options := suspendCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+		suspend:        true,
+	}
+	cmd := cobra.Command{
+		Use:   "suspend [integration or pipe name] ...",
+		Short: "Suspend integrations or pipes",
+		Long: `Suspend one or more integrations or pipes, scaling them down to zero or suspending their CronJob.
+The number of replicas is recorded, so that it can be restored with the resume command.`,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	return &cmd, &options
+}
+
+func newCmdResume(rootCmdOptions *RootCmdOptions) (*cobra.Command, *suspendCmdOptions) {
+	options := suspendCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+		suspend:        false,
+	}
+	cmd := cobra.Command{
+		Use:     "resume [integration or pipe name] ...",
+		Short:   "Resume suspended integrations or pipes",
+		Long:    `Resume one or more suspended integrations or pipes, restoring the number of replicas they had before being suspended.`,
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	return &cmd, &options
+}
+
+type suspendCmdOptions struct {
+	*RootCmdOptions
+	suspend bool
+}
+
+func (o *suspendCmdOptions) run(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("provide one or several integration or pipe names")
+	}
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		kind, err := o.toggle(c, name)
+		if err != nil {
+			return err
+		}
+		if o.suspend {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s suspended\n", kind, name)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s resumed\n", kind, name)
+		}
+	}
+
+	return nil
+}
+
+// toggle suspends or resumes the Pipe with the given name, or the Integration if no Pipe exists, as the Pipe
+// controller owns the replicas of the Integration it creates.
+func (o *suspendCmdOptions) toggle(c client.Client, name string) (string, error) {
+	key := ctrl.ObjectKey{Namespace: o.Namespace, Name: name}
+
+	pipe := v1.NewPipe(o.Namespace, name)
+	err := c.Get(o.Context, key, &pipe)
+	if err == nil {
+		if err := toggleSuspended(&pipe.ObjectMeta, &pipe.Spec.Replicas, o.suspend); err != nil {
+			return "", fmt.Errorf("pipe %s %w", name, err)
+		}
+		return v1.PipeKind, c.Update(o.Context, &pipe)
+	} else if !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+
+	it := v1.NewIntegration(o.Namespace, name)
+	if err := c.Get(o.Context, key, &it); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", fmt.Errorf("no integration or pipe named %s found in namespace %s", name, o.Namespace)
+		}
+		return "", err
+	}
+	if o.suspend && it.IsConditionTrue(v1.IntegrationConditionKnativeServiceAvailable) {
+		return "", fmt.Errorf("integration %s is a Knative service, which is already scaled down to zero when idle", name)
+	}
+	if err := toggleSuspended(&it.ObjectMeta, &it.Spec.Replicas, o.suspend); err != nil {
+		return "", fmt.Errorf("integration %s %w", name, err)
+	}
+
+	return v1.IntegrationKind, c.Update(o.Context, &it)
+}
+
+// toggleSuspended scales the replicas to zero recording their previous value in the suspended annotation,
+// or restores them from the annotation.
+func toggleSuspended(meta *metav1.ObjectMeta, replicas **int32, suspend bool) error {
+	if suspend {
+		if v1.IsSuspended(meta) {
+			return errors.New("is already suspended")
+		}
+		previous := ""
+		if *replicas != nil {
+			previous = strconv.Itoa(int(**replicas))
+		}
+		v1.SetAnnotation(meta, v1.SuspendedReplicasAnnotation, previous)
+		*replicas = ptr.To(int32(0))
+		return nil
+	}
+
+	if !v1.IsSuspended(meta) {
+		return errors.New("is not suspended")
+	}
+	previous := meta.Annotations[v1.SuspendedReplicasAnnotation]
+	delete(meta.Annotations, v1.SuspendedReplicasAnnotation)
+	if previous == "" {
+		*replicas = nil
+		return nil
+	}
+	r, err := strconv.ParseInt(previous, 10, 32)
+	if err != nil {
+		return fmt.Errorf("has an invalid %s annotation: %w", v1.SuspendedReplicasAnnotation, err)
+	}
+	*replicas = ptr.To(int32(r))
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+// nolint: unparam
+func initializeSuspendCmd(t *testing.T, initObjs ...runtime.Object) (*cobra.Command, client.Client) {
+	t.Helper()
+	fakeClient, err := internal.NewFakeClient(initObjs...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	suspendCmd, _ := newCmdSuspend(options)
+	suspendCmd.Args = ArbitraryArgs
+	resumeCmd, _ := newCmdResume(options)
+	resumeCmd.Args = ArbitraryArgs
+	rootCmd.AddCommand(suspendCmd, resumeCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd, fakeClient
+}
+
+func TestSuspendResumeIntegration(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	it.Spec.Replicas = ptr.To(int32(3))
+	rootCmd, c := initializeSuspendCmd(t, &it)
+
+	output, err := ExecuteCommand(rootCmd, "suspend", "my-it")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Integration my-it suspended\n")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&it), &it))
+	assert.Equal(t, int32(0), *it.Spec.Replicas)
+	assert.Equal(t, "3", it.Annotations[v1.SuspendedReplicasAnnotation])
+
+	_, err = ExecuteCommand(rootCmd, "suspend", "my-it")
+	require.EqualError(t, err, "integration my-it is already suspended")
+
+	output, err = ExecuteCommand(rootCmd, "resume", "my-it")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Integration my-it resumed\n")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&it), &it))
+	assert.Equal(t, int32(3), *it.Spec.Replicas)
+	assert.False(t, v1.IsSuspended(&it))
+}
+
+func TestSuspendResumePipe(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	rootCmd, c := initializeSuspendCmd(t, &pipe)
+
+	output, err := ExecuteCommand(rootCmd, "suspend", "my-pipe")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Pipe my-pipe suspended\n")
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&pipe), &pipe))
+	assert.Equal(t, int32(0), *pipe.Spec.Replicas)
+	assert.True(t, v1.IsSuspended(&pipe))
+
+	_, err = ExecuteCommand(rootCmd, "resume", "my-pipe")
+	require.NoError(t, err)
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKeyFromObject(&pipe), &pipe))
+	assert.Nil(t, pipe.Spec.Replicas)
+	assert.False(t, v1.IsSuspended(&pipe))
+}
+
+func TestSuspendKnativeService(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	it.Status.SetCondition(v1.IntegrationConditionKnativeServiceAvailable, corev1.ConditionTrue, "", "")
+	rootCmd, _ := initializeSuspendCmd(t, &it)
+
+	_, err := ExecuteCommand(rootCmd, "suspend", "my-it")
+	require.Error(t, err)
+}
+
+func TestSuspendNotFound(t *testing.T) {
+	rootCmd, _ := initializeSuspendCmd(t)
+
+	_, err := ExecuteCommand(rootCmd, "resume", "missing")
+	require.EqualError(t, err, "no integration or pipe named missing found in namespace default")
+}
@@ -0,0 +1,400 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+const (
+	topSortByName     = "name"
+	topSortByCPU      = "cpu"
+	topSortByMemory   = "memory"
+	topSortByFailures = "failures"
+
+	clearScreen = "\033[H\033[2J"
+)
+
+// camelExchangeMetrics maps the Camel route exchange statistics to the metric names
+// exposed by the Camel Micrometer component, in both the current and legacy naming.
+var camelExchangeMetrics = map[string]string{
+	"camel_exchanges_total":        "total",
+	"CamelExchangesTotal":          "total",
+	"camel_exchanges_failed_total": "failed",
+	"CamelExchangesFailed":         "failed",
+	"camel_exchanges_inflight":     "inflight",
+	"CamelExchangesInflight":       "inflight",
+}
+
+func newCmdTop(rootCmdOptions *RootCmdOptions) (*cobra.Command, *topCmdOptions) {
+	options := topCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "top [integration]",
+		Short: "Display resource usage and message throughput of integrations",
+		Long: `Display the replicas, the CPU and memory usage, and the Camel exchange statistics per route of the integrations.
+Resource usage requires the metrics server to be available on the cluster, exchange statistics require the prometheus trait to be enabled.`,
+		PreRunE: decode(&options, options.Flags),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(args); err != nil {
+				return err
+			}
+			return options.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().String("sort-by", topSortByName, "Sort the integrations and routes by one of name|cpu|memory|failures")
+	cmd.Flags().Duration("interval", 5*time.Second, "The refresh interval")
+	cmd.Flags().Bool("once", false, "Print the statistics once and exit instead of refreshing them live")
+	cmd.Flags().Uint("metrics-port", 8080, "The integration container port exposing the Prometheus endpoint")
+	cmd.Flags().String("metrics-path", "/q/metrics", "The path of the Prometheus endpoint")
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd, &options
+}
+
+type topCmdOptions struct {
+	*RootCmdOptions
+	SortBy      string        `mapstructure:"sort-by" yaml:",omitempty"`
+	Interval    time.Duration `mapstructure:"interval" yaml:",omitempty"`
+	Once        bool          `mapstructure:"once" yaml:",omitempty"`
+	MetricsPort uint          `mapstructure:"metrics-port" yaml:",omitempty"`
+	MetricsPath string        `mapstructure:"metrics-path" yaml:",omitempty"`
+}
+
+// integrationTop holds the statistics of a single Integration.
+type integrationTop struct {
+	Name     string
+	Phase    v1.IntegrationPhase
+	Replicas int32
+	Ready    int
+	CPU      resource.Quantity
+	Memory   resource.Quantity
+	Routes   map[string]*routeTop
+	// Scraped reports whether the exchange statistics could be retrieved from at least one pod
+	Scraped bool
+}
+
+// routeTop holds the exchange statistics of a single route, summed across all the pods.
+type routeTop struct {
+	Integration string
+	ID          string
+	Total       float64
+	Failed      float64
+	Inflight    float64
+}
+
+func (it *integrationTop) failures() float64 {
+	failed := 0.0
+	for _, r := range it.Routes {
+		failed += r.Failed
+	}
+	return failed
+}
+
+func (o *topCmdOptions) validate(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("top expects at most 1 argument, received %d", len(args))
+	}
+	switch o.SortBy {
+	case topSortByName, topSortByCPU, topSortByMemory, topSortByFailures:
+	default:
+		return fmt.Errorf("unsupported sort-by value %q, must be one of %s|%s|%s|%s",
+			o.SortBy, topSortByName, topSortByCPU, topSortByMemory, topSortByFailures)
+	}
+	if !o.Once && o.Interval <= 0 {
+		return fmt.Errorf("invalid refresh interval %s", o.Interval)
+	}
+	return nil
+}
+
+func (o *topCmdOptions) run(cmd *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	for {
+		stats, err := o.collect(c, args)
+		if err != nil {
+			return err
+		}
+		if !o.Once {
+			fmt.Fprint(cmd.OutOrStdout(), clearScreen)
+		}
+		if err := o.print(cmd.OutOrStdout(), stats); err != nil {
+			return err
+		}
+		if o.Once {
+			return nil
+		}
+
+		select {
+		case <-o.Context.Done():
+			return nil
+		case <-time.After(o.Interval):
+		}
+	}
+}
+
+func (o *topCmdOptions) collect(c client.Client, args []string) ([]*integrationTop, error) {
+	list := v1.NewIntegrationList()
+	options := []k8sclient.ListOption{
+		k8sclient.InNamespace(o.Namespace),
+	}
+	if len(args) == 1 {
+		options = append(options, k8sclient.MatchingFields{
+			"metadata.name": args[0],
+		})
+	}
+	if err := c.List(o.Context, &list, options...); err != nil {
+		return nil, err
+	}
+
+	stats := make([]*integrationTop, 0, len(list.Items))
+	for _, it := range list.Items {
+		s := integrationTop{
+			Name:   it.Name,
+			Phase:  it.Status.Phase,
+			Routes: make(map[string]*routeTop),
+		}
+		if it.Status.Replicas != nil {
+			s.Replicas = *it.Status.Replicas
+		}
+
+		selector := v1.IntegrationLabel + "=" + it.Name
+		// Resource usage is best effort, as the metrics server may not be installed
+		if metrics, err := kubernetes.LookupPodMetrics(o.Context, c, o.Namespace, selector); err == nil {
+			for _, m := range metrics.Items {
+				s.CPU.Add(m.CPU())
+				s.Memory.Add(m.Memory())
+			}
+		}
+
+		pods, err := c.CoreV1().Pods(o.Namespace).List(o.Context, metav1.ListOptions{
+			LabelSelector: selector,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !kubernetes.IsPodReady(pod) {
+				continue
+			}
+			s.Ready++
+			if err := o.scrape(c, pod, &s); err == nil {
+				s.Scraped = true
+			}
+		}
+
+		stats = append(stats, &s)
+	}
+
+	return stats, nil
+}
+
+// scrape port-forwards to the pod Prometheus endpoint and sums the route exchange statistics into the Integration ones.
+func (o *topCmdOptions) scrape(c client.Client, pod *corev1.Pod, s *integrationTop) error {
+	ctx, cancel := context.WithTimeout(o.Context, 10*time.Second)
+	defer cancel()
+
+	address, err := kubernetes.PortForwardPod(ctx, c, pod.Namespace, pod.Name, o.MetricsPort, io.Discard, io.Discard)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+o.MetricsPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %q from %s", resp.Status, o.MetricsPath)
+	}
+
+	return parseCamelRouteMetrics(resp.Body, s)
+}
+
+// parseCamelRouteMetrics reads Prometheus text format metrics and sums the Camel exchange statistics per route.
+func parseCamelRouteMetrics(r io.Reader, s *integrationTop) error {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return err
+	}
+
+	for name, family := range families {
+		stat, ok := camelExchangeMetrics[name]
+		if !ok {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			routeID := ""
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "routeId" {
+					routeID = l.GetValue()
+				}
+			}
+			if routeID == "" {
+				// Context level statistics
+				continue
+			}
+
+			value := 0.0
+			switch {
+			case m.GetCounter() != nil:
+				value = m.GetCounter().GetValue()
+			case m.GetGauge() != nil:
+				value = m.GetGauge().GetValue()
+			case m.GetUntyped() != nil:
+				value = m.GetUntyped().GetValue()
+			}
+
+			route, ok := s.Routes[routeID]
+			if !ok {
+				route = &routeTop{Integration: s.Name, ID: routeID}
+				s.Routes[routeID] = route
+			}
+			switch stat {
+			case "total":
+				route.Total += value
+			case "failed":
+				route.Failed += value
+			case "inflight":
+				route.Inflight += value
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *topCmdOptions) print(out io.Writer, stats []*integrationTop) error {
+	sortIntegrationTops(stats, o.SortBy)
+
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tPHASE\tREPLICAS\tCPU(cores)\tMEMORY(bytes)\tEXCHANGES\tFAILED\tINFLIGHT")
+	routes := make([]*routeTop, 0)
+	for _, s := range stats {
+		total, failed, inflight := "-", "-", "-"
+		if s.Scraped {
+			t, f, i := 0.0, 0.0, 0.0
+			for _, r := range s.Routes {
+				t += r.Total
+				f += r.Failed
+				i += r.Inflight
+				routes = append(routes, r)
+			}
+			total, failed, inflight = formatCount(t), formatCount(f), formatCount(i)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\t%s\t%s\t%s\t%s\n", s.Name, string(s.Phase), s.Ready, s.Replicas,
+			formatCPU(s.CPU), formatMemory(s.Memory), total, failed, inflight)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(routes) == 0 {
+		return nil
+	}
+
+	sortRouteTops(routes, o.SortBy)
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "INTEGRATION\tROUTE\tEXCHANGES\tFAILED\tINFLIGHT")
+	for _, r := range routes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Integration, r.ID, formatCount(r.Total), formatCount(r.Failed), formatCount(r.Inflight))
+	}
+
+	return w.Flush()
+}
+
+func sortIntegrationTops(stats []*integrationTop, sortBy string) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		switch sortBy {
+		case topSortByCPU:
+			return stats[i].CPU.Cmp(stats[j].CPU) > 0
+		case topSortByMemory:
+			return stats[i].Memory.Cmp(stats[j].Memory) > 0
+		case topSortByFailures:
+			return stats[i].failures() > stats[j].failures()
+		default:
+			return stats[i].Name < stats[j].Name
+		}
+	})
+}
+
+func sortRouteTops(routes []*routeTop, sortBy string) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if sortBy == topSortByFailures && routes[i].Failed != routes[j].Failed {
+			return routes[i].Failed > routes[j].Failed
+		}
+		if routes[i].Integration != routes[j].Integration {
+			return routes[i].Integration < routes[j].Integration
+		}
+		return routes[i].ID < routes[j].ID
+	})
+}
+
+func formatCount(v float64) string {
+	return fmt.Sprintf("%.0f", v)
+}
+
+func formatCPU(q resource.Quantity) string {
+	if q.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%dm", q.ScaledValue(resource.Milli))
+}
+
+func formatMemory(q resource.Quantity) string {
+	if q.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
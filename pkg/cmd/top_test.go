@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cmdTop = "top"
+
+// nolint: unparam
+func initializeTopCmdOptions(t *testing.T) (*topCmdOptions, *cobra.Command, RootCmdOptions) {
+	t.Helper()
+
+	options, rootCmd := kamelTestPreAddCommandInit()
+	topCmdOptions := addTestTopCmd(*options, rootCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return topCmdOptions, rootCmd, *options
+}
+
+func addTestTopCmd(options RootCmdOptions, rootCmd *cobra.Command) *topCmdOptions {
+	// add a testing version of top Command
+	topCmd, topOptions := newCmdTop(&options)
+	topCmd.RunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	topCmd.PostRunE = func(c *cobra.Command, args []string) error {
+		return nil
+	}
+	topCmd.Args = ArbitraryArgs
+	rootCmd.AddCommand(topCmd)
+	return topOptions
+}
+
+func TestTopNonExistingFlag(t *testing.T) {
+	_, rootCmd, _ := initializeTopCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdTop, "--nonExistingFlag")
+	require.Error(t, err)
+}
+
+func TestTopFlags(t *testing.T) {
+	topCmdOptions, rootCmd, _ := initializeTopCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdTop, "--sort-by", "failures", "--interval", "2s", "--once")
+	require.NoError(t, err)
+	assert.Equal(t, topSortByFailures, topCmdOptions.SortBy)
+	assert.Equal(t, 2*time.Second, topCmdOptions.Interval)
+	assert.True(t, topCmdOptions.Once)
+	require.NoError(t, topCmdOptions.validate(nil))
+}
+
+func TestTopInvalidSortBy(t *testing.T) {
+	topCmdOptions, rootCmd, _ := initializeTopCmdOptions(t)
+	_, err := ExecuteCommand(rootCmd, cmdTop, "--sort-by", "foo")
+	require.NoError(t, err)
+	require.EqualError(t, topCmdOptions.validate(nil), `unsupported sort-by value "foo", must be one of name|cpu|memory|failures`)
+}
+
+func TestTopParseCamelRouteMetrics(t *testing.T) {
+	metrics := `# TYPE camel_exchanges_total counter
+camel_exchanges_total{camelContext="camel-1",routeId="route1"} 10.0
+camel_exchanges_total{camelContext="camel-1",routeId="route2"} 4.0
+camel_exchanges_total{camelContext="camel-1"} 14.0
+# TYPE camel_exchanges_failed_total counter
+camel_exchanges_failed_total{camelContext="camel-1",routeId="route1"} 1.0
+camel_exchanges_failed_total{camelContext="camel-1",routeId="route2"} 3.0
+# TYPE camel_exchanges_inflight gauge
+camel_exchanges_inflight{camelContext="camel-1",routeId="route1"} 2.0
+# TYPE jvm_threads_live_threads gauge
+jvm_threads_live_threads 42.0
+`
+	s := integrationTop{Name: "my-it", Routes: make(map[string]*routeTop)}
+	require.NoError(t, parseCamelRouteMetrics(strings.NewReader(metrics), &s))
+	// A second pod adds up to the same routes
+	require.NoError(t, parseCamelRouteMetrics(strings.NewReader(metrics), &s))
+
+	require.Len(t, s.Routes, 2)
+	assert.Equal(t, routeTop{Integration: "my-it", ID: "route1", Total: 20, Failed: 2, Inflight: 4}, *s.Routes["route1"])
+	assert.Equal(t, routeTop{Integration: "my-it", ID: "route2", Total: 8, Failed: 6}, *s.Routes["route2"])
+	assert.InDelta(t, 8.0, s.failures(), 0)
+}
+
+func TestTopPrintSortByFailures(t *testing.T) {
+	stats := []*integrationTop{
+		{
+			Name:    "a",
+			Scraped: true,
+			Routes: map[string]*routeTop{
+				"r1": {Integration: "a", ID: "r1", Total: 5, Failed: 1},
+			},
+		},
+		{
+			Name:    "b",
+			Scraped: true,
+			Routes: map[string]*routeTop{
+				"r2": {Integration: "b", ID: "r2", Total: 5, Failed: 3},
+			},
+		},
+		{
+			Name:   "c",
+			Routes: map[string]*routeTop{},
+		},
+	}
+
+	o := topCmdOptions{SortBy: topSortByFailures}
+	buf := bytes.Buffer{}
+	require.NoError(t, o.print(&buf, stats))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 8)
+	assert.True(t, strings.HasPrefix(lines[1], "b"))
+	assert.True(t, strings.HasPrefix(lines[2], "a"))
+	assert.Contains(t, lines[3], "-")
+	assert.True(t, strings.HasPrefix(lines[6], "b\t"))
+}
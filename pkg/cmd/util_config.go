@@ -93,6 +93,23 @@ func loadConfiguration(location string) (*Config, error) {
 
 // Update ---.
 func (cfg *Config) Update(cmd *cobra.Command, nodeID string, data interface{}, changedOnly bool) {
+	values := flagValues(cmd, data, changedOnly)
+	if len(values) > 0 {
+		cfg.SetNode(nodeID, values)
+	}
+}
+
+// Merge is like Update, but it preserves the content of the subtree that is not set by the given flags.
+func (cfg *Config) Merge(cmd *cobra.Command, nodeID string, data interface{}, changedOnly bool) {
+	values := flagValues(cmd, data, changedOnly)
+	node := cfg.navigate(cfg.content, nodeID, true)
+	for k, v := range values {
+		node[k] = v
+	}
+}
+
+// flagValues returns the values of the fields of data bound to a flag of the command, indexed by mapstructure tag.
+func flagValues(cmd *cobra.Command, data interface{}, changedOnly bool) map[string]interface{} {
 	values := make(map[string]interface{})
 
 	pl := p.NewClient()
@@ -122,9 +139,42 @@ func (cfg *Config) Update(cmd *cobra.Command, nodeID string, data interface{}, c
 		}
 	}
 
-	if len(values) > 0 {
-		cfg.SetNode(nodeID, values)
+	return values
+}
+
+// Get returns the value at the given path, or nil if there is none.
+func (cfg *Config) Get(path string) interface{} {
+	parent, key := splitConfigPath(path)
+	node := cfg.content
+	if parent != "" {
+		node = cfg.navigate(cfg.content, parent, false)
+	}
+	if node == nil {
+		return nil
+	}
+	if m, ok := node[key].(map[interface{}]interface{}); ok {
+		return cfg.convert(m)
+	}
+
+	return node[key]
+}
+
+// SetValue sets the value at the given path, creating the parent nodes if needed.
+func (cfg *Config) SetValue(path string, value interface{}) {
+	parent, key := splitConfigPath(path)
+	node := cfg.content
+	if parent != "" {
+		node = cfg.navigate(cfg.content, parent, true)
+	}
+	node[key] = value
+}
+
+func splitConfigPath(path string) (string, string) {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i], path[i+1:]
 	}
+
+	return "", path
 }
 
 // SetNode allows to replace a subtree with a given content.
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/jolokia"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+// lookupIntegrationPods returns the ready pods of the integration. When a pod name is provided,
+// only that pod is returned, provided it belongs to the integration.
+func lookupIntegrationPods(ctx context.Context, c client.Client, namespace string, integration string, podName string) ([]corev1.Pod, error) {
+	list, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: v1.IntegrationLabel + "=" + integration,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		if podName != "" && pod.Name != podName {
+			continue
+		}
+		if kubernetes.IsPodReady(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+
+	if len(pods) == 0 {
+		if podName != "" {
+			return nil, fmt.Errorf("no ready pod %q found for integration %q in namespace %q", podName, integration, namespace)
+		}
+		return nil, fmt.Errorf("no ready pod found for integration %q in namespace %q", integration, namespace)
+	}
+
+	return pods, nil
+}
+
+// addJolokiaFlags adds the flags configuring the connection to the Jolokia agents.
+func addJolokiaFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint("jolokia-port", 0, "The port of the Jolokia agent, defaults to the port set by the jolokia trait")
+	cmd.Flags().String("jolokia-client-cert", "", "The client certificate file presented to the Jolokia agents served over HTTPS, required by the agents enforcing SSL client authentication")
+	cmd.Flags().String("jolokia-client-key", "", "The private key file of the Jolokia client certificate")
+}
+
+// newJolokiaClient port-forwards to the Jolokia agent of the pod and returns a client targeting it, configured with
+// the port, protocol and credentials of the agent, as set by the jolokia trait. The given port, if any, overrides the
+// port of the agent. The port forward is closed when the context is canceled.
+func newJolokiaClient(ctx context.Context, c client.Client, pod *corev1.Pod, port uint, certFile string, keyFile string) (*jolokia.Client, error) {
+	options := make(map[string]string)
+	for _, container := range pod.Spec.Containers {
+		for k, v := range jolokia.AgentOptions(container.Args) {
+			options[k] = v
+		}
+	}
+
+	address, err := kubernetes.PortForwardPod(ctx, c, pod.Namespace, pod.Name, jolokiaPort(port, options), io.Discard, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the Jolokia agent of pod %q, make sure the jolokia trait is enabled: %w", pod.Name, err)
+	}
+
+	jc, err := jolokia.NewClientForAgent(address, options, certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the Jolokia agent of pod %q: %w", pod.Name, err)
+	}
+
+	return jc, nil
+}
+
+// jolokiaPort returns the given port if set, or the port of the agent options, or the default port of the Jolokia agent.
+func jolokiaPort(port uint, options map[string]string) uint {
+	if port != 0 {
+		return port
+	}
+	if p, err := strconv.ParseUint(options["port"], 10, 32); err == nil && p != 0 {
+		return uint(p)
+	}
+
+	return jolokia.DefaultPort
+}
+
+// lookupCamelContextMBean returns the name of the Camel context MBean registered in the pod.
+func lookupCamelContextMBean(ctx context.Context, jc *jolokia.Client) (string, error) {
+	names, err := jc.Search(ctx, jolokia.CamelContextMBeanPattern)
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no Camel context MBean found, make sure JMX is enabled in the Camel application")
+	}
+
+	return names[0], nil
+}
@@ -0,0 +1,307 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/watch"
+)
+
+func newCmdWait(rootCmdOptions *RootCmdOptions) (*cobra.Command, *waitCmdOptions) {
+	options := waitCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "wait [kind/]name ...",
+		Short: "Wait for integrations, pipes, kits or builds to reach a condition or a phase",
+		Long: `Wait for integrations, pipes, integration kits or builds to reach a condition or a phase, printing their phase changes.
+The supported kinds are integration (it), pipe, integrationkit (kit) and build, integration being the default.
+The command fails as soon as a resource reaches a terminal failure phase, i.e. Error, or Failed and Interrupted for
+a build, unless it is the awaited phase. The timeout applies to each resource in turn.`,
+		Example: `  kamel wait it/my-it --for=condition=Ready --timeout=10m
+  kamel wait pipe/my-pipe --for=phase=Ready
+  kamel wait kit/kit-123 --for=condition=IntegrationKitAvailable=False`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: decode(&options, options.Flags),
+		RunE:    options.run,
+	}
+
+	cmd.Flags().String("for", "", "The condition to wait for, either condition=<type>[=<status>] or phase=<phase>")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "The maximum time to wait for each resource")
+
+	return &cmd, &options
+}
+
+type waitCmdOptions struct {
+	*RootCmdOptions
+	For     string        `mapstructure:"for" yaml:",omitempty"`
+	Timeout time.Duration `mapstructure:"timeout" yaml:",omitempty"`
+}
+
+// waitCondition is the parsed value of the --for flag.
+type waitCondition struct {
+	phase           string
+	conditionType   string
+	conditionStatus corev1.ConditionStatus
+}
+
+// waitKinds maps the accepted kind names to the kind of the resources.
+var waitKinds = map[string]string{
+	"it":                              v1.IntegrationKind,
+	"integration":                     v1.IntegrationKind,
+	"integrations":                    v1.IntegrationKind,
+	"pipe":                            v1.PipeKind,
+	"pipes":                           v1.PipeKind,
+	"kit":                             v1.IntegrationKitKind,
+	"ik":                              v1.IntegrationKitKind,
+	"integrationkit":                  v1.IntegrationKitKind,
+	"integrationkits":                 v1.IntegrationKitKind,
+	"build":                           v1.BuildKind,
+	"builds":                          v1.BuildKind,
+	"integration.camel.apache.org":    v1.IntegrationKind,
+	"pipe.camel.apache.org":           v1.PipeKind,
+	"integrationkit.camel.apache.org": v1.IntegrationKitKind,
+	"build.camel.apache.org":          v1.BuildKind,
+}
+
+func parseWaitCondition(value string) (waitCondition, error) {
+	key, target, ok := strings.Cut(value, "=")
+	if !ok || target == "" {
+		return waitCondition{}, fmt.Errorf("invalid --for value %q, expected condition=<type>[=<status>] or phase=<phase>", value)
+	}
+	switch strings.ToLower(key) {
+	case "phase":
+		return waitCondition{phase: target}, nil
+	case "condition":
+		conditionType, status, hasStatus := strings.Cut(target, "=")
+		if !hasStatus {
+			status = string(corev1.ConditionTrue)
+		}
+		return waitCondition{conditionType: conditionType, conditionStatus: corev1.ConditionStatus(status)}, nil
+	default:
+		return waitCondition{}, fmt.Errorf("invalid --for value %q, expected condition=<type>[=<status>] or phase=<phase>", value)
+	}
+}
+
+func (o *waitCmdOptions) run(cmd *cobra.Command, args []string) error {
+	if o.For == "" {
+		return errors.New("the --for flag is required")
+	}
+	condition, err := parseWaitCondition(o.For)
+	if err != nil {
+		return err
+	}
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		kind, name, err := parseWaitResource(arg)
+		if err != nil {
+			return err
+		}
+		if err := o.waitWithTimeout(cmd, c, kind, name, condition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *waitCmdOptions) waitWithTimeout(cmd *cobra.Command, c client.Client, kind string, name string, condition waitCondition) error {
+	ctx, cancel := context.WithTimeout(o.Context, o.Timeout)
+	defer cancel()
+
+	return o.wait(ctx, cmd, c, kind, name, condition)
+}
+
+func parseWaitResource(arg string) (string, string, error) {
+	kindName, name, ok := strings.Cut(arg, "/")
+	if !ok {
+		return v1.IntegrationKind, arg, nil
+	}
+	kind, ok := waitKinds[strings.ToLower(kindName)]
+	if !ok {
+		return "", "", fmt.Errorf("unsupported kind %s, expected one of integration, pipe, integrationkit or build", kindName)
+	}
+
+	return kind, name, nil
+}
+
+// wait watches the resource until the condition is met, the resource reaches a failure phase, or the context is done.
+func (o *waitCmdOptions) wait(ctx context.Context, cmd *cobra.Command, c client.Client, kind string, name string, condition waitCondition) error {
+	obj, err := newWaitObject(kind)
+	if err != nil {
+		return err
+	}
+	if err := c.Get(ctx, ctrl.ObjectKey{Namespace: o.Namespace, Name: name}, obj); err != nil {
+		return err
+	}
+	listOptions := metav1.ListOptions{
+		FieldSelector:   "metadata.name=" + name,
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+	watcher, err := watchWaitObject(ctx, c, kind, o.Namespace, listOptions)
+	if err != nil {
+		return err
+	}
+
+	resource := fmt.Sprintf("%s/%s", strings.ToLower(kind), name)
+	met := false
+	var waitErr error
+	lastPhase := ""
+	watch.HandleStateChanges(ctx, watcher, obj, waitObjectState, func(obj ctrl.Object) bool {
+		phase, conditions, failure := waitObjectStatus(obj)
+		if phase != lastPhase && phase != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s in phase %s\n", resource, phase)
+			lastPhase = phase
+		}
+		if condition.isMet(phase, conditions) {
+			met = true
+			return false
+		}
+		if isWaitFailurePhase(kind, phase) {
+			waitErr = fmt.Errorf("%s is in phase %s: %s", resource, phase, failure)
+			return false
+		}
+		return true
+	})
+
+	switch {
+	case waitErr != nil:
+		return waitErr
+	case met:
+		fmt.Fprintf(cmd.OutOrStdout(), "%s condition met\n", resource)
+		return nil
+	case ctx.Err() != nil:
+		return fmt.Errorf("timed out waiting for %s to reach %s", resource, o.For)
+	default:
+		return fmt.Errorf("stopped watching %s before it reached %s", resource, o.For)
+	}
+}
+
+func (w waitCondition) isMet(phase string, conditions []v1.ResourceCondition) bool {
+	if w.phase != "" {
+		return strings.EqualFold(w.phase, phase)
+	}
+	for _, c := range conditions {
+		if strings.EqualFold(c.GetType(), w.conditionType) {
+			return strings.EqualFold(string(c.GetStatus()), string(w.conditionStatus))
+		}
+	}
+
+	return false
+}
+
+// isWaitFailurePhase returns true if the phase is a terminal failure phase of the resources of the given kind.
+func isWaitFailurePhase(kind string, phase string) bool {
+	switch kind {
+	case v1.BuildKind:
+		return phase == string(v1.BuildPhaseError) || phase == string(v1.BuildPhaseFailed) || phase == v1.BuildPhaseInterrupted
+	default:
+		return phase == string(v1.IntegrationPhaseError)
+	}
+}
+
+func newWaitObject(kind string) (ctrl.Object, error) {
+	switch kind {
+	case v1.IntegrationKind:
+		return &v1.Integration{}, nil
+	case v1.PipeKind:
+		return &v1.Pipe{}, nil
+	case v1.IntegrationKitKind:
+		return &v1.IntegrationKit{}, nil
+	case v1.BuildKind:
+		return &v1.Build{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+}
+
+func watchWaitObject(ctx context.Context, c client.Client, kind string, namespace string, options metav1.ListOptions) (k8swatch.Interface, error) {
+	switch kind {
+	case v1.IntegrationKind:
+		return c.CamelV1().Integrations(namespace).Watch(ctx, options)
+	case v1.PipeKind:
+		return c.CamelV1().Pipes(namespace).Watch(ctx, options)
+	case v1.IntegrationKitKind:
+		return c.CamelV1().IntegrationKits(namespace).Watch(ctx, options)
+	case v1.BuildKind:
+		return c.CamelV1().Builds(namespace).Watch(ctx, options)
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", kind)
+	}
+}
+
+// waitObjectStatus returns the phase, the conditions and the failure message of the given resource.
+func waitObjectStatus(obj ctrl.Object) (string, []v1.ResourceCondition, string) {
+	switch o := obj.(type) {
+	case *v1.Integration:
+		return string(o.Status.Phase), o.Status.GetConditions(), conditionsFailure(o.Status.GetConditions())
+	case *v1.Pipe:
+		return string(o.Status.Phase), o.Status.GetConditions(), conditionsFailure(o.Status.GetConditions())
+	case *v1.IntegrationKit:
+		failure := conditionsFailure(o.Status.GetConditions())
+		if o.Status.Failure != nil {
+			failure = o.Status.Failure.Reason
+		}
+		return string(o.Status.Phase), o.Status.GetConditions(), failure
+	case *v1.Build:
+		return string(o.Status.Phase), o.Status.GetConditions(), o.Status.Error
+	default:
+		return "", nil, ""
+	}
+}
+
+// waitObjectState returns the phase and the condition statuses of the given resource, so that the watch reports
+// the condition changes happening within a phase.
+func waitObjectState(obj ctrl.Object) string {
+	phase, conditions, _ := waitObjectStatus(obj)
+	state := phase
+	for _, c := range conditions {
+		state += fmt.Sprintf("|%s=%s", c.GetType(), c.GetStatus())
+	}
+
+	return state
+}
+
+// conditionsFailure returns the message of the first condition that is not true, and has a message.
+func conditionsFailure(conditions []v1.ResourceCondition) string {
+	for _, c := range conditions {
+		if c.GetStatus() != corev1.ConditionTrue && c.GetMessage() != "" {
+			return c.GetMessage()
+		}
+	}
+
+	return "no failure message"
+}
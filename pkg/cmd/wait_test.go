@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+const cmdWait = "wait"
+
+// nolint: unparam
+func initializeWaitCmd(t *testing.T, initObjs ...runtime.Object) *cobra.Command {
+	t.Helper()
+	platform := v1.NewIntegrationPlatform("default", "camel-k")
+	fakeClient, err := internal.NewFakeClient(append(initObjs, &platform)...)
+	require.NoError(t, err)
+	options, rootCmd := kamelTestPreAddCommandInitWithClient(fakeClient)
+	options.Namespace = "default"
+	waitCmd, _ := newCmdWait(options)
+	rootCmd.AddCommand(waitCmd)
+	kamelTestPostAddCommandInit(t, rootCmd, options)
+
+	return rootCmd
+}
+
+func TestParseWaitCondition(t *testing.T) {
+	condition, err := parseWaitCondition("condition=Ready")
+	require.NoError(t, err)
+	assert.Equal(t, waitCondition{conditionType: "Ready", conditionStatus: corev1.ConditionTrue}, condition)
+
+	condition, err = parseWaitCondition("condition=Ready=False")
+	require.NoError(t, err)
+	assert.Equal(t, waitCondition{conditionType: "Ready", conditionStatus: corev1.ConditionFalse}, condition)
+
+	condition, err = parseWaitCondition("phase=Running")
+	require.NoError(t, err)
+	assert.Equal(t, waitCondition{phase: "Running"}, condition)
+
+	_, err = parseWaitCondition("delete")
+	require.Error(t, err)
+	_, err = parseWaitCondition("status=Ready")
+	require.Error(t, err)
+}
+
+func TestWaitConditionMet(t *testing.T) {
+	it := v1.NewIntegration("default", "my-it")
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	it.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionTrue, "", "")
+	build := v1.NewBuild("default", "my-build")
+	build.Status.Phase = v1.BuildPhaseSucceeded
+	rootCmd := initializeWaitCmd(t, &it, build)
+
+	output, err := ExecuteCommand(rootCmd, cmdWait, "it/my-it", "--for", "condition=Ready")
+	require.NoError(t, err)
+	assert.Equal(t, "integration/my-it in phase Running\nintegration/my-it condition met\n", output)
+
+	output, err = ExecuteCommand(rootCmd, cmdWait, "my-it", "--for", "phase=running")
+	require.NoError(t, err)
+	assert.Contains(t, output, "integration/my-it condition met\n")
+
+	output, err = ExecuteCommand(rootCmd, cmdWait, "build/my-build", "--for", "phase=Succeeded")
+	require.NoError(t, err)
+	assert.Equal(t, "build/my-build in phase Succeeded\nbuild/my-build condition met\n", output)
+}
+
+func TestWaitErrorPhase(t *testing.T) {
+	pipe := v1.NewPipe("default", "my-pipe")
+	pipe.Status.Phase = v1.PipePhaseError
+	pipe.Status.SetCondition(v1.PipeConditionReady, corev1.ConditionFalse, "Error", "cannot resolve kamelet")
+	rootCmd := initializeWaitCmd(t, &pipe)
+
+	_, err := ExecuteCommand(rootCmd, cmdWait, "pipe/my-pipe", "--for", "phase=Ready")
+	require.EqualError(t, err, "pipe/my-pipe is in phase Error: cannot resolve kamelet")
+
+	_, err = ExecuteCommand(rootCmd, cmdWait, "pipe/my-pipe", "--for", "phase=Error")
+	require.NoError(t, err)
+}
+
+func TestWaitBuildFailurePhases(t *testing.T) {
+	failed := v1.NewBuild("default", "failed-build")
+	failed.Status.Phase = v1.BuildPhaseFailed
+	failed.Status.Error = "maven failure"
+	interrupted := v1.NewBuild("default", "interrupted-build")
+	interrupted.Status.Phase = v1.BuildPhaseInterrupted
+	interrupted.Status.Error = "interrupted by a newer build"
+	rootCmd := initializeWaitCmd(t, failed, interrupted)
+
+	_, err := ExecuteCommand(rootCmd, cmdWait, "build/failed-build", "--for", "phase=Succeeded")
+	require.EqualError(t, err, "build/failed-build is in phase Failed: maven failure")
+
+	_, err = ExecuteCommand(rootCmd, cmdWait, "build/interrupted-build", "--for", "phase=Succeeded")
+	require.EqualError(t, err, "build/interrupted-build is in phase Interrupted: interrupted by a newer build")
+}
+
+func TestWaitTimeout(t *testing.T) {
+	kit := v1.NewIntegrationKit("default", "my-kit")
+	kit.Status.Phase = v1.IntegrationKitPhaseBuildRunning
+	rootCmd := initializeWaitCmd(t, kit)
+
+	_, err := ExecuteCommand(rootCmd, cmdWait, "kit/my-kit", "--for", "phase=Ready", "--timeout", "100ms")
+	require.EqualError(t, err, "timed out waiting for integrationkit/my-kit to reach phase=Ready")
+
+	// The timeout applies to each resource
+	it := v1.NewIntegration("default", "my-it")
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	rootCmd = initializeWaitCmd(t, kit, &it)
+	_, err = ExecuteCommand(rootCmd, cmdWait, "it/my-it", "kit/my-kit", "--for", "phase=Running", "--timeout", "100ms")
+	require.EqualError(t, err, "timed out waiting for integrationkit/my-kit to reach phase=Running")
+
+	_, err = ExecuteCommand(rootCmd, cmdWait, "foo/my-kit", "--for", "phase=Ready")
+	require.Error(t, err)
+}
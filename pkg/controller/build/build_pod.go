@@ -28,6 +28,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/builder"
 	"github.com/apache/camel-k/v2/pkg/client"
 	"github.com/apache/camel-k/v2/pkg/platform"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
@@ -35,8 +36,10 @@ import (
 )
 
 const (
-	builderDir    = "/builder"
-	builderVolume = "camel-k-builder"
+	builderDir       = "/builder"
+	builderVolume    = "camel-k-builder"
+	mavenCacheVolume = "camel-k-maven-cache"
+	ociLayoutVolume  = "camel-k-oci-layout"
 )
 
 func newBuildPod(ctx context.Context, client client.Client, build *v1.Build) *corev1.Pod {
@@ -83,6 +86,7 @@ func newBuildPod(ctx context.Context, client client.Client, build *v1.Build) *co
 		switch {
 		// Builder task
 		case task.Builder != nil:
+			addMavenCacheToPod(ctx, client, build, task.Builder.Maven.Cache, pod)
 			addBuildTaskToPod(ctx, client, build, task.Builder.Name, pod)
 		// Custom task
 		case task.Custom != nil:
@@ -97,6 +101,9 @@ func newBuildPod(ctx context.Context, client client.Client, build *v1.Build) *co
 			addBuildTaskToPod(ctx, client, build, task.Spectrum.Name, pod)
 		case task.Jib != nil:
 			addBuildTaskToPod(ctx, client, build, task.Jib.Name, pod)
+		case task.OCILayout != nil:
+			addOCILayoutToPod(task.OCILayout, pod)
+			addBuildTaskToPod(ctx, client, build, task.OCILayout.Name, pod)
 		}
 	}
 
@@ -228,10 +235,60 @@ func addBuildTaskToPod(ctx context.Context, client client.Client, build *v1.Buil
 		}
 	}
 
+	if hasVolume(pod, mavenCacheVolume) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      mavenCacheVolume,
+			MountPath: builder.MavenCacheDir,
+		})
+	}
+	if hasVolume(pod, ociLayoutVolume) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      ociLayoutVolume,
+			MountPath: builder.OCILayoutDir,
+		})
+	}
+
 	configureResources(taskName, build, &container)
 	addContainerToPod(build, container, pod)
 }
 
+// addMavenCacheToPod adds the volume of the persistent Maven cache to the pod, when the PersistentVolumeClaim
+// of the cache exists in the namespace of the pod. Otherwise, the build runs with an empty local repository.
+func addMavenCacheToPod(ctx context.Context, c client.Client, build *v1.Build, cache *v1.MavenCacheSpec, pod *corev1.Pod) {
+	if cache == nil || hasVolume(pod, mavenCacheVolume) {
+		return
+	}
+	pvc, err := kubernetes.LookupPersistentVolumeClaim(ctx, c, build.BuilderPodNamespace(), cache.PersistentVolumeClaim)
+	if err != nil || pvc == nil {
+		Log.Infof("Maven cache %s not found in namespace %s, build %s runs without it",
+			cache.PersistentVolumeClaim, build.BuilderPodNamespace(), build.Name)
+		return
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: mavenCacheVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.Name,
+			},
+		},
+	})
+}
+
+// addOCILayoutToPod adds the volume of the OCI image layout the image is written to.
+func addOCILayoutToPod(task *v1.OCILayoutTask, pod *corev1.Pod) {
+	if hasVolume(pod, ociLayoutVolume) {
+		return
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: ociLayoutVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: task.PersistentVolumeClaim,
+			},
+		},
+	})
+}
+
 func addCustomTaskToPod(build *v1.Build, task *v1.UserTask, pod *corev1.Pod) {
 	container := corev1.Container{
 		Name:            task.Name,
@@ -25,6 +25,7 @@ import (
 	"github.com/apache/camel-k/v2/pkg/internal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -67,3 +68,101 @@ func TestNewBuildPodConfiguration(t *testing.T) {
 	assert.Equal(t, map[string]string{"node": "selector"}, pod.Spec.NodeSelector)
 	assert.Equal(t, map[string]string{"annotation": "value"}, pod.Annotations)
 }
+
+func TestNewBuildPodWithMavenCache(t *testing.T) {
+	ctx := context.TODO()
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "maven-cache",
+		},
+	}
+	c, err := internal.NewFakeClient(&pvc)
+	require.NoError(t, err)
+
+	build := v1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "theBuildName",
+		},
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{
+							Name: "builder",
+							Configuration: v1.BuildConfiguration{
+								BuilderPodNamespace: "ns",
+							},
+						},
+						Maven: v1.MavenBuildSpec{
+							MavenSpec: v1.MavenSpec{
+								Cache: &v1.MavenCacheSpec{PersistentVolumeClaim: "maven-cache"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pod := newBuildPod(ctx, c, &build)
+	require.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, mavenCacheVolume, pod.Spec.Volumes[0].Name)
+	assert.Equal(t, "maven-cache", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Contains(t, pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      mavenCacheVolume,
+		MountPath: "/etc/maven/cache",
+	})
+
+	// The build runs without the cache when its PersistentVolumeClaim does not exist
+	build.Spec.Tasks[0].Builder.Maven.Cache.PersistentVolumeClaim = "missing"
+	pod = newBuildPod(ctx, c, &build)
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, builderVolume, pod.Spec.Volumes[0].Name)
+}
+
+func TestNewBuildPodWithOCILayout(t *testing.T) {
+	ctx := context.TODO()
+	c, err := internal.NewFakeClient()
+	require.NoError(t, err)
+
+	build := v1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "theBuildName",
+		},
+		Spec: v1.BuildSpec{
+			Tasks: []v1.Task{
+				{
+					Builder: &v1.BuilderTask{
+						BaseTask: v1.BaseTask{Name: "builder"},
+					},
+				},
+				{
+					OCILayout: &v1.OCILayoutTask{
+						BaseTask:              v1.BaseTask{Name: "oci-layout"},
+						PersistentVolumeClaim: "oci-layout",
+						Format:                v1.OCILayoutFormatTarball,
+					},
+				},
+			},
+		},
+	}
+
+	pod := newBuildPod(ctx, c, &build)
+	require.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, ociLayoutVolume, pod.Spec.Volumes[1].Name)
+	assert.Equal(t, "oci-layout", pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, "oci-layout", pod.Spec.Containers[0].Name)
+	assert.Contains(t, pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      ociLayoutVolume,
+		MountPath: "/etc/camel-k/oci-layout",
+	})
+	require.Len(t, pod.Spec.InitContainers, 1)
+	assert.NotContains(t, pod.Spec.InitContainers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      ociLayoutVolume,
+		MountPath: "/etc/camel-k/oci-layout",
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+// podEvictedReason is the reason of the failure of the evicted Pods.
+const podEvictedReason = "Evicted"
+
+// classifyFailure classifies the failure of the Build, from its error and from the reasons and messages of its
+// failed conditions, such as the termination reason and message of the containers of the builder Pod.
+func classifyFailure(build *v1.Build) v1.FailureClass {
+	messages := []string{build.Status.Error}
+	for _, condition := range build.Status.Conditions {
+		if condition.Status == corev1.ConditionFalse {
+			messages = append(messages, condition.Reason, condition.Message)
+		}
+	}
+	message := strings.Join(messages, "\n")
+
+	switch maven.ClassifyError(message) {
+	case maven.PermanentError:
+		return v1.FailureClassPermanent
+	case maven.TransientError:
+		return v1.FailureClassTransient
+	case maven.UnknownError:
+		if strings.Contains(message, podEvictedReason) {
+			// The builder Pod has been evicted from its node, e.g. under node pressure
+			return v1.FailureClassTransient
+		}
+	}
+
+	return v1.FailureClassUnknown
+}
@@ -30,8 +30,9 @@ import (
 )
 
 const (
-	buildResultLabel = "result"
-	buildTypeLabel   = "type"
+	buildResultLabel       = "result"
+	buildTypeLabel         = "type"
+	buildFailureClassLabel = "class"
 )
 
 var (
@@ -66,6 +67,17 @@ var (
 		},
 	)
 
+	buildFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "camel_k_build_failures_total",
+			Help: "Camel K build failures",
+		},
+		[]string{
+			buildFailureClassLabel,
+			buildTypeLabel,
+		},
+	)
+
 	queueDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "camel_k_build_queue_duration_seconds",
@@ -86,7 +98,7 @@ var (
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(buildDuration, buildRecovery, queueDuration)
+	metrics.Registry.MustRegister(buildDuration, buildRecovery, buildFailures, queueDuration)
 }
 
 func observeBuildQueueDuration(build *v1.Build, creator *corev1.ObjectReference) {
@@ -130,6 +142,11 @@ func observeBuildResult(build *v1.Build, phase v1.BuildPhase, creator *corev1.Ob
 	buildDuration.WithLabelValues(resultLabel, typeLabel).Observe(duration.Seconds())
 }
 
+func observeBuildFailure(build *v1.Build, class v1.FailureClass) {
+	Log.WithValues("build-failure-class", class).ForBuild(build).Infof("Build failure class %s", class)
+	buildFailures.WithLabelValues(string(class), build.Labels[v1.IntegrationKitLayoutLabel]).Inc()
+}
+
 func getBuildAttemptFor(build *v1.Build) (int, int) {
 	attempt := 0
 	attemptMax := math.MaxInt32
@@ -161,6 +161,7 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 				)
 			}
 		}
+		setProvenanceSubject(&build.Status)
 
 	case corev1.PodFailed:
 		phase := v1.BuildPhaseFailed
@@ -170,6 +171,8 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1.Build) (*v
 			message = fmt.Sprintf("Builder Pod %s deleted", pod.Name)
 		} else if _, ok := pod.GetAnnotations()[timeoutAnnotation]; ok {
 			message = fmt.Sprintf("Builder Pod %s timeout", pod.Name)
+		} else if pod.Status.Reason == podEvictedReason {
+			message = fmt.Sprintf("Builder Pod %s %s: %s", pod.Name, podEvictedReason, pod.Status.Message)
 		}
 		// Do not override errored build
 		if build.Status.Phase == v1.BuildPhaseError {
@@ -364,6 +367,8 @@ func publishTaskName(tasks []v1.Task) string {
 		return t.Jib.Name
 	case t.S2i != nil:
 		return t.S2i.Name
+	case t.OCILayout != nil:
+		return t.OCILayout.Name
 	}
 
 	return ""
@@ -382,5 +387,5 @@ func publishTaskDigest(tasks []v1.Task, cntStates []corev1.ContainerStatus) stri
 
 func operatorSupportedPublishingStrategy(tasks []v1.Task) bool {
 	taskName := publishTaskName(tasks)
-	return taskName == "jib" || taskName == "spectrum" || taskName == "s2i"
+	return taskName == "jib" || taskName == "spectrum" || taskName == "s2i" || taskName == "oci-layout"
 }
@@ -104,6 +104,7 @@ func (action *monitorRoutineAction) runBuild(ctx context.Context, build *v1.Buil
 	defer cancel()
 
 	status := v1.BuildStatus{}
+	var provenance *v1.Provenance
 	buildDir := ""
 	Builder := builder.New(action.client)
 
@@ -166,6 +167,9 @@ tasks:
 
 			// Execute the task
 			status = Builder.Build(build).Task(task).Do(ctxWithTimeout)
+			if status.Provenance != nil {
+				provenance = status.Provenance
+			}
 
 			lastTask := i == len(build.Spec.Tasks)-1
 			taskFailed := status.Phase == v1.BuildPhaseFailed ||
@@ -173,6 +177,9 @@ tasks:
 				status.Phase == v1.BuildPhaseInterrupted
 			if lastTask && !taskFailed {
 				status.Phase = v1.BuildPhaseSucceeded
+				// The provenance is attested by the builder task, before the image is published
+				status.Provenance = provenance
+				setProvenanceSubject(&status)
 			}
 
 			if lastTask || taskFailed {
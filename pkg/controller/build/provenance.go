@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+// setProvenanceSubject records the image published by the build, addressed by its digest, as the subject of the
+// provenance attestation of the build. The provenance is left as is when the digest of the image is not known.
+func setProvenanceSubject(status *v1.BuildStatus) {
+	if status.Provenance == nil || status.Image == "" || status.Digest == "" {
+		return
+	}
+
+	image := status.Image
+	if i := strings.LastIndex(image, "@"); i > 0 {
+		image = image[:i]
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	status.Provenance.Image = image + "@" + status.Digest
+}
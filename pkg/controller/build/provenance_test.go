@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+func TestSetProvenanceSubject(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	status := v1.BuildStatus{
+		Image:      "registry:5000/ns/camel-k-kit-abc:123",
+		Digest:     digest,
+		Provenance: &v1.Provenance{InputsDigest: "sha256:inputs"},
+	}
+	setProvenanceSubject(&status)
+	assert.Equal(t, "registry:5000/ns/camel-k-kit-abc@"+digest, status.Provenance.Image)
+
+	status = v1.BuildStatus{
+		Image:      "registry:5000/ns/camel-k-kit-abc",
+		Digest:     digest,
+		Provenance: &v1.Provenance{},
+	}
+	setProvenanceSubject(&status)
+	assert.Equal(t, "registry:5000/ns/camel-k-kit-abc@"+digest, status.Provenance.Image)
+
+	// The digest of the image is unknown
+	status = v1.BuildStatus{
+		Image:      "registry:5000/ns/camel-k-kit-abc:123",
+		Provenance: &v1.Provenance{},
+	}
+	setProvenanceSubject(&status)
+	assert.Empty(t, status.Provenance.Image)
+
+	// No provenance is attested
+	status = v1.BuildStatus{
+		Image:  "registry:5000/ns/camel-k-kit-abc:123",
+		Digest: digest,
+	}
+	setProvenanceSubject(&status)
+	assert.Nil(t, status.Provenance)
+}
@@ -33,23 +33,16 @@ const (
 	defaultRecoveryBackoffMaxDuration = 1 * time.Second
 	defaultRecoveryBackoffFactor      = 2
 	defaultRecoveryMaxAttempt         = 5
+	// defaultRetryPolicyBackoffMaxDuration is the maximum backoff of the Builds with a retry policy not setting it.
+	defaultRetryPolicyBackoffMaxDuration = 1 * time.Minute
 )
 
 func newErrorRecoveryAction() Action {
-	// TODO: externalize options
-	return &errorRecoveryAction{
-		backOff: backoff.Backoff{
-			Min:    defaultRecoveryBackoffMinDuration,
-			Max:    defaultRecoveryBackoffMaxDuration,
-			Factor: defaultRecoveryBackoffFactor,
-			Jitter: false,
-		},
-	}
+	return &errorRecoveryAction{}
 }
 
 type errorRecoveryAction struct {
 	baseAction
-	backOff backoff.Backoff
 }
 
 func (action *errorRecoveryAction) Name() string {
@@ -61,13 +54,24 @@ func (action *errorRecoveryAction) CanHandle(build *v1.Build) bool {
 }
 
 func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build) (*v1.Build, error) {
-	if build.Status.Failure == nil {
-		build.Status.Failure = &v1.Failure{
-			Reason: build.Status.Error,
-			Time:   metav1.Now(),
-			Recovery: v1.FailureRecovery{
-				AttemptMax: defaultRecoveryMaxAttempt,
-			},
+	// The class of the failure is reset on each recovery attempt, so that the failure of the attempt is classified
+	if build.Status.Failure == nil || build.Status.Failure.Class == "" {
+		if build.Status.Failure == nil {
+			build.Status.Failure = &v1.Failure{
+				Time: metav1.Now(),
+				Recovery: v1.FailureRecovery{
+					AttemptMax: retryMaxAttempts(build.Spec.RetryPolicy),
+				},
+			}
+		}
+		class := classifyFailure(build)
+		build.Status.Failure.Reason = build.Status.Error
+		build.Status.Failure.Class = class
+		observeBuildFailure(build, class)
+
+		if class == v1.FailureClassPermanent {
+			action.L.Infof("Permanent failure, the build is not recovered: %s", build.Status.Error)
+			build.Status.Phase = v1.BuildPhaseError
 		}
 		return build, nil
 	}
@@ -83,13 +87,14 @@ func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build)
 	}
 
 	elapsed := time.Since(lastAttempt).Seconds()
-	elapsedMin := action.backOff.ForAttempt(float64(build.Status.Failure.Recovery.Attempt)).Seconds()
+	elapsedMin := retryBackoff(build.Spec.RetryPolicy).ForAttempt(float64(build.Status.Failure.Recovery.Attempt)).Seconds()
 
 	if elapsed < elapsedMin {
 		return nil, nil
 	}
 
 	build.Status.Phase = v1.BuildPhaseInitialization
+	build.Status.Failure.Class = ""
 	build.Status.Failure.Recovery.Attempt++
 	build.Status.Failure.Recovery.AttemptTime = metav1.Now()
 
@@ -100,3 +105,32 @@ func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1.Build)
 
 	return build, nil
 }
+
+// retryMaxAttempts returns the maximum number of attempts to recover a failed Build, from its retry policy if any.
+func retryMaxAttempts(policy *v1.BuildRetryPolicy) int {
+	if policy == nil || policy.MaxAttempts == nil {
+		return defaultRecoveryMaxAttempt
+	}
+	return *policy.MaxAttempts
+}
+
+// retryBackoff returns the exponential backoff between the attempts to recover a failed Build, from its retry
+// policy if any.
+func retryBackoff(policy *v1.BuildRetryPolicy) *backoff.Backoff {
+	b := &backoff.Backoff{
+		Min:    defaultRecoveryBackoffMinDuration,
+		Max:    defaultRecoveryBackoffMaxDuration,
+		Factor: defaultRecoveryBackoffFactor,
+		Jitter: false,
+	}
+	if policy != nil {
+		b.Max = defaultRetryPolicyBackoffMaxDuration
+		if policy.MinBackoff != nil {
+			b.Min = policy.MinBackoff.Duration
+		}
+		if policy.MaxBackoff != nil {
+			b.Max = policy.MaxBackoff.Duration
+		}
+	}
+	return b
+}
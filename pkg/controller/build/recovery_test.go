@@ -0,0 +1,136 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+)
+
+func newFailedBuild(message string, policy *v1.BuildRetryPolicy) *v1.Build {
+	build := v1.NewBuild("ns", "my-build")
+	build.Spec.RetryPolicy = policy
+	build.Status.Phase = v1.BuildPhaseFailed
+	build.Status.Error = message
+	return build
+}
+
+func newTestErrorRecoveryAction() Action {
+	action := newErrorRecoveryAction()
+	action.InjectLogger(log.Log)
+	return action
+}
+
+func TestRecoveryPermanentFailure(t *testing.T) {
+	build := newFailedBuild("COMPILATION ERROR : ", nil)
+
+	build, err := newTestErrorRecoveryAction().Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, build)
+	assert.Equal(t, v1.BuildPhaseError, build.Status.Phase)
+	assert.Equal(t, v1.FailureClassPermanent, build.Status.Failure.Class)
+	assert.Equal(t, "COMPILATION ERROR : ", build.Status.Failure.Reason)
+	assert.Equal(t, 0, build.Status.Failure.Recovery.Attempt)
+}
+
+func TestRecoveryTransientFailure(t *testing.T) {
+	maxAttempts := 1
+	build := newFailedBuild("Could not transfer artifact org.acme:lib:jar:1.0 from/to central: Connect timed out", &v1.BuildRetryPolicy{
+		MaxAttempts: &maxAttempts,
+		MinBackoff:  &metav1.Duration{Duration: time.Millisecond},
+	})
+	action := newTestErrorRecoveryAction()
+
+	build, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, build)
+	assert.Equal(t, v1.BuildPhaseFailed, build.Status.Phase)
+	assert.Equal(t, v1.FailureClassTransient, build.Status.Failure.Class)
+	assert.Equal(t, 1, build.Status.Failure.Recovery.AttemptMax)
+
+	time.Sleep(10 * time.Millisecond)
+	build, err = action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, build)
+	assert.Equal(t, v1.BuildPhaseInitialization, build.Status.Phase)
+	assert.Equal(t, 1, build.Status.Failure.Recovery.Attempt)
+	assert.Empty(t, build.Status.Failure.Class)
+
+	// The recovery attempt fails again, and the attempts are exhausted
+	build.Status.Phase = v1.BuildPhaseFailed
+	build.Status.Error = "Builder Pod my-build Evicted: The node was low on resource: memory."
+	build, err = action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, build)
+	assert.Equal(t, v1.FailureClassTransient, build.Status.Failure.Class)
+	assert.Equal(t, build.Status.Error, build.Status.Failure.Reason)
+	build, err = action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, build)
+	assert.Equal(t, v1.BuildPhaseError, build.Status.Phase)
+}
+
+func TestRecoveryBackoff(t *testing.T) {
+	build := newFailedBuild("Build routine not running", nil)
+	action := newTestErrorRecoveryAction()
+
+	build, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	require.NotNil(t, build)
+	assert.Equal(t, v1.FailureClassUnknown, build.Status.Failure.Class)
+	assert.Equal(t, defaultRecoveryMaxAttempt, build.Status.Failure.Recovery.AttemptMax)
+
+	// Waiting for the backoff
+	target, err := action.Handle(context.TODO(), build)
+	require.NoError(t, err)
+	assert.Nil(t, target)
+
+	// Without retry policy, the backoff is capped to the historical maximum
+	backoff := retryBackoff(nil)
+	assert.Equal(t, time.Second, backoff.ForAttempt(0))
+	assert.Equal(t, time.Second, backoff.ForAttempt(10))
+
+	backoff = retryBackoff(&v1.BuildRetryPolicy{})
+	assert.Equal(t, 5*time.Second, backoff.ForAttempt(0))
+	assert.Equal(t, 20*time.Second, backoff.ForAttempt(2))
+	assert.Equal(t, time.Minute, backoff.ForAttempt(10))
+}
+
+func TestClassifyFailure(t *testing.T) {
+	build := newFailedBuild("Builder Pod my-build failed (see conditions for more details)", nil)
+	assert.Equal(t, v1.FailureClassUnknown, classifyFailure(build))
+
+	build.Status.SetCondition("ContainerbuilderSucceeded", corev1.ConditionFalse, "Error (1)",
+		"[ERROR] Failed to execute goal on project camel-k-integration: Could not resolve dependencies: "+
+			"Could not find artifact org.acme:missing:jar:1.0 in central")
+	assert.Equal(t, v1.FailureClassPermanent, classifyFailure(build))
+
+	build.Status.Conditions = nil
+	build.Status.SetCondition("ContainerjibSucceeded", corev1.ConditionFalse, "Error (1)",
+		"Error: PUT https://registry/v2/ns/camel-k-kit/manifests/1: unexpected status code 503 Service Unavailable")
+	assert.Equal(t, v1.FailureClassTransient, classifyFailure(build))
+}
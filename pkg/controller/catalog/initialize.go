@@ -51,8 +51,8 @@ func (action *initializeAction) Handle(ctx context.Context, catalog *v1.CamelCat
 		return catalog, err
 	}
 
-	if platform.Status.Phase != v1.IntegrationPlatformPhaseReady {
-		// Wait the platform to be ready
+	if platform.Status.Phase != v1.IntegrationPlatformPhaseReady && platform.Status.Phase != v1.IntegrationPlatformPhaseWarming {
+		// Wait the platform to be ready, or to build its warm kits
 		return catalog, nil
 	}
 
@@ -0,0 +1,348 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitwebhook serves the Git push webhooks, rebuilding the Integrations built from the pushed repository and ref.
+// The push events of GitHub, GitLab and Gitea are supported.
+package gitwebhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/platform"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+)
+
+const (
+	// PortEnvVariable is the environment variable of the operator enabling the Git webhook on the given port.
+	PortEnvVariable = "CAMEL_K_GIT_WEBHOOK_PORT"
+	// SecretEnvVariable is the environment variable of the operator holding the secret the webhooks are authenticated
+	// with: the key of the signature of the GitHub and Gitea webhooks, or the token of the GitLab webhooks.
+	SecretEnvVariable = "CAMEL_K_GIT_WEBHOOK_SECRET"
+
+	maxPayloadSize = 10 << 20
+)
+
+var glog = log.WithName("git-webhook")
+
+// pushEvent holds the fields of the GitHub, GitLab and Gitea push events identifying the pushed repository and ref.
+type pushEvent struct {
+	Ref         string `json:"ref"`
+	After       string `json:"after"`
+	CheckoutSHA string `json:"checkout_sha"`
+	Deleted     bool   `json:"deleted"`
+	Repository  struct {
+		URL           string `json:"url"`
+		HTMLURL       string `json:"html_url"`
+		CloneURL      string `json:"clone_url"`
+		SSHURL        string `json:"ssh_url"`
+		GitHTTPURL    string `json:"git_http_url"`
+		GitSSHURL     string `json:"git_ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+	Project struct {
+		WebURL        string `json:"web_url"`
+		GitHTTPURL    string `json:"git_http_url"`
+		GitSSHURL     string `json:"git_ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"project"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
+	} `json:"commits"`
+}
+
+// Handler handles the Git push webhooks.
+type Handler struct {
+	client     client.Client
+	secret     []byte
+	namespaces []string
+}
+
+// NewHandler returns the handler of the Git push webhooks, authenticated with the given secret, rebuilding the
+// Integrations of the given namespaces, or of all the namespaces when none is given. The secret is required, as the
+// webhooks trigger builds.
+func NewHandler(c client.Client, secret string, namespaces ...string) (*Handler, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("the Git webhook requires a secret, set with the %s environment variable", SecretEnvVariable)
+	}
+
+	return &Handler{
+		client:     c,
+		secret:     []byte(secret),
+		namespaces: namespaces,
+	}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.authenticate(r.Header, body) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !isPushEvent(r.Header) {
+		fmt.Fprintln(w, "event ignored")
+		return
+	}
+
+	var event pushEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, fmt.Sprintf("invalid push event: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	rebuilt, err := h.rebuild(r.Context(), event)
+	if err != nil {
+		glog.Error(err, "cannot rebuild the Integrations")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "%d integration(s) rebuilt\n", rebuilt)
+}
+
+// authenticate checks the signature of the GitHub and Gitea payloads, or the token of the GitLab webhooks.
+func (h *Handler) authenticate(header http.Header, body []byte) bool {
+	if len(h.secret) == 0 {
+		return false
+	}
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), h.secret) == 1
+	}
+	signature := strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if signature == "" {
+		signature = header.Get("X-Gitea-Signature")
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// isPushEvent returns whether the webhook is a push event, assuming it is when the event type is not set.
+func isPushEvent(header http.Header) bool {
+	for _, name := range []string{"X-GitHub-Event", "X-Gitea-Event", "X-Gitlab-Event"} {
+		if event := header.Get(name); event != "" {
+			return event == "push" || event == "Push Hook" || event == "Tag Push Hook"
+		}
+	}
+	return true
+}
+
+// rebuild rebuilds the Integrations handled by the operator, built from the pushed repository and ref, which have not
+// been built from the pushed commit yet, and returns the number of rebuilt Integrations. The Integrations are rebuilt as
+// with `kamel rebuild`, by resetting their status.
+func (h *Handler) rebuild(ctx context.Context, event pushEvent) (int, error) {
+	if event.Deleted || event.Ref == "" {
+		return 0, nil
+	}
+	commit := event.After
+	if event.CheckoutSHA != "" {
+		commit = event.CheckoutSHA
+	}
+	urls := make(map[string]bool)
+	for _, u := range []string{
+		event.Repository.URL, event.Repository.HTMLURL, event.Repository.CloneURL, event.Repository.SSHURL,
+		event.Repository.GitHTTPURL, event.Repository.GitSSHURL,
+		event.Project.WebURL, event.Project.GitHTTPURL, event.Project.GitSSHURL,
+	} {
+		if u != "" {
+			urls[NormalizeURL(u)] = true
+		}
+	}
+	defaultBranch := event.Repository.DefaultBranch
+	if defaultBranch == "" {
+		defaultBranch = event.Project.DefaultBranch
+	}
+
+	integrations, err := h.integrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rebuilt := 0
+	var errs []error
+	for _, it := range integrations {
+		if !platform.IsOperatorHandler(&it) {
+			continue
+		}
+		git := it.Spec.Git
+		if git == nil || !urls[NormalizeURL(git.URL)] || !refMatches(git, event.Ref, defaultBranch) {
+			continue
+		}
+		if commit != "" && it.Status.GitCommit == commit {
+			continue
+		}
+		if it.Status.Phase == v1.IntegrationPhaseNone || it.Status.Phase == v1.IntegrationPhaseInitialization {
+			// Already about to be rebuilt
+			continue
+		}
+		if git.Path != "" && !pathChanged(event, git.Path) {
+			continue
+		}
+
+		glog.Infof("Rebuilding Integration %s/%s after push of %s to %s", it.Namespace, it.Name, event.Ref, git.URL)
+		it.Status = v1.IntegrationStatus{}
+		if err := h.client.Status().Update(ctx, &it); err != nil {
+			errs = append(errs, fmt.Errorf("cannot rebuild Integration %s/%s: %w", it.Namespace, it.Name, err))
+			continue
+		}
+		rebuilt++
+	}
+
+	return rebuilt, errors.Join(errs...)
+}
+
+// integrations lists the Integrations of the namespaces of the handler.
+func (h *Handler) integrations(ctx context.Context) ([]v1.Integration, error) {
+	if len(h.namespaces) == 0 {
+		integrations := v1.NewIntegrationList()
+		if err := h.client.List(ctx, &integrations); err != nil {
+			return nil, err
+		}
+		return integrations.Items, nil
+	}
+
+	var items []v1.Integration
+	for _, ns := range h.namespaces {
+		integrations := v1.NewIntegrationList()
+		if err := h.client.List(ctx, &integrations, ctrl.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		items = append(items, integrations.Items...)
+	}
+
+	return items, nil
+}
+
+// refMatches returns whether the pushed ref is the one the Integration is built from. The Integrations built from a
+// given commit are never rebuilt.
+func refMatches(git *v1.GitConfigSpec, ref string, defaultBranch string) bool {
+	switch {
+	case git.Commit != "":
+		return false
+	case git.Tag != "":
+		return ref == "refs/tags/"+git.Tag
+	case git.Branch != "":
+		return ref == "refs/heads/"+git.Branch
+	default:
+		return defaultBranch != "" && ref == "refs/heads/"+defaultBranch
+	}
+}
+
+// pathChanged returns whether a file of the given directory has changed in the pushed commits, assuming it has when
+// the event does not list the changed files.
+func pathChanged(event pushEvent, path string) bool {
+	if len(event.Commits) == 0 {
+		return true
+	}
+	prefix := strings.Trim(path, "/") + "/"
+	for _, commit := range event.Commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, file := range files {
+				if strings.HasPrefix(file, prefix) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// NormalizeURL returns the host and path of the given Git repository URL, so that the HTTP and SSH URLs of a same
+// repository can be compared, e.g. `github.com/apache/camel-k` for both `https://github.com/apache/camel-k.git` and
+// `git@github.com:apache/camel-k.git`.
+func NormalizeURL(url string) string {
+	u := strings.ToLower(strings.TrimSpace(url))
+	scheme := false
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		scheme = true
+	}
+	if i := strings.Index(u, "@"); i >= 0 && (strings.Index(u, "/") < 0 || i < strings.Index(u, "/")) {
+		u = u[i+1:]
+	}
+	host, path, _ := strings.Cut(u, "/")
+	if h, p, ok := strings.Cut(host, ":"); ok {
+		if scheme {
+			// The port of the host
+			host = h
+		} else {
+			// The SCP-like syntax of the SSH URLs
+			host = h
+			path = strings.TrimPrefix(p+"/"+path, "/")
+		}
+	}
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+
+	return strings.TrimSuffix(host+"/"+path, "/")
+}
+
+// Server serves the Git webhooks.
+type Server struct {
+	Addr    string
+	Handler http.Handler
+}
+
+// Start serves the Git webhooks until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			glog.Error(err, "cannot shutdown the Git webhook server")
+		}
+	}()
+
+	glog.Infof("Serving the Git webhooks on %s", s.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -143,8 +143,9 @@ func (action *buildAction) createBuild(ctx context.Context, it *v1.Integration)
 			Annotations: annotations,
 		},
 		Spec: v1.BuildSpec{
-			Tasks:   env.Pipeline,
-			Timeout: timeout,
+			Tasks:       env.Pipeline,
+			Timeout:     timeout,
+			RetryPolicy: env.BuildRetryPolicy,
 		},
 	}
 
@@ -216,6 +217,7 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, it *v1.Integr
 			return nil, fmt.Errorf("could not get a runnable artifact from Build due to %w", err)
 		}
 		it.Status.Jar = artifact.Target
+		it.Status.GitCommit = build.Status.GitCommit
 		// Address the image by repository digest instead of tag if possible
 		if build.Status.Digest != "" {
 			image := it.Status.Image
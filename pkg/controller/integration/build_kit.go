@@ -78,6 +78,26 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1.Integr
 		return integration, err
 	}
 
+	if len(existingKits) == 0 {
+		// Wait for the warm kits being built the integration can be built on top of, so that its kit is built
+		// incrementally rather than from scratch. The warm kits not building, e.g. waiting for their platform, are
+		// not waited for, as the integration would wait for them without bound.
+		warmKits, err := lookupWarmKitsForIntegration(ctx, action.client, integration)
+		if err != nil {
+			err = fmt.Errorf("failed to lookup warm kits for integration %s/%s: %w", integration.Namespace, integration.Name, err)
+			integration.Status.Phase = v1.IntegrationPhaseError
+			integration.SetReadyConditionError(err.Error())
+			return integration, err
+		}
+		for _, kit := range warmKits {
+			if kit.Status.Phase == v1.IntegrationKitPhaseBuildSubmitted || kit.Status.Phase == v1.IntegrationKitPhaseBuildRunning {
+				action.L.Info("Waiting for warm kit to be built", "integration", integration.Name,
+					"namespace", integration.Namespace, "integration kit", kit.Name)
+				return nil, nil
+			}
+		}
+	}
+
 	action.L.Debug("Applying traits to integration",
 		"integration", integration.Name,
 		"namespace", integration.Namespace)
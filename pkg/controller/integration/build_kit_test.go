@@ -356,3 +356,101 @@ func TestCamelBuildKitKitLookupNoMatchingKits(t *testing.T) {
 	assert.Equal(t, it.Namespace, newKit.Labels[kubernetes.CamelCreatorLabelNamespace])
 	assert.Equal(t, v1.IntegrationKitPhase(""), newKit.Status.Phase)
 }
+
+func TestCamelBuildKitWaitForBuildingWarmKit(t *testing.T) {
+	catalog := &v1.CamelCatalog{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.CamelCatalogKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "camel-k-catalog",
+		},
+		Spec: v1.CamelCatalogSpec{
+			Runtime: v1.RuntimeSpec{
+				Provider: v1.RuntimeProviderQuarkus,
+				Version:  defaults.DefaultRuntimeVersion,
+			},
+		},
+	}
+	ip := &v1.IntegrationPlatform{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationPlatformKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "camel-k",
+		},
+		Status: v1.IntegrationPlatformStatus{
+			IntegrationPlatformSpec: v1.IntegrationPlatformSpec{
+				Build: v1.IntegrationPlatformBuildSpec{
+					RuntimeVersion: defaults.DefaultRuntimeVersion,
+				},
+			},
+			Phase: v1.IntegrationPlatformPhaseReady,
+		},
+	}
+	warm := &v1.IntegrationKit{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKitKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "warm",
+			Labels: map[string]string{
+				v1.IntegrationKitTypeLabel:           v1.IntegrationKitTypePlatform,
+				v1.IntegrationKitWarmLabel:           "camel-k",
+				kubernetes.CamelLabelRuntimeVersion:  defaults.DefaultRuntimeVersion,
+				kubernetes.CamelLabelRuntimeProvider: string(v1.RuntimeProviderQuarkus),
+			},
+		},
+		Spec: v1.IntegrationKitSpec{
+			Dependencies: []string{"camel:timer"},
+		},
+	}
+	it := &v1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-it",
+		},
+		Status: v1.IntegrationStatus{
+			Phase:           v1.IntegrationPhaseBuildingKit,
+			RuntimeVersion:  defaults.DefaultRuntimeVersion,
+			RuntimeProvider: v1.RuntimeProviderQuarkus,
+			Dependencies:    []string{"camel:log", "camel:timer"},
+		},
+	}
+	hash, err := digest.ComputeForIntegration(it, nil, nil)
+	require.NoError(t, err)
+	it.Status.Digest = hash
+
+	a := buildKitAction{}
+	a.InjectLogger(log.Log)
+
+	// The warm kit being built is waited for
+	warm.Status.Phase = v1.IntegrationKitPhaseBuildRunning
+	c, err := internal.NewFakeClient(it.DeepCopy(), warm.DeepCopy(), ip, catalog)
+	require.NoError(t, err)
+	a.InjectClient(c)
+	handledIt, err := a.Handle(context.TODO(), it.DeepCopy())
+	require.NoError(t, err)
+	assert.Nil(t, handledIt)
+
+	// The warm kit not scheduled for build is not waited for
+	warm.Status.Phase = v1.IntegrationKitPhaseWaitingForPlatform
+	c, err = internal.NewFakeClient(it.DeepCopy(), warm.DeepCopy(), ip, catalog)
+	require.NoError(t, err)
+	a.InjectClient(c)
+	handledIt, err = a.Handle(context.TODO(), it.DeepCopy())
+	require.NoError(t, err)
+	require.NotNil(t, handledIt)
+	assert.Equal(t, v1.IntegrationPhaseBuildingKit, handledIt.Status.Phase)
+	assert.NotNil(t, handledIt.Status.IntegrationKit)
+}
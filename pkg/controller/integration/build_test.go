@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -66,6 +67,7 @@ func TestIntegrationBuildSubmitted(t *testing.T) {
 			IntegrationPlatformSpec: v1.IntegrationPlatformSpec{
 				Build: v1.IntegrationPlatformBuildSpec{
 					RuntimeVersion: defaults.DefaultRuntimeVersion,
+					RetryPolicy:    &v1.BuildRetryPolicy{MaxAttempts: ptr.To(2)},
 				},
 			},
 		},
@@ -120,6 +122,8 @@ func TestIntegrationBuildSubmitted(t *testing.T) {
 	require.NotNil(t, expectedBuild.OwnerReferences[0], "The Build should have an Integration owner reference")
 	assert.Equal(t, "Integration", expectedBuild.OwnerReferences[0].Kind)
 	assert.Equal(t, "my-it", expectedBuild.OwnerReferences[0].Name)
+	require.NotNil(t, expectedBuild.Spec.RetryPolicy, "The Build should have the retry policy of the platform")
+	assert.Equal(t, ptr.To(2), expectedBuild.Spec.RetryPolicy.MaxAttempts)
 }
 
 func TestIntegrationBuildRunningBuildMissing(t *testing.T) {
@@ -190,7 +194,8 @@ func TestIntegrationBuildRunningBuildSucceeded(t *testing.T) {
 					Target: "/deploy/my.jar",
 				},
 			},
-			Digest: "123456",
+			Digest:    "123456",
+			GitCommit: "4bb9ed2e49fbe8d64fba1a3b2a8e1b7bc0e6dd0a",
 		},
 	}
 	c, err := internal.NewFakeClient(it, build)
@@ -207,6 +212,7 @@ func TestIntegrationBuildRunningBuildSucceeded(t *testing.T) {
 	assert.Equal(t, v1.IntegrationPhaseDeploying, handledIt.Status.Phase)
 	assert.Equal(t, "my-image@123456", handledIt.Status.Image)
 	assert.Equal(t, "/deploy/my.jar", handledIt.Status.Jar)
+	assert.Equal(t, "4bb9ed2e49fbe8d64fba1a3b2a8e1b7bc0e6dd0a", handledIt.Status.GitCommit)
 }
 
 func TestIntegrationBuildRunningBuildError(t *testing.T) {
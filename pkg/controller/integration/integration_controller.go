@@ -159,6 +159,14 @@ func integrationKitEnqueueRequestsFromMapFunc(ctx context.Context, c client.Clie
 			Log.ForIntegration(integration).Errorf(err, "Error matching integration %q with kit %q", integration.Name, kit.Name)
 			continue
 		}
+		if !match && integration.Status.IntegrationKit == nil && integration.Status.Phase == v1.IntegrationPhaseBuildingKit {
+			// The integration may be waiting for the warm kit to be built
+			match, err = warmKitMatches(ctx, c, integration, kit)
+			if err != nil {
+				Log.ForIntegration(integration).Errorf(err, "Error matching integration %q with warm kit %q", integration.Name, kit.Name)
+				continue
+			}
+		}
 		if !match {
 			continue
 		}
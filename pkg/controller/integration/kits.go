@@ -81,6 +81,86 @@ func lookupKitsForIntegration(ctx context.Context, c client.Client, integration
 	return kits, nil
 }
 
+// lookupWarmKitsForIntegration returns the warm kits the v1.Integration can be built incrementally on top of, i.e.
+// the ones declared by its platform or profile whose dependencies are a subset of the ones of the v1.Integration.
+// The kits that failed to build are skipped.
+func lookupWarmKitsForIntegration(ctx context.Context, c client.Client, integration *v1.Integration) ([]v1.IntegrationKit, error) {
+	pl, err := platform.GetForResource(ctx, c, integration)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	warm, err := labels.NewRequirement(v1.IntegrationKitWarmLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	list := v1.NewIntegrationKitList()
+	if err := c.List(ctx, &list,
+		ctrl.InNamespace(integration.GetIntegrationKitNamespace(pl)),
+		ctrl.MatchingLabels{
+			v1.IntegrationKitTypeLabel:           v1.IntegrationKitTypePlatform,
+			kubernetes.CamelLabelRuntimeVersion:  integration.Status.RuntimeVersion,
+			kubernetes.CamelLabelRuntimeProvider: string(integration.Status.RuntimeProvider),
+		},
+		ctrl.MatchingLabelsSelector{
+			Selector: labels.NewSelector().Add(*warm),
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	kits := make([]v1.IntegrationKit, 0)
+	for i := range list.Items {
+		kit := &list.Items[i]
+		if kit.Status.Phase == v1.IntegrationKitPhaseError {
+			continue
+		}
+		match, err := warmKitMatches(ctx, c, integration, kit)
+		if err != nil {
+			return nil, err
+		} else if match {
+			kits = append(kits, *kit)
+		}
+	}
+
+	return kits, nil
+}
+
+// warmKitMatches returns whether the warm v1.IntegrationKit can be the base of the incremental build of the
+// v1.Integration, i.e. whether the v1.Integration requires all its dependencies and traits.
+func warmKitMatches(ctx context.Context, c client.Client, integration *v1.Integration, kit *v1.IntegrationKit) (bool, error) {
+	if _, ok := kit.Labels[v1.IntegrationKitWarmLabel]; !ok {
+		return false, nil
+	}
+	if kit.Labels[kubernetes.CamelLabelRuntimeVersion] != integration.Status.RuntimeVersion ||
+		kit.Labels[kubernetes.CamelLabelRuntimeProvider] != string(integration.Status.RuntimeProvider) {
+		return false, nil
+	}
+	if !util.StringSliceContains(integration.Status.Dependencies, kit.Spec.Dependencies) {
+		return false, nil
+	}
+
+	pl, err := platform.GetForResource(ctx, c, integration)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	itp, err := platform.ApplyIntegrationProfile(ctx, c, integration)
+	if err != nil {
+		return false, err
+	}
+	itc, err := trait.NewSpecTraitsOptionsForIntegrationAndPlatform(c, integration, itp, pl)
+	if err != nil {
+		return false, err
+	}
+	ikc, err := trait.NewSpecTraitsOptionsForIntegrationKit(c, kit)
+	if err != nil {
+		return false, err
+	}
+
+	return trait.HasMatchingTraits(itc, ikc)
+}
+
 // sameOrMatch returns whether the v1.IntegrationKit is the one used by the v1.Integration or if it meets the
 // requirements of the v1.Integration.
 func sameOrMatch(ctx context.Context, c client.Client, kit *v1.IntegrationKit, integration *v1.Integration) (bool, error) {
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/camel-k/v2/pkg/client"
 
 	"github.com/apache/camel-k/v2/pkg/trait"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 
 	"github.com/apache/camel-k/v2/pkg/internal"
 	"github.com/stretchr/testify/assert"
@@ -446,3 +447,78 @@ func integrationAndKitHaveSameTraits(c client.Client, i1 *v1.Integration, i2 *v1
 
 	return trait.Equals(ikOpts, itOpts), nil
 }
+
+func TestLookupWarmKitsForIntegration(t *testing.T) {
+	newWarmKit := func(name string, phase v1.IntegrationKitPhase, dependencies ...string) *v1.IntegrationKit {
+		return &v1.IntegrationKit{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationKitKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Labels: map[string]string{
+					v1.IntegrationKitTypeLabel:           v1.IntegrationKitTypePlatform,
+					v1.IntegrationKitWarmLabel:           "camel-k",
+					kubernetes.CamelLabelRuntimeVersion:  "1.0.0",
+					kubernetes.CamelLabelRuntimeProvider: string(v1.RuntimeProviderQuarkus),
+				},
+			},
+			Spec: v1.IntegrationKitSpec{
+				Dependencies: dependencies,
+			},
+			Status: v1.IntegrationKitStatus{
+				Phase: phase,
+			},
+		}
+	}
+	notWarm := newWarmKit("not-warm", v1.IntegrationKitPhaseReady, "camel:kafka")
+	delete(notWarm.Labels, v1.IntegrationKitWarmLabel)
+
+	c, err := internal.NewFakeClient(
+		&v1.IntegrationPlatform{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1.SchemeGroupVersion.String(),
+				Kind:       v1.IntegrationPlatformKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "camel-k",
+			},
+		},
+		newWarmKit("kafka", v1.IntegrationKitPhaseBuildRunning, "camel:kafka"),
+		newWarmKit("kafka-http", v1.IntegrationKitPhaseReady, "camel:kafka", "camel:http"),
+		newWarmKit("jms", v1.IntegrationKitPhaseReady, "camel:jms"),
+		newWarmKit("failed", v1.IntegrationKitPhaseError, "camel:kafka"),
+		notWarm,
+	)
+	require.NoError(t, err)
+
+	kits, err := lookupWarmKitsForIntegration(context.TODO(), c, &v1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Status: v1.IntegrationStatus{
+			RuntimeVersion:  "1.0.0",
+			RuntimeProvider: v1.RuntimeProviderQuarkus,
+			Dependencies: []string{
+				"camel:http",
+				"camel:kafka",
+				"camel:timer",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(kits))
+	for _, kit := range kits {
+		names = append(names, kit.Name)
+	}
+	assert.ElementsMatch(t, []string{"kafka", "kafka-http"}, names)
+}
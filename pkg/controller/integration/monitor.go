@@ -63,7 +63,8 @@ func (action *monitorAction) Name() string {
 func (action *monitorAction) CanHandle(integration *v1.Integration) bool {
 	return integration.Status.Phase == v1.IntegrationPhaseDeploying ||
 		integration.Status.Phase == v1.IntegrationPhaseRunning ||
-		integration.Status.Phase == v1.IntegrationPhaseError
+		integration.Status.Phase == v1.IntegrationPhaseError ||
+		integration.Status.Phase == v1.IntegrationPhaseSuspended
 }
 
 //nolint:nestif
@@ -241,8 +242,20 @@ func (action *monitorAction) monitorPods(ctx context.Context, environment *trait
 	}
 	integration.Status.Replicas = replicas
 
+	// A suspended Integration is scaled down to zero (or its CronJob is suspended) by the deployer traits,
+	// so there is no readiness to check until it's resumed
+	if v1.IsSuspended(integration) {
+		message := fmt.Sprintf("%s is suspended", controller.getControllerName())
+		if podCount > 0 {
+			message = fmt.Sprintf("%s is suspended, %d pods still running", controller.getControllerName(), podCount)
+		}
+		integration.Status.Phase = v1.IntegrationPhaseSuspended
+		integration.SetReadyCondition(corev1.ConditionFalse, v1.IntegrationConditionSuspendedReason, message)
+		return integration, nil
+	}
+
 	// Reconcile Integration phase and ready condition
-	if integration.Status.Phase == v1.IntegrationPhaseDeploying {
+	if integration.Status.Phase == v1.IntegrationPhaseDeploying || integration.Status.Phase == v1.IntegrationPhaseSuspended {
 		integration.Status.Phase = v1.IntegrationPhaseRunning
 	}
 	if err = action.updateIntegrationPhaseAndReadyCondition(
@@ -108,6 +108,33 @@ func TestMonitorIntegration(t *testing.T) {
 	assert.Equal(t, v1.IntegrationConditionDeploymentReadyReason, handledIt.Status.GetCondition(v1.IntegrationConditionReady).Reason)
 }
 
+func TestMonitorSuspendedIntegration(t *testing.T) {
+	c, it, err := nominalEnvironment()
+	require.NoError(t, err)
+	v1.SetAnnotation(&it.ObjectMeta, v1.SuspendedReplicasAnnotation, "")
+	it.Spec.Replicas = ptr.To(int32(0))
+
+	a := monitorAction{}
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+	handledIt, err := a.Handle(context.TODO(), it)
+	require.NoError(t, err)
+	assert.Equal(t, v1.IntegrationPhaseSuspended, handledIt.Status.Phase)
+	// The pod is still terminating
+	assert.Equal(t, int32(1), *handledIt.Status.Replicas)
+	assert.Equal(t, corev1.ConditionFalse, handledIt.Status.GetCondition(v1.IntegrationConditionReady).Status)
+	assert.Equal(t, v1.IntegrationConditionSuspendedReason, handledIt.Status.GetCondition(v1.IntegrationConditionReady).Reason)
+
+	// Resume
+	delete(handledIt.Annotations, v1.SuspendedReplicasAnnotation)
+	handledIt.Spec.Replicas = nil
+	assert.True(t, a.CanHandle(handledIt))
+	handledIt, err = a.Handle(context.TODO(), handledIt)
+	require.NoError(t, err)
+	assert.Equal(t, v1.IntegrationPhaseRunning, handledIt.Status.Phase)
+	assert.Equal(t, corev1.ConditionTrue, handledIt.Status.GetCondition(v1.IntegrationConditionReady).Status)
+}
+
 func TestMonitorFailureIntegration(t *testing.T) {
 	c, it, err := nominalEnvironment()
 	require.NoError(t, err)
@@ -173,8 +173,9 @@ func (action *buildAction) createBuild(ctx context.Context, kit *v1.IntegrationK
 			Annotations: annotations,
 		},
 		Spec: v1.BuildSpec{
-			Tasks:   env.Pipeline,
-			Timeout: timeout,
+			Tasks:       env.Pipeline,
+			Timeout:     timeout,
+			RetryPolicy: env.BuildRetryPolicy,
 		},
 	}
 
@@ -245,6 +246,8 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1.Integ
 				Checksum: a.Checksum,
 			})
 		}
+		kit.Status.DependencyTree = build.Status.DependencyTree
+		kit.Status.Provenance = build.Status.Provenance
 
 		return kit, err
 	case v1.BuildPhaseError, v1.BuildPhaseInterrupted:
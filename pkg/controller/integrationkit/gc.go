@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationkit
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/platform"
+)
+
+const (
+	// GCIntervalEnvVariable is the environment variable of the operator setting the interval of the garbage collection
+	// of the kits, e.g. 30m. The garbage collection is disabled when set to 0.
+	GCIntervalEnvVariable = "CAMEL_K_KIT_GC_INTERVAL"
+	// DefaultGCInterval is the default interval of the garbage collection of the kits.
+	DefaultGCInterval = 10 * time.Minute
+)
+
+// GarbageCollector periodically collects the unused kits of the namespaces whose platform sets a kit retention policy.
+type GarbageCollector struct {
+	Client   client.Client
+	Interval time.Duration
+	// Namespaces are the namespaces watched by the operator, all the namespaces when empty.
+	Namespaces []string
+}
+
+// Start collects the unused kits at each interval until the context is done.
+func (gc *GarbageCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(gc.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			gc.collect(ctx)
+		}
+	}
+}
+
+// collect collects the unused kits of the watched namespaces having kits of the operator.
+func (gc *GarbageCollector) collect(ctx context.Context) {
+	kits, err := gc.kits(ctx)
+	if err != nil {
+		Log.Error(err, "cannot list the kits to garbage collect")
+		return
+	}
+
+	namespaces := make(map[string]bool)
+	for _, kit := range kits {
+		if platform.IsOperatorHandler(&kit) {
+			namespaces[kit.Namespace] = true
+		}
+	}
+	for namespace := range namespaces {
+		pl, err := platform.GetForName(ctx, gc.Client, namespace, "")
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				Log.Error(err, "cannot get the platform of namespace "+namespace)
+			}
+			continue
+		}
+		if !platform.IsOperatorHandler(pl) || pl.Status.Build.KitRetention == nil {
+			continue
+		}
+		collected, err := platform.PruneKits(ctx, gc.Client, pl, pl.Status.Build.KitRetention, namespace, false)
+		if err != nil {
+			Log.Error(err, "cannot garbage collect the kits of namespace "+namespace)
+		}
+		if len(collected) > 0 {
+			Log.Infof("Garbage collected %d unused kits in namespace %s", len(collected), namespace)
+		}
+	}
+}
+
+// kits lists the kits of the watched namespaces.
+func (gc *GarbageCollector) kits(ctx context.Context) ([]v1.IntegrationKit, error) {
+	if len(gc.Namespaces) == 0 {
+		kits := v1.NewIntegrationKitList()
+		if err := gc.Client.List(ctx, &kits); err != nil {
+			return nil, err
+		}
+		return kits.Items, nil
+	}
+
+	var items []v1.IntegrationKit
+	for _, ns := range gc.Namespaces {
+		kits := v1.NewIntegrationKitList()
+		if err := gc.Client.List(ctx, &kits, ctrl.InNamespace(ns)); err != nil {
+			return nil, err
+		}
+		items = append(items, kits.Items...)
+	}
+
+	return items, nil
+}
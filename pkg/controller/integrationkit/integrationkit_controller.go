@@ -124,7 +124,7 @@ func add(_ context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for IntegrationPlatform phase transitioning to ready and enqueue
+	// Watch for IntegrationPlatform phase transitioning to ready or warming and enqueue
 	// requests for any integration kits that are in phase waiting for platform
 	err = c.Watch(
 		source.Kind(
@@ -132,7 +132,7 @@ func add(_ context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 			&v1.IntegrationPlatform{},
 			handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, itp *v1.IntegrationPlatform) []reconcile.Request {
 				var requests []reconcile.Request
-				if itp.Status.Phase == v1.IntegrationPlatformPhaseReady {
+				if itp.Status.Phase == v1.IntegrationPlatformPhaseReady || itp.Status.Phase == v1.IntegrationPlatformPhaseWarming {
 					list := &v1.IntegrationKitList{}
 					if err := mgr.GetClient().List(ctx, list, ctrl.InNamespace(itp.Namespace)); err != nil {
 						log.Error(err, "Failed to list integration kits")
@@ -232,9 +232,10 @@ func (r *reconcileIntegrationKit) Reconcile(ctx context.Context, request reconci
 			return r.update(ctx, &instance, target)
 		}
 
-		// Platform is always local to the kit
+		// Platform is always local to the kit. The kits are also built while it is warming, so that its warm kits
+		// can be built before the Integrations.
 		pl, err := platform.GetForResource(ctx, r.client, target)
-		if err != nil || pl.Status.Phase != v1.IntegrationPlatformPhaseReady {
+		if err != nil || pl.Status.Phase != v1.IntegrationPlatformPhaseReady && pl.Status.Phase != v1.IntegrationPlatformPhaseWarming {
 			target.Status.Phase = v1.IntegrationKitPhaseWaitingForPlatform
 		} else {
 			target.Status.Phase = v1.IntegrationKitPhaseInitialization
@@ -72,6 +72,12 @@ func (action *createAction) Handle(ctx context.Context, platform *v1.Integration
 	}
 
 	platform.Status.Phase = v1.IntegrationPlatformPhaseReady
+	// Build the warm kits, if any, before the Integrations
+	if warm, err := hasWarmKits(ctx, action.client, platform); err != nil {
+		return nil, err
+	} else if warm {
+		platform.Status.Phase = v1.IntegrationPlatformPhaseWarming
+	}
 	platform.Status.SetCondition(
 		v1.IntegrationPlatformConditionCamelCatalogAvailable,
 		corev1.ConditionTrue,
@@ -139,7 +145,7 @@ func (action *createAction) handleNewCatalog(ctx context.Context, platform *v1.I
 	var camelVersion string
 	if catalog == nil {
 		cat, err := camel.CreateCatalog(
-			ctx, action.client, platform.Namespace, platform.Status.Build.Maven,
+			ctx, action.client, platform.Namespace, platform.Status.Build.Maven, platform.Status.Build.Registry,
 			platform.Status.Build.GetTimeout().Duration, runtimeSpec, nil)
 		if err != nil {
 			action.L.Error(err, "IntegrationPlatform unable to create Camel catalog",
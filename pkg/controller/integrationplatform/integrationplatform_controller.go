@@ -151,6 +151,7 @@ func (r *reconcileIntegrationPlatform) Reconcile(ctx context.Context, request re
 	actions := []Action{
 		NewInitializeAction(),
 		NewCreateAction(),
+		NewWarmAction(),
 		NewMonitorAction(),
 	}
 
@@ -204,5 +205,10 @@ func (r *reconcileIntegrationPlatform) Reconcile(ctx context.Context, request re
 		break
 	}
 
+	if targetPhase == v1.IntegrationPlatformPhaseWarming {
+		// Poll the warm kits until they are built
+		return reconcile.Result{RequeueAfter: warmKitsRequeueAfter}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
@@ -19,12 +19,14 @@ package integrationplatform
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	platformutil "github.com/apache/camel-k/v2/pkg/platform"
 	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/openshift"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -98,6 +100,13 @@ func (action *monitorAction) Handle(ctx context.Context, platform *v1.Integratio
 			corev1.ConditionTrue,
 			"PublishingStrategyDeprecationNoticeReason",
 			"S2I publishing strategy is deprecated and may be removed in the future, use Jib strategy instead")
+	} else if platform.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyOCILayout &&
+		platform.Status.Build.Registry.Address == "" {
+		platform.Status.SetCondition(
+			v1.IntegrationPlatformConditionTypeRegistryAvailable,
+			corev1.ConditionFalse,
+			v1.IntegrationPlatformConditionTypeRegistryAvailableReason,
+			"registry not required because images are written to an OCI image layout")
 	} else {
 		if platform.Status.Build.Registry.Address == "" {
 			// error, we need a registry if we're not on Openshift
@@ -128,6 +137,9 @@ func (action *monitorAction) Handle(ctx context.Context, platform *v1.Integratio
 	}
 	action.checkTraitAnnotationsDeprecatedNotice(platform)
 	action.checkMavenSettings(platform)
+	action.checkMavenCache(ctx, platform)
+	action.checkOCILayout(ctx, platform)
+	action.checkWarmKits(ctx, platform)
 	if err = action.addPlainQuarkusCatalog(ctx, catalog); err != nil {
 		// Only warn the user, we don't want to fail
 		action.L.Infof(
@@ -184,6 +196,98 @@ func (action *monitorAction) checkMavenSettings(platform *v1.IntegrationPlatform
 	}
 }
 
+// checkMavenCache creates the PersistentVolumeClaim of the persistent Maven cache, if configured and missing.
+func (action *monitorAction) checkMavenCache(ctx context.Context, platform *v1.IntegrationPlatform) {
+	cache := platform.Status.Build.Maven.Cache
+	if cache == nil {
+		platform.Status.RemoveCondition(v1.IntegrationPlatformConditionMavenCacheAvailable)
+		return
+	}
+	if err := action.createMavenCache(ctx, platform.Namespace, cache); err != nil {
+		platform.Status.SetCondition(
+			v1.IntegrationPlatformConditionMavenCacheAvailable,
+			corev1.ConditionFalse,
+			"MavenCacheAvailable",
+			fmt.Sprintf("Maven cache %s is not available: %s", cache.PersistentVolumeClaim, err.Error()),
+		)
+		action.L.Infof("WARN: Maven cache %s is not available for platform %s: %s",
+			cache.PersistentVolumeClaim, platform.Name, err.Error())
+		return
+	}
+	platform.Status.SetCondition(
+		v1.IntegrationPlatformConditionMavenCacheAvailable,
+		corev1.ConditionTrue,
+		"MavenCacheAvailable",
+		fmt.Sprintf("Maven cache available in PersistentVolumeClaim %s.", cache.PersistentVolumeClaim),
+	)
+}
+
+func (action *monitorAction) createMavenCache(ctx context.Context, namespace string, cache *v1.MavenCacheSpec) error {
+	if cache.PersistentVolumeClaim == "" {
+		return errors.New("the name of the PersistentVolumeClaim is missing")
+	}
+	pvc, err := kubernetes.LookupPersistentVolumeClaim(ctx, action.client, namespace, cache.PersistentVolumeClaim)
+	if err != nil || pvc != nil {
+		return err
+	}
+	size, err := cache.GetSize()
+	if err != nil {
+		return err
+	}
+	if _, err := cache.GetMaxSize(); err != nil {
+		return err
+	}
+	// The cache is mounted by the builder pods running concurrently, possibly on different nodes
+	pvc = kubernetes.NewPersistentVolumeClaim(namespace, cache.PersistentVolumeClaim, cache.StorageClass, size,
+		corev1.ReadWriteMany)
+	if cache.StorageClass == "" {
+		pvc.Spec.StorageClassName = nil
+	}
+	action.L.Infof("Creating PersistentVolumeClaim %s for the Maven cache", cache.PersistentVolumeClaim)
+
+	return action.client.Create(ctx, pvc)
+}
+
+// checkWarmKits creates the warm kits declared since the platform is ready.
+func (action *monitorAction) checkWarmKits(ctx context.Context, platform *v1.IntegrationPlatform) {
+	if _, err := reconcileWarmKits(ctx, action.client, platform); err != nil {
+		action.L.Infof("WARN: the warm kits of platform %s cannot be created: %s", platform.Name, err.Error())
+	}
+}
+
+// checkOCILayout checks the PersistentVolumeClaim the OCILayout publish strategy writes the images to.
+func (action *monitorAction) checkOCILayout(ctx context.Context, platform *v1.IntegrationPlatform) {
+	if platform.Status.Build.PublishStrategy != v1.IntegrationPlatformBuildPublishStrategyOCILayout {
+		platform.Status.RemoveCondition(v1.IntegrationPlatformConditionOCILayoutAvailable)
+		return
+	}
+	ociLayout := platform.Status.Build.OCILayout
+	message := ""
+	if ociLayout == nil || ociLayout.PersistentVolumeClaim == "" {
+		message = "the OCI image layout PersistentVolumeClaim is not configured"
+	} else if pvc, err := kubernetes.LookupPersistentVolumeClaim(ctx, action.client, platform.Namespace, ociLayout.PersistentVolumeClaim); err != nil {
+		message = err.Error()
+	} else if pvc == nil {
+		message = fmt.Sprintf("PersistentVolumeClaim %s not found", ociLayout.PersistentVolumeClaim)
+	}
+	if message != "" {
+		platform.Status.SetCondition(
+			v1.IntegrationPlatformConditionOCILayoutAvailable,
+			corev1.ConditionFalse,
+			"OCILayoutAvailable",
+			fmt.Sprintf("OCI image layout is not available: %s", message),
+		)
+		action.L.Infof("WARN: OCI image layout is not available for platform %s: %s", platform.Name, message)
+		return
+	}
+	platform.Status.SetCondition(
+		v1.IntegrationPlatformConditionOCILayoutAvailable,
+		corev1.ConditionTrue,
+		"OCILayoutAvailable",
+		fmt.Sprintf("OCI image layout available in PersistentVolumeClaim %s.", ociLayout.PersistentVolumeClaim),
+	)
+}
+
 func specOrDefault(runtimeVersionSpec string) string {
 	if runtimeVersionSpec == "" {
 		return defaults.DefaultRuntimeVersion
@@ -26,11 +26,13 @@ import (
 	"github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
 	"github.com/apache/camel-k/v2/pkg/internal"
 	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestCanHandlePhaseReadyOrError(t *testing.T) {
@@ -324,3 +326,88 @@ func TestMonitorWarningInsecureRegistry(t *testing.T) {
 		answer.Status.GetCondition(
 			v1.IntegrationPlatformConditionType("InsecureRegistryWarning")).Message)
 }
+
+func TestMonitorMavenCache(t *testing.T) {
+	catalog := v1.NewCamelCatalog("ns", fmt.Sprintf("camel-catalog-%s", "1.2.3"))
+	catalog.Spec.Runtime.Version = "1.2.3"
+	catalog.Spec.Runtime.Provider = v1.RuntimeProviderQuarkus
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "ns"
+	ip.Name = "ck"
+	ip.Spec.Build.Registry.Address = "1.2.3.4"
+	ip.Spec.Build.RuntimeVersion = "1.2.3"
+	ip.Spec.Build.RuntimeProvider = v1.RuntimeProviderQuarkus
+	ip.Spec.Build.Maven.Cache = &v1.MavenCacheSpec{
+		PersistentVolumeClaim: "maven-cache",
+		Size:                  "20Gi",
+	}
+	ip.Status.Build.RuntimeVersion = "1.2.3"
+	ip.Status.Build.RuntimeProvider = v1.RuntimeProviderQuarkus
+	ip.Status.Build.Registry.Address = "1.2.3.4"
+	ip.Status.Phase = v1.IntegrationPlatformPhaseReady
+	c, err := internal.NewFakeClient(&ip, &catalog)
+	require.NoError(t, err)
+
+	action := NewMonitorAction()
+	action.InjectLogger(log.Log)
+	action.InjectClient(c)
+
+	answer, err := action.Handle(context.TODO(), &ip)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionTrue,
+		answer.Status.GetCondition(v1.IntegrationPlatformConditionMavenCacheAvailable).Status)
+
+	pvc, err := kubernetes.LookupPersistentVolumeClaim(context.TODO(), c, "ns", "maven-cache")
+	require.NoError(t, err)
+	require.NotNil(t, pvc)
+	assert.Nil(t, pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, "20Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	ip.Spec.Build.Maven.Cache.MaxSize = "invalid"
+	pvcs := corev1.PersistentVolumeClaimList{}
+	require.NoError(t, c.List(context.TODO(), &pvcs))
+	require.NoError(t, c.Delete(context.TODO(), &pvcs.Items[0]))
+	answer, err = action.Handle(context.TODO(), &ip)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionFalse,
+		answer.Status.GetCondition(v1.IntegrationPlatformConditionMavenCacheAvailable).Status)
+}
+
+func TestMonitorOCILayout(t *testing.T) {
+	catalog := v1.NewCamelCatalog("ns", fmt.Sprintf("camel-catalog-%s", "1.2.3"))
+	catalog.Spec.Runtime.Version = "1.2.3"
+	catalog.Spec.Runtime.Provider = v1.RuntimeProviderQuarkus
+	ip := v1.IntegrationPlatform{}
+	ip.Namespace = "ns"
+	ip.Name = "ck"
+	ip.Spec.Build.PublishStrategy = v1.IntegrationPlatformBuildPublishStrategyOCILayout
+	ip.Spec.Build.OCILayout = &v1.OCILayoutSpec{PersistentVolumeClaim: "oci-layout"}
+	ip.Spec.Build.RuntimeVersion = "1.2.3"
+	ip.Spec.Build.RuntimeProvider = v1.RuntimeProviderQuarkus
+	ip.Status.Build.RuntimeVersion = "1.2.3"
+	ip.Status.Build.RuntimeProvider = v1.RuntimeProviderQuarkus
+	ip.Status.Phase = v1.IntegrationPlatformPhaseReady
+	c, err := internal.NewFakeClient(&ip, &catalog)
+	require.NoError(t, err)
+
+	action := NewMonitorAction()
+	action.InjectLogger(log.Log)
+	action.InjectClient(c)
+
+	// No registry is required, but the volume is missing
+	answer, err := action.Handle(context.TODO(), &ip)
+	require.NoError(t, err)
+	assert.Equal(t, v1.IntegrationPlatformPhaseReady, answer.Status.Phase)
+	assert.Equal(t, corev1.ConditionFalse,
+		answer.Status.GetCondition(v1.IntegrationPlatformConditionTypeRegistryAvailable).Status)
+	assert.Equal(t, corev1.ConditionFalse,
+		answer.Status.GetCondition(v1.IntegrationPlatformConditionOCILayoutAvailable).Status)
+
+	pvc := kubernetes.NewPersistentVolumeClaim("ns", "oci-layout", "", resource.MustParse("1Gi"), corev1.ReadWriteOnce)
+	require.NoError(t, c.Create(context.TODO(), pvc))
+	answer, err = action.Handle(context.TODO(), &ip)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ConditionTrue,
+		answer.Status.GetCondition(v1.IntegrationPlatformConditionOCILayoutAvailable).Status)
+}
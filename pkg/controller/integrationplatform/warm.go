@@ -0,0 +1,319 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/camel"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+)
+
+// warmKitsRequeueAfter is the interval the warm kits are checked at while the platform is warming.
+const warmKitsRequeueAfter = 10 * time.Second
+
+// NewWarmAction returns the action that builds the warm kits of the platform before it is ready.
+func NewWarmAction() Action {
+	return &warmAction{}
+}
+
+type warmAction struct {
+	baseAction
+}
+
+func (action *warmAction) Name() string {
+	return "warm"
+}
+
+func (action *warmAction) CanHandle(platform *v1.IntegrationPlatform) bool {
+	return platform.Status.Phase == v1.IntegrationPlatformPhaseWarming
+}
+
+func (action *warmAction) Handle(ctx context.Context, platform *v1.IntegrationPlatform) (*v1.IntegrationPlatform, error) {
+	ready, err := reconcileWarmKits(ctx, action.client, platform)
+	if err != nil {
+		return nil, err
+	}
+	if ready {
+		action.L.Info("IntegrationPlatform warm kits built")
+		platform.Status.Phase = v1.IntegrationPlatformPhaseReady
+	}
+
+	return platform, nil
+}
+
+// hasWarmKits returns whether the platform, or any IntegrationProfile of its namespace, declares warm kits.
+func hasWarmKits(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform) (bool, error) {
+	if len(platform.Status.Build.WarmKits) > 0 {
+		return true, nil
+	}
+	profiles := v1.IntegrationProfileList{}
+	if err := c.List(ctx, &profiles, ctrl.InNamespace(platform.Namespace)); err != nil {
+		return false, err
+	}
+	for _, profile := range profiles.Items {
+		if len(profile.Spec.Build.WarmKits) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// reconcileWarmKits creates the missing warm kits declared by the platform and by the IntegrationProfiles of its
+// namespace, recreates the ones whose declaration or runtime has changed, deletes the ones no longer declared, and
+// returns whether they are all built, successfully or not.
+func reconcileWarmKits(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform) (bool, error) {
+	kits, owners, err := newWarmKits(ctx, c, platform)
+	if err != nil {
+		return false, err
+	}
+	if err := deleteUndeclaredWarmKits(ctx, c, platform.Namespace, kits, owners); err != nil {
+		return false, err
+	}
+	if len(kits) == 0 {
+		platform.Status.RemoveCondition(v1.IntegrationPlatformConditionWarmKitsReady)
+		return true, nil
+	}
+
+	built, failed := 0, 0
+	for _, kit := range kits {
+		existing, err := kubernetes.GetIntegrationKit(ctx, c, kit.Name, kit.Namespace)
+		if k8serrors.IsNotFound(err) {
+			if err := c.Create(ctx, kit); err != nil && !k8serrors.IsAlreadyExists(err) {
+				return false, fmt.Errorf("cannot create warm kit %s: %w", kit.Name, err)
+			}
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if existing.DeletionTimestamp != nil {
+			continue
+		}
+		if warmKitDrifted(existing, kit) {
+			Log.Infof("Recreating warm kit %s/%s, whose dependencies or runtime have changed", kit.Namespace, kit.Name)
+			if err := c.Delete(ctx, existing); err != nil && !k8serrors.IsNotFound(err) {
+				return false, fmt.Errorf("cannot delete warm kit %s: %w", kit.Name, err)
+			}
+			// The kit is created once the previous one is deleted, if not now
+			if err := c.Create(ctx, kit); err != nil && !k8serrors.IsAlreadyExists(err) {
+				return false, fmt.Errorf("cannot create warm kit %s: %w", kit.Name, err)
+			}
+			continue
+		}
+		switch existing.Status.Phase {
+		case v1.IntegrationKitPhaseReady:
+			built++
+		case v1.IntegrationKitPhaseError:
+			built++
+			failed++
+		}
+	}
+
+	ready := built == len(kits)
+	status := corev1.ConditionFalse
+	message := fmt.Sprintf("%d of %d warm kits built", built, len(kits))
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	if failed > 0 {
+		message += fmt.Sprintf(", %d failed", failed)
+	}
+	platform.Status.SetCondition(v1.IntegrationPlatformConditionWarmKitsReady, status, "WarmKitsReady", message)
+
+	return ready, nil
+}
+
+// warmKitDrifted returns whether the existing warm kit differs from its declaration, i.e. has other dependencies or
+// another runtime.
+func warmKitDrifted(existing *v1.IntegrationKit, kit *v1.IntegrationKit) bool {
+	for _, label := range []string{kubernetes.CamelLabelRuntimeVersion, kubernetes.CamelLabelRuntimeProvider} {
+		if existing.Labels[label] != kit.Labels[label] {
+			return true
+		}
+	}
+
+	return !slices.Equal(existing.Spec.Dependencies, kit.Spec.Dependencies)
+}
+
+// deleteUndeclaredWarmKits deletes the warm kits of the given owners that are no longer declared.
+func deleteUndeclaredWarmKits(ctx context.Context, c client.Client, namespace string, kits []*v1.IntegrationKit,
+	owners map[types.UID]bool) error {
+	declared := make(map[string]bool, len(kits))
+	for _, kit := range kits {
+		declared[kit.Name] = true
+	}
+
+	warm, err := labels.NewRequirement(v1.IntegrationKitWarmLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	list := v1.NewIntegrationKitList()
+	if err := c.List(ctx, &list, ctrl.InNamespace(namespace), ctrl.MatchingLabelsSelector{
+		Selector: labels.NewSelector().Add(*warm),
+	}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		kit := &list.Items[i]
+		if declared[kit.Name] || len(kit.OwnerReferences) == 0 || !owners[kit.OwnerReferences[0].UID] {
+			continue
+		}
+		if err := c.Delete(ctx, kit); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("cannot delete warm kit %s: %w", kit.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// newWarmKits returns the warm kits declared by the platform and by the IntegrationProfiles of its namespace, along
+// with the owners whose warm kits are all returned. The kits whose Camel catalog is not available yet are skipped.
+func newWarmKits(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform) ([]*v1.IntegrationKit,
+	map[types.UID]bool, error) {
+	runtime := v1.RuntimeSpec{
+		Version:  platform.Status.Build.RuntimeVersion,
+		Provider: platform.Status.Build.RuntimeProvider,
+	}
+	owner := metav1.OwnerReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationPlatformKind,
+		Name:       platform.Name,
+		UID:        platform.UID,
+	}
+	owners := make(map[types.UID]bool)
+	kits, err := newWarmKitsFor(ctx, c, platform, platform.Status.Build.WarmKits, runtime, owner, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kits != nil {
+		owners[owner.UID] = true
+	}
+
+	profiles := v1.IntegrationProfileList{}
+	if err := c.List(ctx, &profiles, ctrl.InNamespace(platform.Namespace)); err != nil {
+		return nil, nil, err
+	}
+	for _, profile := range profiles.Items {
+		profileRuntime := runtime
+		if profile.Spec.Build.RuntimeVersion != "" {
+			profileRuntime.Version = profile.Spec.Build.RuntimeVersion
+		}
+		if profile.Spec.Build.RuntimeProvider != "" {
+			profileRuntime.Provider = profile.Spec.Build.RuntimeProvider
+		}
+		owner := metav1.OwnerReference{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.IntegrationProfileKind,
+			Name:       profile.Name,
+			UID:        profile.UID,
+		}
+		profileKits, err := newWarmKitsFor(ctx, c, platform, profile.Spec.Build.WarmKits, profileRuntime, owner, &profile)
+		if err != nil {
+			return nil, nil, err
+		}
+		if profileKits != nil {
+			owners[owner.UID] = true
+		}
+		kits = append(kits, profileKits...)
+	}
+
+	return kits, owners, nil
+}
+
+// newWarmKitsFor returns the given warm kits, or nil if their Camel catalog is not available.
+func newWarmKitsFor(ctx context.Context, c client.Client, platform *v1.IntegrationPlatform, specs []v1.WarmKitSpec,
+	runtime v1.RuntimeSpec, owner metav1.OwnerReference, profile *v1.IntegrationProfile) ([]*v1.IntegrationKit, error) {
+	if len(specs) == 0 {
+		return []*v1.IntegrationKit{}, nil
+	}
+	catalog, err := loadCatalog(ctx, c, platform.Namespace, runtime)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, nil
+	}
+
+	kits := make([]*v1.IntegrationKit, 0, len(specs))
+	for _, spec := range specs {
+		kits = append(kits, newWarmKit(platform, spec, *catalog, owner, profile))
+	}
+
+	return kits, nil
+}
+
+// newWarmKit returns the kit of the given warm kit declaration, with the dependencies of the Camel K runtime, so that
+// it has as many libraries as possible in common with the Integrations it is the base of.
+func newWarmKit(platform *v1.IntegrationPlatform, spec v1.WarmKitSpec, catalog v1.CamelCatalog,
+	owner metav1.OwnerReference, profile *v1.IntegrationProfile) *v1.IntegrationKit {
+	dependencies := make([]string, 0, len(spec.Dependencies)+len(catalog.Spec.Runtime.Dependencies))
+	for _, dependency := range spec.Dependencies {
+		dependencies = append(dependencies, camel.NormalizeDependency(dependency))
+	}
+	for _, dependency := range catalog.Spec.Runtime.Dependencies {
+		dependencies = append(dependencies, dependency.GetDependencyID())
+	}
+	sort.Strings(dependencies)
+
+	name := spec.Name
+	if name == "" {
+		hash := sha256.Sum256([]byte(owner.Kind + "/" + owner.Name + ":" + strings.Join(dependencies, ",")))
+		name = "kit-warm-" + hex.EncodeToString(hash[:])[:12]
+	}
+
+	kit := v1.NewIntegrationKit(platform.Namespace, name)
+	kit.Labels = map[string]string{
+		v1.IntegrationKitTypeLabel:           v1.IntegrationKitTypePlatform,
+		v1.IntegrationKitWarmLabel:           owner.Name,
+		kubernetes.CamelLabelRuntimeVersion:  catalog.Spec.Runtime.Version,
+		kubernetes.CamelLabelRuntimeProvider: string(catalog.Spec.Runtime.Provider),
+		v1.IntegrationKitLayoutLabel:         v1.IntegrationKitLayoutFastJar,
+		v1.IntegrationKitPriorityLabel:       "1000",
+	}
+	v1.SetAnnotation(&kit.ObjectMeta, v1.PlatformSelectorAnnotation, platform.Name)
+	if profile != nil {
+		v1.SetAnnotation(&kit.ObjectMeta, v1.IntegrationProfileAnnotation, profile.Name)
+		v1.SetAnnotation(&kit.ObjectMeta, v1.IntegrationProfileNamespaceAnnotation, profile.Namespace)
+	}
+	if operatorID := defaults.OperatorID(); operatorID != "" {
+		kit.SetOperatorID(operatorID)
+	}
+	kit.OwnerReferences = []metav1.OwnerReference{owner}
+	kit.Spec.Dependencies = dependencies
+
+	return kit
+}
@@ -0,0 +1,197 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+	"github.com/apache/camel-k/v2/pkg/util/defaults"
+	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+)
+
+func newWarmTestPlatform(warmKits ...v1.WarmKitSpec) *v1.IntegrationPlatform {
+	ip := v1.NewIntegrationPlatform("ns", "ck")
+	ip.UID = "platform-uid"
+	ip.Status.Phase = v1.IntegrationPlatformPhaseWarming
+	ip.Status.Build.RuntimeProvider = v1.RuntimeProviderQuarkus
+	ip.Status.Build.RuntimeVersion = defaults.DefaultRuntimeVersion
+	ip.Status.Build.WarmKits = warmKits
+	return &ip
+}
+
+func newWarmTestCatalog() *v1.CamelCatalog {
+	catalog := v1.NewCamelCatalog("ns", "camel-catalog-"+defaults.DefaultRuntimeVersion)
+	catalog.Spec.Runtime.Version = defaults.DefaultRuntimeVersion
+	catalog.Spec.Runtime.Provider = v1.RuntimeProviderQuarkus
+	catalog.Spec.Runtime.Dependencies = []v1.MavenArtifact{
+		{GroupID: "org.apache.camel.k", ArtifactID: "camel-k-runtime"},
+	}
+	return &catalog
+}
+
+func TestCreateWithWarmKits(t *testing.T) {
+	ip := newWarmTestPlatform(v1.WarmKitSpec{Dependencies: []string{"camel:kafka"}})
+	ip.Status.Phase = v1.IntegrationPlatformPhaseCreating
+	catalog := newWarmTestCatalog()
+	catalog.Spec.Runtime.Metadata = map[string]string{"camel.version": "4.4.0"}
+	c, err := internal.NewFakeClient(ip, catalog)
+	require.NoError(t, err)
+
+	action := NewCreateAction()
+	action.InjectLogger(log.Log)
+	action.InjectClient(c)
+	// We don't want to test the installation procedure here
+	os.Setenv("KAMEL_INSTALL_DEFAULT_KAMELETS", "false")
+	answer, err := action.Handle(context.TODO(), ip)
+	require.NoError(t, err)
+	assert.Equal(t, v1.IntegrationPlatformPhaseWarming, answer.Status.Phase)
+}
+
+func TestWarmKits(t *testing.T) {
+	ip := newWarmTestPlatform(
+		v1.WarmKitSpec{Name: "kafka", Dependencies: []string{"camel-kafka", "camel:http"}},
+		v1.WarmKitSpec{Dependencies: []string{"camel:jms"}},
+	)
+	c, err := internal.NewFakeClient(ip, newWarmTestCatalog())
+	require.NoError(t, err)
+
+	action := NewWarmAction()
+	action.InjectLogger(log.Log)
+	action.InjectClient(c)
+	answer, err := action.Handle(context.TODO(), ip)
+	require.NoError(t, err)
+	assert.Equal(t, v1.IntegrationPlatformPhaseWarming, answer.Status.Phase)
+	assert.Equal(t, corev1.ConditionFalse, answer.Status.GetCondition(v1.IntegrationPlatformConditionWarmKitsReady).Status)
+
+	kits := v1.NewIntegrationKitList()
+	require.NoError(t, c.List(context.TODO(), &kits, k8sclient.InNamespace("ns")))
+	require.Len(t, kits.Items, 2)
+	kit, err := kubernetes.GetIntegrationKit(context.TODO(), c, "kafka", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"camel:http", "camel:kafka", "mvn:org.apache.camel.k:camel-k-runtime"}, kit.Spec.Dependencies)
+	assert.Equal(t, v1.IntegrationKitTypePlatform, kit.Labels[v1.IntegrationKitTypeLabel])
+	assert.Equal(t, "ck", kit.Labels[v1.IntegrationKitWarmLabel])
+	assert.Equal(t, defaults.DefaultRuntimeVersion, kit.Labels[kubernetes.CamelLabelRuntimeVersion])
+	assert.Equal(t, "ck", kit.Annotations[v1.PlatformSelectorAnnotation])
+	assert.Equal(t, metav1.OwnerReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       v1.IntegrationPlatformKind,
+		Name:       "ck",
+		UID:        "platform-uid",
+	}, kit.OwnerReferences[0])
+
+	for _, kit := range kits.Items {
+		kit.Status.Phase = v1.IntegrationKitPhaseReady
+		require.NoError(t, c.Status().Update(context.TODO(), &kit))
+	}
+	answer, err = action.Handle(context.TODO(), ip)
+	require.NoError(t, err)
+	assert.Equal(t, v1.IntegrationPlatformPhaseReady, answer.Status.Phase)
+	assert.Equal(t, corev1.ConditionTrue, answer.Status.GetCondition(v1.IntegrationPlatformConditionWarmKitsReady).Status)
+}
+
+func TestWarmKitsUndeclared(t *testing.T) {
+	ip := newWarmTestPlatform(v1.WarmKitSpec{Name: "kafka", Dependencies: []string{"camel:kafka"}})
+	undeclared := v1.NewIntegrationKit("ns", "undeclared")
+	undeclared.Labels = map[string]string{v1.IntegrationKitWarmLabel: "ck"}
+	undeclared.OwnerReferences = []metav1.OwnerReference{{Kind: v1.IntegrationPlatformKind, Name: "ck", UID: "platform-uid"}}
+	other := v1.NewIntegrationKit("ns", "other")
+	other.Labels = map[string]string{v1.IntegrationKitWarmLabel: "other"}
+	other.OwnerReferences = []metav1.OwnerReference{{Kind: v1.IntegrationPlatformKind, Name: "other", UID: "other-uid"}}
+	c, err := internal.NewFakeClient(ip, newWarmTestCatalog(), undeclared, other)
+	require.NoError(t, err)
+
+	_, err = reconcileWarmKits(context.TODO(), c, ip)
+	require.NoError(t, err)
+
+	_, err = kubernetes.GetIntegrationKit(context.TODO(), c, "undeclared", "ns")
+	assert.True(t, k8serrors.IsNotFound(err))
+	_, err = kubernetes.GetIntegrationKit(context.TODO(), c, "other", "ns")
+	require.NoError(t, err)
+	_, err = kubernetes.GetIntegrationKit(context.TODO(), c, "kafka", "ns")
+	require.NoError(t, err)
+}
+
+func TestWarmKitsDrift(t *testing.T) {
+	ip := newWarmTestPlatform(v1.WarmKitSpec{Name: "kafka", Dependencies: []string{"camel:kafka"}})
+	c, err := internal.NewFakeClient(ip, newWarmTestCatalog())
+	require.NoError(t, err)
+	_, err = reconcileWarmKits(context.TODO(), c, ip)
+	require.NoError(t, err)
+	kit, err := kubernetes.GetIntegrationKit(context.TODO(), c, "kafka", "ns")
+	require.NoError(t, err)
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	require.NoError(t, c.Status().Update(context.TODO(), kit))
+
+	// The declaration of the warm kit changes
+	ip.Status.Build.WarmKits[0].Dependencies = []string{"camel:kafka", "camel:http"}
+	ready, err := reconcileWarmKits(context.TODO(), c, ip)
+	require.NoError(t, err)
+	assert.False(t, ready)
+	kit, err = kubernetes.GetIntegrationKit(context.TODO(), c, "kafka", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"camel:http", "camel:kafka", "mvn:org.apache.camel.k:camel-k-runtime"}, kit.Spec.Dependencies)
+	assert.Equal(t, v1.IntegrationKitPhaseNone, kit.Status.Phase)
+
+	// The runtime of the warm kit changes
+	kit.Labels[kubernetes.CamelLabelRuntimeVersion] = "1.2.3"
+	require.NoError(t, c.Update(context.TODO(), kit))
+	_, err = reconcileWarmKits(context.TODO(), c, ip)
+	require.NoError(t, err)
+	kit, err = kubernetes.GetIntegrationKit(context.TODO(), c, "kafka", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, defaults.DefaultRuntimeVersion, kit.Labels[kubernetes.CamelLabelRuntimeVersion])
+}
+
+func TestWarmKitsFromProfile(t *testing.T) {
+	ip := newWarmTestPlatform()
+	profile := v1.NewIntegrationProfile("ns", "my-profile")
+	profile.UID = "profile-uid"
+	profile.Spec.Build.WarmKits = []v1.WarmKitSpec{{Dependencies: []string{"camel:kafka"}}}
+	c, err := internal.NewFakeClient(ip, newWarmTestCatalog(), &profile)
+	require.NoError(t, err)
+
+	warm, err := hasWarmKits(context.TODO(), c, ip)
+	require.NoError(t, err)
+	assert.True(t, warm)
+
+	ready, err := reconcileWarmKits(context.TODO(), c, ip)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	kits := v1.NewIntegrationKitList()
+	require.NoError(t, c.List(context.TODO(), &kits, k8sclient.InNamespace("ns")))
+	require.Len(t, kits.Items, 1)
+	kit := kits.Items[0]
+	assert.Equal(t, "my-profile", kit.Labels[v1.IntegrationKitWarmLabel])
+	assert.Equal(t, "my-profile", kit.Annotations[v1.IntegrationProfileAnnotation])
+	assert.Equal(t, "ns", kit.Annotations[v1.IntegrationProfileNamespaceAnnotation])
+	assert.Equal(t, v1.IntegrationProfileKind, kit.OwnerReferences[0].Kind)
+}
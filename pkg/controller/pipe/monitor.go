@@ -47,7 +47,8 @@ func (action *monitorAction) Name() string {
 func (action *monitorAction) CanHandle(binding *v1.Pipe) bool {
 	return binding.Status.Phase == v1.PipePhaseCreating ||
 		binding.Status.Phase == v1.PipePhaseError ||
-		binding.Status.Phase == v1.PipePhaseReady
+		binding.Status.Phase == v1.PipePhaseReady ||
+		binding.Status.Phase == v1.PipePhaseSuspended
 }
 
 func (action *monitorAction) Handle(ctx context.Context, pipe *v1.Pipe) (*v1.Pipe, error) {
@@ -72,6 +73,9 @@ func (action *monitorAction) Handle(ctx context.Context, pipe *v1.Pipe) (*v1.Pip
 	integrationProfileNamespaceChanged := v1.GetIntegrationProfileNamespaceAnnotation(pipe) != "" &&
 		(v1.GetIntegrationProfileNamespaceAnnotation(pipe) != v1.GetIntegrationProfileNamespaceAnnotation(&it))
 
+	// The suspension is recorded as an annotation, that must be propagated to the Integration
+	suspendedChanged := v1.IsSuspended(pipe) != v1.IsSuspended(&it)
+
 	sameTraits, err := trait.IntegrationAndPipeSameTraits(action.client, &it, pipe)
 	if err != nil {
 		return nil, err
@@ -91,13 +95,14 @@ func (action *monitorAction) Handle(ctx context.Context, pipe *v1.Pipe) (*v1.Pip
 
 	semanticEquality := equality.Semantic.DeepDerivative(expected.Spec, it.Spec)
 
-	if !semanticEquality || operatorIDChanged || integrationProfileChanged || integrationProfileNamespaceChanged || !sameTraits {
+	if !semanticEquality || operatorIDChanged || integrationProfileChanged || integrationProfileNamespaceChanged || !sameTraits || suspendedChanged {
 		action.L.Info(
 			"Pipe needs a rebuild",
 			"semantic-equality", !semanticEquality,
 			"operatorid-changed", operatorIDChanged,
 			"integration-profile-changed", integrationProfileChanged || integrationProfileNamespaceChanged,
-			"traits-changed", !sameTraits)
+			"traits-changed", !sameTraits,
+			"suspended-changed", suspendedChanged)
 
 		// Pipe has changed and needs rebuild
 		target := pipe.DeepCopy()
@@ -125,6 +130,10 @@ func (action *monitorAction) Handle(ctx context.Context, pipe *v1.Pipe) (*v1.Pip
 		target.Status.Phase = v1.PipePhaseError
 		setPipeReadyCondition(target, &it)
 
+	case v1.IntegrationPhaseSuspended:
+		target.Status.Phase = v1.PipePhaseSuspended
+		setPipeReadyCondition(target, &it)
+
 	default:
 		target.Status.Phase = v1.PipePhaseCreating
 
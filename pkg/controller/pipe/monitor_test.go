@@ -376,3 +376,59 @@ func TestPipeIntegrationPipeTraitAnnotations(t *testing.T) {
 	assert.Equal(t, corev1.ConditionFalse, handledPipe.Status.GetCondition(v1.PipeConditionReady).Status)
 	assert.Equal(t, "Integration \"my-pipe\" is in \"Creating\" phase", handledPipe.Status.GetCondition(v1.PipeConditionReady).Message)
 }
+
+func TestPipeIntegrationSuspended(t *testing.T) {
+	pipe := &v1.Pipe{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       v1.PipeKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-pipe",
+		},
+		Spec: v1.PipeSpec{
+			Source: v1.Endpoint{
+				URI: ptr.To("timer:tick"),
+			},
+			Sink: v1.Endpoint{
+				URI: ptr.To("log:info"),
+			},
+		},
+		Status: v1.PipeStatus{
+			Phase: v1.PipePhaseReady,
+		},
+	}
+
+	c, err := internal.NewFakeClient(pipe)
+	require.NoError(t, err)
+	it, err := CreateIntegrationFor(context.TODO(), c, pipe)
+	require.NoError(t, err)
+	it.Status.Phase = v1.IntegrationPhaseRunning
+	c, err = internal.NewFakeClient(pipe, it)
+	require.NoError(t, err)
+
+	a := NewMonitorAction()
+	a.InjectLogger(log.Log)
+	a.InjectClient(c)
+
+	// The suspension of the Pipe must be propagated to the Integration
+	suspended := pipe.DeepCopy()
+	v1.SetAnnotation(&suspended.ObjectMeta, v1.SuspendedReplicasAnnotation, "")
+	handledPipe, err := a.Handle(context.TODO(), suspended)
+	require.NoError(t, err)
+	assert.Equal(t, v1.PipePhaseNone, handledPipe.Status.Phase)
+
+	// The Integration phase is mirrored
+	v1.SetAnnotation(&it.ObjectMeta, v1.SuspendedReplicasAnnotation, "")
+	it.Status.Phase = v1.IntegrationPhaseSuspended
+	it.Status.SetCondition(v1.IntegrationConditionReady, corev1.ConditionFalse, v1.IntegrationConditionSuspendedReason, "suspended")
+	c, err = internal.NewFakeClient(suspended, it)
+	require.NoError(t, err)
+	a.InjectClient(c)
+	handledPipe, err = a.Handle(context.TODO(), suspended)
+	require.NoError(t, err)
+	assert.Equal(t, v1.PipePhaseSuspended, handledPipe.Status.Phase)
+	assert.Equal(t, v1.IntegrationConditionSuspendedReason, handledPipe.Status.GetCondition(v1.PipeConditionReady).Reason)
+	assert.True(t, a.CanHandle(handledPipe))
+}
@@ -266,6 +266,21 @@ func applyPlatformSpec(source *v1.IntegrationPlatform, target *v1.IntegrationPla
 		target.Status.Build.Timeout = source.Status.Build.Timeout
 	}
 
+	if target.Status.Build.RetryPolicy == nil {
+		log.Debugf("Integration Platform %s [%s]: setting build retry policy", target.Name, target.Namespace)
+		target.Status.Build.RetryPolicy = source.Status.Build.RetryPolicy
+	}
+
+	if target.Status.Build.KitRetention == nil {
+		log.Debugf("Integration Platform %s [%s]: setting kit retention policy", target.Name, target.Namespace)
+		target.Status.Build.KitRetention = source.Status.Build.KitRetention
+	}
+
+	if len(target.Status.Build.Hooks) == 0 {
+		log.Debugf("Integration Platform %s [%s]: setting build hooks", target.Name, target.Namespace)
+		target.Status.Build.Hooks = source.Status.Build.Hooks
+	}
+
 	if target.Status.Build.MaxRunningBuilds <= 0 {
 		log.Debugf("Integration Platform %s [%s]: setting max running builds", target.Name, target.Namespace)
 		target.Status.Build.MaxRunningBuilds = source.Status.Build.MaxRunningBuilds
@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+)
+
+// PruneKits garbage collects the unused kits of the given namespace according to the given retention policy, and
+// returns the collected kits, the least recently used first. When a platform is given, only the kits of its operator,
+// i.e. with the same operator id, are considered. The kits in use are recorded as used, and the collected kits are deleted, along
+// with their image when requested by the policy, unless it is a dry run.
+func PruneKits(ctx context.Context, c client.Client, pl *v1.IntegrationPlatform, policy *v1.KitRetentionPolicy,
+	namespace string, dryRun bool) ([]v1.IntegrationKit, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	kits := v1.NewIntegrationKitList()
+	if err := c.List(ctx, &kits, ctrl.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	handled := make([]v1.IntegrationKit, 0, len(kits.Items))
+	for _, kit := range kits.Items {
+		if pl == nil || v1.GetOperatorIDAnnotation(&kit) == v1.GetOperatorIDAnnotation(pl) {
+			handled = append(handled, kit)
+		}
+	}
+	used, err := usedKits(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	collected, inUse := selectUnusedKits(handled, used, policy, now.Time)
+	if dryRun {
+		return collected, nil
+	}
+
+	var errs []error
+	for _, kit := range inUse {
+		target := kit.DeepCopy()
+		target.Status.LastUsedAt = &now
+		if err := c.Status().Patch(ctx, target, ctrl.MergeFrom(&kit)); err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("cannot record the use of kit %s: %w", kit.Name, err))
+		}
+	}
+	for _, kit := range collected {
+		log.Infof("Garbage collecting unused kit %s/%s", kit.Namespace, kit.Name)
+		if err := c.Delete(ctx, &kit); err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("cannot delete kit %s: %w", kit.Name, err))
+			continue
+		}
+		if policy.DeleteImages && pl != nil {
+			if err := deleteKitImage(ctx, c, pl, kit); err != nil {
+				errs = append(errs, fmt.Errorf("cannot delete image %s of kit %s: %w", kit.Status.Image, kit.Name, err))
+			}
+		}
+	}
+
+	return collected, errors.Join(errs...)
+}
+
+// usedKits returns the kits used by the Integrations, by namespace and name. The Integrations of all the namespaces
+// are considered, as they may use the kits of other namespaces, unless they cannot be listed.
+func usedKits(ctx context.Context, c client.Client, namespace string) (map[string]bool, error) {
+	integrations := v1.NewIntegrationList()
+	if err := c.List(ctx, &integrations); err != nil {
+		if !k8serrors.IsForbidden(err) {
+			return nil, err
+		}
+		if err := c.List(ctx, &integrations, ctrl.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, it := range integrations.Items {
+		for _, ref := range []*corev1.ObjectReference{it.Spec.IntegrationKit, it.Status.IntegrationKit} {
+			if ref == nil || ref.Name == "" {
+				continue
+			}
+			ns := ref.Namespace
+			if ns == "" {
+				ns = it.Namespace
+			}
+			used[ns+"/"+ref.Name] = true
+		}
+	}
+
+	return used, nil
+}
+
+// selectUnusedKits returns the kits of type platform that are collected by the retention policy, the least recently
+// used first, and the kits in use. The kits being built are neither collected nor recorded as used, and the warm kits
+// are never collected.
+func selectUnusedKits(kits []v1.IntegrationKit, used map[string]bool, policy *v1.KitRetentionPolicy,
+	now time.Time) ([]v1.IntegrationKit, []v1.IntegrationKit) {
+	var unused, inUse []v1.IntegrationKit
+	for _, kit := range kits {
+		if kit.Labels[v1.IntegrationKitTypeLabel] != v1.IntegrationKitTypePlatform || kit.DeletionTimestamp != nil {
+			continue
+		}
+		if kit.Status.Phase != v1.IntegrationKitPhaseReady && kit.Status.Phase != v1.IntegrationKitPhaseError {
+			continue
+		}
+		if _, warm := kit.Labels[v1.IntegrationKitWarmLabel]; warm {
+			// The warm kits are kept as long as they are declared, as bases of the incremental builds
+			continue
+		}
+		if used[kit.Namespace+"/"+kit.Name] {
+			inUse = append(inUse, kit)
+		} else {
+			unused = append(unused, kit)
+		}
+	}
+
+	// The most recently used first
+	sort.SliceStable(unused, func(i, j int) bool {
+		return lastUsed(unused[i]).After(lastUsed(unused[j]))
+	})
+	var collected []v1.IntegrationKit
+	for i, kit := range unused {
+		expired := policy.TTL != nil && now.Sub(lastUsed(kit)) > policy.TTL.Duration
+		exceeding := policy.MaxUnusedKits != nil && i >= *policy.MaxUnusedKits
+		if expired || exceeding {
+			collected = append(collected, kit)
+		}
+	}
+	// The least recently used first
+	for i, j := 0, len(collected)-1; i < j; i, j = i+1, j-1 {
+		collected[i], collected[j] = collected[j], collected[i]
+	}
+
+	return collected, inUse
+}
+
+// lastUsed returns the last time the kit was seen in use, or its creation time if it has never been.
+func lastUsed(kit v1.IntegrationKit) time.Time {
+	if kit.Status.LastUsedAt != nil && kit.Status.LastUsedAt.After(kit.CreationTimestamp.Time) {
+		return kit.Status.LastUsedAt.Time
+	}
+	return kit.CreationTimestamp.Time
+}
+
+// deleteKitImage deletes the image of the kit from the registry of the platform. The images of other registries,
+// e.g. the OpenShift internal registry used by the S2I publish strategy, are left untouched.
+func deleteKitImage(ctx context.Context, c client.Client, pl *v1.IntegrationPlatform, kit v1.IntegrationKit) error {
+	spec := pl.Status.Build.Registry
+	if kit.Status.Image == "" || spec.Address == "" || !strings.HasPrefix(kit.Status.Image, spec.Address+"/") {
+		return nil
+	}
+
+	var opts []name.Option
+	if spec.Insecure {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(kit.Status.Image, opts...)
+	if err != nil {
+		return err
+	}
+	if digest := strings.TrimSpace(kit.Status.Digest); digest != "" {
+		// Registries generally only support the deletion of the manifests by digest
+		ref = ref.Context().Digest(digest)
+	}
+	keychain, err := registry.NewKeychain(ctx, c, kit.Namespace, spec.Secret)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Deleting image %s of kit %s/%s", ref.Name(), kit.Namespace, kit.Name)
+	return remote.Delete(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+}
@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+func newRetentionTestKit(name string, kitType string, lastUsed time.Duration) *v1.IntegrationKit {
+	kit := v1.NewIntegrationKit("ns", name)
+	kit.Labels = map[string]string{v1.IntegrationKitTypeLabel: kitType}
+	kit.CreationTimestamp = metav1.NewTime(time.Now().Add(-lastUsed))
+	kit.Status.Phase = v1.IntegrationKitPhaseReady
+	return kit
+}
+
+func newRetentionTestObjects() []runtime.Object {
+	it := v1.NewIntegration("ns", "my-it")
+	it.Status.IntegrationKit = &corev1.ObjectReference{Name: "used"}
+
+	return []runtime.Object{
+		&it,
+		newRetentionTestKit("used", v1.IntegrationKitTypePlatform, 72*time.Hour),
+		newRetentionTestKit("recent", v1.IntegrationKitTypePlatform, time.Hour),
+		newRetentionTestKit("old", v1.IntegrationKitTypePlatform, 24*time.Hour),
+		newRetentionTestKit("oldest", v1.IntegrationKitTypePlatform, 48*time.Hour),
+		newRetentionTestKit("external", v1.IntegrationKitTypeExternal, 96*time.Hour),
+		newRetentionTestKit("user", v1.IntegrationKitTypeUser, 96*time.Hour),
+	}
+}
+
+func kitNames(kits []v1.IntegrationKit) []string {
+	names := make([]string, 0, len(kits))
+	for _, kit := range kits {
+		names = append(names, kit.Name)
+	}
+	return names
+}
+
+func TestPruneKitsMaxUnused(t *testing.T) {
+	c, err := internal.NewFakeClient(newRetentionTestObjects()...)
+	require.NoError(t, err)
+
+	maxUnused := 1
+	policy := &v1.KitRetentionPolicy{MaxUnusedKits: &maxUnused}
+	collected, err := PruneKits(context.TODO(), c, nil, policy, "ns", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oldest", "old"}, kitNames(collected))
+
+	for _, name := range []string{"old", "oldest"} {
+		err := c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: name}, &v1.IntegrationKit{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	}
+	for _, name := range []string{"used", "recent", "external", "user"} {
+		require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: name}, &v1.IntegrationKit{}))
+	}
+
+	used := v1.IntegrationKit{}
+	require.NoError(t, c.Get(context.TODO(), ctrl.ObjectKey{Namespace: "ns", Name: "used"}, &used))
+	assert.NotNil(t, used.Status.LastUsedAt)
+}
+
+func TestPruneKitsTTL(t *testing.T) {
+	c, err := internal.NewFakeClient(newRetentionTestObjects()...)
+	require.NoError(t, err)
+
+	policy := &v1.KitRetentionPolicy{TTL: &metav1.Duration{Duration: 36 * time.Hour}}
+	collected, err := PruneKits(context.TODO(), c, nil, policy, "ns", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oldest"}, kitNames(collected))
+}
+
+func TestPruneKitsDryRun(t *testing.T) {
+	c, err := internal.NewFakeClient(newRetentionTestObjects()...)
+	require.NoError(t, err)
+
+	maxUnused := 0
+	policy := &v1.KitRetentionPolicy{MaxUnusedKits: &maxUnused}
+	collected, err := PruneKits(context.TODO(), c, nil, policy, "ns", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oldest", "old", "recent"}, kitNames(collected))
+
+	kits := v1.NewIntegrationKitList()
+	require.NoError(t, c.List(context.TODO(), &kits, ctrl.InNamespace("ns")))
+	assert.Len(t, kits.Items, 6)
+}
+
+func TestPruneKitsLastUsed(t *testing.T) {
+	kit := newRetentionTestKit("kit", v1.IntegrationKitTypePlatform, 48*time.Hour)
+	kit.Status.LastUsedAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	c, err := internal.NewFakeClient(kit)
+	require.NoError(t, err)
+
+	policy := &v1.KitRetentionPolicy{TTL: &metav1.Duration{Duration: 24 * time.Hour}}
+	collected, err := PruneKits(context.TODO(), c, nil, policy, "ns", false)
+	require.NoError(t, err)
+	assert.Empty(t, collected)
+}
+
+func TestPruneKitsOperatorID(t *testing.T) {
+	kit := newRetentionTestKit("kit", v1.IntegrationKitTypePlatform, 48*time.Hour)
+	v1.SetAnnotation(&kit.ObjectMeta, v1.OperatorIDAnnotation, "camel-k")
+	other := newRetentionTestKit("other", v1.IntegrationKitTypePlatform, 48*time.Hour)
+	v1.SetAnnotation(&other.ObjectMeta, v1.OperatorIDAnnotation, "other-operator")
+	legacy := newRetentionTestKit("legacy", v1.IntegrationKitTypePlatform, 48*time.Hour)
+	c, err := internal.NewFakeClient(kit, other, legacy)
+	require.NoError(t, err)
+
+	pl := v1.NewIntegrationPlatform("ns", "camel-k")
+	v1.SetAnnotation(&pl.ObjectMeta, v1.OperatorIDAnnotation, "camel-k")
+	maxUnused := 0
+	policy := &v1.KitRetentionPolicy{MaxUnusedKits: &maxUnused}
+	// Only the kits of the operator of the platform are collected
+	collected, err := PruneKits(context.TODO(), c, &pl, policy, "ns", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kit"}, kitNames(collected))
+
+	// Without platform, all the kits are considered
+	collected, err = PruneKits(context.TODO(), c, nil, policy, "ns", true)
+	require.NoError(t, err)
+	assert.Len(t, collected, 3)
+}
+
+func TestPruneKitsWarm(t *testing.T) {
+	kit := newRetentionTestKit("warm", v1.IntegrationKitTypePlatform, 48*time.Hour)
+	kit.Labels[v1.IntegrationKitWarmLabel] = "camel-k"
+	c, err := internal.NewFakeClient(kit)
+	require.NoError(t, err)
+
+	maxUnused := 0
+	policy := &v1.KitRetentionPolicy{MaxUnusedKits: &maxUnused}
+	collected, err := PruneKits(context.TODO(), c, nil, policy, "ns", false)
+	require.NoError(t, err)
+	assert.Empty(t, collected)
+}
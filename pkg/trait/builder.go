@@ -18,16 +18,19 @@ limitations under the License.
 package trait
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/camel-k/v2/pkg/util/boolean"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
@@ -64,7 +67,7 @@ func (t *builderTrait) Matches(trait Trait) bool {
 	if !ok {
 		return false
 	}
-	if t.BaseImage != otherTrait.BaseImage || len(t.Properties) != len(otherTrait.Properties) || len(t.Tasks) != len(otherTrait.Tasks) {
+	if t.BaseImage != otherTrait.BaseImage || t.buildTool() != otherTrait.buildTool() || len(t.Properties) != len(otherTrait.Properties) || len(t.Tasks) != len(otherTrait.Tasks) {
 		return false
 	}
 	// More sofisticated check if len is the same. Sort and compare via slices equal func.
@@ -100,6 +103,9 @@ func (t *builderTrait) Configure(e *Environment) (bool, *TraitCondition, error)
 		if e.IntegrationKit != nil && !e.IntegrationKitInPhase(v1.IntegrationKitPhaseBuildSubmitted) {
 			return false, condition, nil
 		}
+		if err := t.validateBuildTool(e); err != nil {
+			return false, condition, err
+		}
 
 		trait := e.Catalog.GetTrait(quarkusTraitID)
 		if trait != nil {
@@ -125,6 +131,9 @@ func (t *builderTrait) configureForQuarkus(trait Trait, e *Environment, conditio
 	}
 
 	if ok && (isNativeIntegration || isNativeKit) {
+		if t.buildTool() == traitv1.GradleBuildTool {
+			return condition, errors.New("native builds are not supported by the gradle build tool")
+		}
 		// TODO expect maven repository in local repo (need to change builder pod accordingly!)
 		command := builder.QuarkusRuntimeSupport(e.CamelCatalog.GetCamelQuarkusVersion()).BuildCommands()
 		nativeBuilderImage := quarkus.NativeBuilderImage
@@ -154,6 +163,29 @@ func (t *builderTrait) configureForQuarkus(trait Trait, e *Environment, conditio
 	return condition, nil
 }
 
+// buildTool returns the tool building the project, defaulting to Maven.
+func (t *builderTrait) buildTool() string {
+	if t.BuildTool == "" {
+		return traitv1.MavenBuildTool
+	}
+
+	return t.BuildTool
+}
+
+func (t *builderTrait) validateBuildTool(e *Environment) error {
+	switch t.buildTool() {
+	case traitv1.MavenBuildTool:
+		return nil
+	case traitv1.GradleBuildTool:
+		if e.Platform.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyJib {
+			return errors.New("the Jib publish strategy is not supported by the gradle build tool")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown build tool: %s. One of [%s, %s] is expected", t.BuildTool, traitv1.MavenBuildTool, traitv1.GradleBuildTool)
+	}
+}
+
 func existsTaskRequest(tasks []string, taskName string) bool {
 	for _, task := range tasks {
 		ts := strings.Split(task, ":")
@@ -221,6 +253,11 @@ func (t *builderTrait) Apply(e *Environment) error {
 		return err
 	}
 
+	retryPolicy, err := t.retryPolicy(e)
+	if err != nil {
+		return err
+	}
+
 	imageName := getImageName(e)
 	// Building task
 	builderTask, err := t.builderTask(e, taskConfOrDefault(tasksConf, "builder"))
@@ -238,6 +275,30 @@ func (t *builderTrait) Apply(e *Environment) error {
 	}
 	builderTask.Configuration.NodeSelector = t.NodeSelector
 	builderTask.Configuration.Annotations = t.Annotations
+
+	// Build hooks declared in the platform
+	hookSteps, err := builder.HookSteps(e.Platform.Status.Build.Hooks, t.hookConstraints(e, builderTask))
+	if err != nil {
+		if err := failIntegrationKit(
+			e,
+			"IntegrationKitBuildHooksValid",
+			corev1.ConditionFalse,
+			"IntegrationKitBuildHooksValid",
+			fmt.Sprintf("One or more build hooks of the platform are not valid: %s", err.Error()),
+		); err != nil {
+			return err
+		}
+		return nil
+	}
+	builderTask.Hooks = e.Platform.Status.Build.Hooks
+	packageHookSteps := make([]string, 0)
+	for _, step := range hookSteps {
+		if step.Phase() >= builder.ApplicationPackagePhase {
+			packageHookSteps = append(packageHookSteps, step.ID())
+		} else {
+			builderTask.Steps = append(builderTask.Steps, step.ID())
+		}
+	}
 	pipelineTasks = append(pipelineTasks, v1.Task{Builder: builderTask})
 
 	// Custom tasks
@@ -258,7 +319,7 @@ func (t *builderTrait) Apply(e *Environment) error {
 	packageTask := builderTask.DeepCopy()
 	packageTask.Name = "package"
 	packageTask.Configuration = *taskConfOrDefault(tasksConf, "package")
-	packageTask.Steps = make([]string, 0)
+	packageTask.Steps = packageHookSteps
 	pipelineTasks = append(pipelineTasks, v1.Task{Package: packageTask})
 
 	// Publishing task
@@ -274,6 +335,7 @@ func (t *builderTrait) Apply(e *Environment) error {
 				BaseImage: t.getBaseImage(e),
 				Image:     imageName,
 				Registry:  e.Platform.Status.Build.Registry,
+				Signing:   e.Platform.Status.Build.Signing,
 			},
 		}})
 
@@ -287,6 +349,7 @@ func (t *builderTrait) Apply(e *Environment) error {
 				BaseImage: t.getBaseImage(e),
 				Image:     imageName,
 				Registry:  e.Platform.Status.Build.Registry,
+				Signing:   e.Platform.Status.Build.Signing,
 			},
 		}}
 		if t.ImagePlatforms != nil {
@@ -306,6 +369,16 @@ func (t *builderTrait) Apply(e *Environment) error {
 			},
 			Tag: tag,
 		}})
+
+	case v1.IntegrationPlatformBuildPublishStrategyOCILayout:
+		ociLayoutTask, err := t.determineOCILayoutTask(e, builderTask, tasksConf, imageName)
+		if err != nil {
+			return err
+		}
+		if ociLayoutTask == nil {
+			return nil
+		}
+		pipelineTasks = append(pipelineTasks, v1.Task{OCILayout: ociLayoutTask})
 	}
 
 	// filter only those tasks required by the user
@@ -326,9 +399,44 @@ func (t *builderTrait) Apply(e *Environment) error {
 	}
 	// add local pipeline tasks to env pipeline
 	e.Pipeline = append(e.Pipeline, pipelineTasks...)
+	e.BuildRetryPolicy = retryPolicy
 	return nil
 }
 
+// retryPolicy returns the retry policy of the failed builds, the platform one overridden by the trait options.
+func (t *builderTrait) retryPolicy(e *Environment) (*v1.BuildRetryPolicy, error) {
+	policy := &v1.BuildRetryPolicy{}
+	if e.Platform != nil && e.Platform.Status.Build.RetryPolicy != nil {
+		policy = e.Platform.Status.Build.RetryPolicy.DeepCopy()
+	}
+	if t.RetryMaxAttempts != nil {
+		if *t.RetryMaxAttempts < 0 {
+			return nil, fmt.Errorf("invalid retry max attempts: %d", *t.RetryMaxAttempts)
+		}
+		maxAttempts := *t.RetryMaxAttempts
+		policy.MaxAttempts = &maxAttempts
+	}
+	if t.RetryMinBackoff != "" {
+		d, err := time.ParseDuration(t.RetryMinBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry min backoff: %w", err)
+		}
+		policy.MinBackoff = &metav1.Duration{Duration: d}
+	}
+	if t.RetryMaxBackoff != "" {
+		d, err := time.ParseDuration(t.RetryMaxBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry max backoff: %w", err)
+		}
+		policy.MaxBackoff = &metav1.Duration{Duration: d}
+	}
+	if policy.MaxAttempts == nil && policy.MinBackoff == nil && policy.MaxBackoff == nil {
+		return nil, nil
+	}
+
+	return policy, nil
+}
+
 func getTag(e *Environment) string {
 	if e.IntegrationKit != nil {
 		return e.IntegrationKit.ResourceVersion
@@ -466,6 +574,9 @@ func (t *builderTrait) builderTask(e *Environment, taskConf *v1.BuildConfigurati
 
 	steps := make([]builder.Step, 0)
 	steps = append(steps, builder.Project.CommonSteps...)
+	if maven.Offline != nil {
+		steps = append(steps, builder.Project.VerifyOffline)
+	}
 
 	// sort steps by phase
 	sort.SliceStable(steps, func(i, j int) bool {
@@ -507,6 +618,21 @@ func (t *builderTrait) getBaseImage(e *Environment) string {
 	return baseImage
 }
 
+// hookConstraints returns the constraints of the build of the kit on the build hooks of the platform.
+func (t *builderTrait) hookConstraints(e *Environment, builderTask *v1.BuilderTask) builder.HookConstraints {
+	realBuildStrategy := builderTask.Configuration.Strategy
+	if realBuildStrategy == "" {
+		realBuildStrategy = e.Platform.Status.Build.BuildConfiguration.Strategy
+	}
+
+	return builder.HookConstraints{
+		Strategy: realBuildStrategy,
+		// The S2I and the custom publish tasks build the image from its Dockerfile
+		Dockerfile: e.Platform.Status.Build.PublishStrategy == v1.IntegrationPlatformBuildPublishStrategyS2I || len(t.Tasks) > 0,
+		Git:        builderTask.Git != nil,
+	}
+}
+
 func (t *builderTrait) determineCustomTasks(e *Environment, builderTask *v1.BuilderTask, tasksConf map[string]*v1.BuildConfiguration) ([]v1.Task, error) {
 	imageName := getImageName(e)
 
@@ -536,6 +662,51 @@ func (t *builderTrait) determineCustomTasks(e *Environment, builderTask *v1.Buil
 	return t.customTasks(tasksConf, imageName)
 }
 
+// determineOCILayoutTask returns the task writing the image to the OCI image layout of the platform, or nil when the
+// kit cannot be built with the OCILayout publish strategy, in which case the kit is failed.
+func (t *builderTrait) determineOCILayoutTask(e *Environment, builderTask *v1.BuilderTask, tasksConf map[string]*v1.BuildConfiguration, imageName string) (*v1.OCILayoutTask, error) {
+	realBuildStrategy := builderTask.Configuration.Strategy
+	if realBuildStrategy == "" {
+		realBuildStrategy = e.Platform.Status.Build.BuildConfiguration.Strategy
+	}
+
+	var message string
+	switch {
+	case realBuildStrategy != v1.BuildStrategyPod:
+		message = fmt.Sprintf("The %s publish strategy is unavailable when using `%s` platform build strategy: use `%s` instead.",
+			v1.IntegrationPlatformBuildPublishStrategyOCILayout, realBuildStrategy, v1.BuildStrategyPod)
+	case e.Platform.Status.Build.OCILayout == nil || e.Platform.Status.Build.OCILayout.PersistentVolumeClaim == "":
+		message = fmt.Sprintf("The %s publish strategy requires the OCI image layout PersistentVolumeClaim to be configured in the platform.",
+			v1.IntegrationPlatformBuildPublishStrategyOCILayout)
+	}
+	if message != "" {
+		if err := failIntegrationKit(e, "IntegrationKitOCILayoutValid", corev1.ConditionFalse, "IntegrationKitOCILayoutValid", message); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	format := e.Platform.Status.Build.OCILayout.Format
+	if format == "" {
+		format = v1.OCILayoutFormatTarball
+	}
+
+	return &v1.OCILayoutTask{
+		BaseTask: v1.BaseTask{
+			Name:          "oci-layout",
+			Configuration: *taskConfOrDefault(tasksConf, "oci-layout"),
+		},
+		PublishTask: v1.PublishTask{
+			BaseImage: t.getBaseImage(e),
+			// The image is not pushed to any registry, whose address may not be configured
+			Image:    strings.TrimPrefix(imageName, "/"),
+			Registry: e.Platform.Status.Build.Registry,
+		},
+		PersistentVolumeClaim: e.Platform.Status.Build.OCILayout.PersistentVolumeClaim,
+		Format:                format,
+	}, nil
+}
+
 // the format expected is "<task-name>;<task-image>;<task-container-command>[;<task-container-user-id>]".
 func (t *builderTrait) customTasks(tasksConf map[string]*v1.BuildConfiguration, imageName string) ([]v1.Task, error) {
 	customTasks := make([]v1.Task, len(t.Tasks))
@@ -675,6 +846,9 @@ func filter(tasks []v1.Task, filterTasks []string) ([]v1.Task, error) {
 			case t.Jib != nil && t.Jib.Name == f:
 				filteredTasks = append(filteredTasks, t)
 				found = true
+			case t.OCILayout != nil && t.OCILayout.Name == f:
+				filteredTasks = append(filteredTasks, t)
+				found = true
 			}
 		}
 
@@ -699,6 +873,8 @@ func publishingOrUserTask(t v1.Task) bool {
 		return true
 	case t.Jib != nil:
 		return true
+	case t.OCILayout != nil:
+		return true
 	}
 
 	return false
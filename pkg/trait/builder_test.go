@@ -20,6 +20,7 @@ package trait
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ import (
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	traitv1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1/trait"
+	"github.com/apache/camel-k/v2/pkg/builder"
 	"github.com/apache/camel-k/v2/pkg/internal"
 	"github.com/apache/camel-k/v2/pkg/util/camel"
 	"github.com/apache/camel-k/v2/pkg/util/defaults"
@@ -97,6 +99,39 @@ func TestJibBuilderTrait(t *testing.T) {
 	assert.NotEmpty(t, env.Pipeline[2].Jib.Registry)
 }
 
+func TestOCILayoutBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyOCILayout, v1.BuildStrategyPod)
+	env.Platform.Namespace = "ns"
+	env.Platform.Spec.Build.Registry = v1.RegistrySpec{}
+	env.Platform.Spec.Build.OCILayout = &v1.OCILayoutSpec{PersistentVolumeClaim: "oci-layout"}
+	env.Platform.ResyncStatusFullConfig()
+
+	err := createNominalBuilderTraitTest().Apply(env)
+
+	require.NoError(t, err)
+	assert.Len(t, env.Pipeline, 3)
+	require.NotNil(t, env.Pipeline[2].OCILayout)
+	assert.Equal(t, "oci-layout", env.Pipeline[2].OCILayout.Name)
+	assert.Equal(t, "root-jdk-image", env.Pipeline[2].OCILayout.BaseImage)
+	assert.Equal(t, "oci-layout", env.Pipeline[2].OCILayout.PersistentVolumeClaim)
+	assert.Equal(t, v1.OCILayoutFormatTarball, env.Pipeline[2].OCILayout.Format)
+	assert.Regexp(t, "^ns/camel-k-my-kit:", env.Pipeline[2].OCILayout.Image)
+}
+
+func TestOCILayoutBuilderTraitInvalidStrategy(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyOCILayout, v1.BuildStrategyRoutine)
+	env.Platform.Spec.Build.OCILayout = &v1.OCILayoutSpec{PersistentVolumeClaim: "oci-layout"}
+	env.Platform.ResyncStatusFullConfig()
+
+	err := createNominalBuilderTraitTest().Apply(env)
+
+	// The error will be reported to IntegrationKits
+	require.NoError(t, err)
+	assert.Empty(t, env.Pipeline)
+	assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+	assert.Equal(t, v1.IntegrationKitConditionType("IntegrationKitOCILayoutValid"), env.IntegrationKit.Status.Conditions[0].Type)
+}
+
 func createBuilderTestEnv(cluster v1.IntegrationPlatformCluster, strategy v1.IntegrationPlatformBuildPublishStrategy, buildStrategy v1.BuildStrategy) *Environment {
 	c, err := camel.DefaultCatalog()
 	if err != nil {
@@ -170,6 +205,137 @@ func TestMavenPropertyBuilderTrait(t *testing.T) {
 	assert.Equal(t, "build-time-value1", env.Pipeline[0].Builder.Maven.Properties["build-time-prop1"])
 }
 
+func TestOfflineBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib, v1.BuildStrategyRoutine)
+	builderTrait := createNominalBuilderTraitTest()
+	err := builderTrait.Apply(env)
+	require.NoError(t, err)
+	assert.NotContains(t, env.Pipeline[0].Builder.Steps, builder.Project.VerifyOffline.ID())
+
+	env = createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib, v1.BuildStrategyRoutine)
+	env.Platform.Status.Build.Maven.Offline = &v1.MavenOfflineSpec{Bundle: "/bundles/camel-k-bundle.tar.gz"}
+	builderTrait = createNominalBuilderTraitTest()
+	err = builderTrait.Apply(env)
+	require.NoError(t, err)
+	assert.Equal(t, "/bundles/camel-k-bundle.tar.gz", env.Pipeline[0].Builder.Maven.Offline.Bundle)
+	assert.Contains(t, env.Pipeline[0].Builder.Steps, builder.Project.VerifyOffline.ID())
+}
+
+func TestRetryPolicyBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib, v1.BuildStrategyRoutine)
+	builderTrait := createNominalBuilderTraitTest()
+	require.NoError(t, builderTrait.Apply(env))
+	assert.Nil(t, env.BuildRetryPolicy)
+
+	platformMaxAttempts := 3
+	env = createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib, v1.BuildStrategyRoutine)
+	env.Platform.Status.Build.RetryPolicy = &v1.BuildRetryPolicy{
+		MaxAttempts: &platformMaxAttempts,
+		MaxBackoff:  &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	maxAttempts := 0
+	builderTrait = createNominalBuilderTraitTest()
+	builderTrait.RetryMaxAttempts = &maxAttempts
+	builderTrait.RetryMinBackoff = "30s"
+	require.NoError(t, builderTrait.Apply(env))
+	assert.Equal(t, &v1.BuildRetryPolicy{
+		MaxAttempts: &maxAttempts,
+		MinBackoff:  &metav1.Duration{Duration: 30 * time.Second},
+		MaxBackoff:  &metav1.Duration{Duration: 5 * time.Minute},
+	}, env.BuildRetryPolicy)
+	// The platform retry policy is not altered
+	assert.Equal(t, 3, *env.Platform.Status.Build.RetryPolicy.MaxAttempts)
+
+	builderTrait.RetryMaxBackoff = "1 minute"
+	require.ErrorContains(t, builderTrait.Apply(env), "invalid retry max backoff")
+}
+
+func TestBuildHooksBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyS2I, v1.BuildStrategyPod)
+	env.Platform.Status.Build.Hooks = []v1.BuildHook{
+		{Name: "company-ca", Phase: v1.BuildHookPhaseInit, Script: "cp /etc/ssl/company-ca.crt ."},
+		{Name: "user", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "USER 1001"},
+	}
+	require.NoError(t, createNominalBuilderTraitTest().Apply(env))
+
+	hookSteps, err := builder.HookSteps(env.Platform.Status.Build.Hooks, builder.HookConstraints{
+		Strategy:   v1.BuildStrategyPod,
+		Dockerfile: true,
+	})
+	require.NoError(t, err)
+	builderTask := env.Pipeline[0].Builder
+	packageTask := env.Pipeline[1].Package
+	assert.Equal(t, env.Platform.Status.Build.Hooks, builderTask.Hooks)
+	assert.Equal(t, env.Platform.Status.Build.Hooks, packageTask.Hooks)
+	assert.Contains(t, builderTask.Steps, hookSteps[0].ID())
+	assert.NotContains(t, builderTask.Steps, hookSteps[1].ID())
+	assert.Equal(t, []string{hookSteps[1].ID()}, packageTask.Steps)
+}
+
+func TestInvalidBuildHooksBuilderTrait(t *testing.T) {
+	tests := []struct {
+		name            string
+		publishStrategy v1.IntegrationPlatformBuildPublishStrategy
+		strategy        v1.BuildStrategy
+		git             bool
+		hook            v1.BuildHook
+		message         string
+	}{
+		{
+			name:            "Dockerfile at another phase",
+			publishStrategy: v1.IntegrationPlatformBuildPublishStrategyS2I,
+			strategy:        v1.BuildStrategyPod,
+			hook:            v1.BuildHook{Name: "user", Phase: v1.BuildHookPhaseInit, Dockerfile: "USER 1001"},
+			message:         "build hook user can only have a Dockerfile at the ApplicationPackage phase",
+		},
+		{
+			name:            "script with the routine strategy",
+			publishStrategy: v1.IntegrationPlatformBuildPublishStrategyJib,
+			strategy:        v1.BuildStrategyRoutine,
+			hook:            v1.BuildHook{Name: "company-ca", Phase: v1.BuildHookPhaseInit, Script: "cp /etc/ssl/company-ca.crt ."},
+			message:         "build hook company-ca can only run a script with the pod build strategy",
+		},
+		{
+			name:            "Dockerfile with Jib",
+			publishStrategy: v1.IntegrationPlatformBuildPublishStrategyJib,
+			strategy:        v1.BuildStrategyPod,
+			hook:            v1.BuildHook{Name: "user", Phase: v1.BuildHookPhaseApplicationPackage, Dockerfile: "USER 1001"},
+			message:         "build hook user cannot have a Dockerfile, as the image is not built from its Dockerfile",
+		},
+		{
+			name:            "ProjectGeneration with Git",
+			publishStrategy: v1.IntegrationPlatformBuildPublishStrategyJib,
+			strategy:        v1.BuildStrategyPod,
+			git:             true,
+			hook:            v1.BuildHook{Name: "pom", Phase: v1.BuildHookPhaseProjectGeneration, Script: "echo"},
+			message:         "build hook pom cannot run at the ProjectGeneration phase of the builds from Git",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, test.publishStrategy, test.strategy)
+			env.Platform.Status.Build.Hooks = []v1.BuildHook{test.hook}
+			if test.git {
+				env.Integration = &v1.Integration{
+					Spec: v1.IntegrationSpec{
+						Git: &v1.GitConfigSpec{URL: "https://github.com/apache/camel-k-examples"},
+					},
+				}
+			}
+
+			err := createNominalBuilderTraitTest().Apply(env)
+
+			// The error will be reported to IntegrationKits
+			require.NoError(t, err)
+			assert.Empty(t, env.Pipeline)
+			assert.Equal(t, v1.IntegrationKitPhaseError, env.IntegrationKit.Status.Phase)
+			assert.Equal(t, v1.IntegrationKitConditionType("IntegrationKitBuildHooksValid"), env.IntegrationKit.Status.Conditions[0].Type)
+			assert.Contains(t, env.IntegrationKit.Status.Conditions[0].Message, test.message)
+		})
+	}
+}
+
 func createNominalBuilderTraitTest() *builderTrait {
 	builderTrait, _ := newBuilderTrait().(*builderTrait)
 	return builderTrait
@@ -675,3 +841,50 @@ func TestBuilderTraitOrderStrategy(t *testing.T) {
 
 	assert.Equal(t, v1.BuildOrderStrategyFIFO, env.Pipeline[0].Builder.Configuration.OrderStrategy)
 }
+
+func TestBuilderTraitGradleBuildTool(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyS2I, v1.BuildStrategyRoutine)
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.BuildTool = traitv1.GradleBuildTool
+
+	configured, _, err := builderTrait.Configure(env)
+	require.NoError(t, err)
+	assert.True(t, configured)
+}
+
+func TestBuilderTraitGradleBuildToolJibNotSupported(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib, v1.BuildStrategyRoutine)
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.BuildTool = traitv1.GradleBuildTool
+
+	configured, _, err := builderTrait.Configure(env)
+	require.Error(t, err)
+	assert.False(t, configured)
+	assert.Equal(t, "the Jib publish strategy is not supported by the gradle build tool", err.Error())
+}
+
+func TestBuilderTraitUnknownBuildTool(t *testing.T) {
+	env := createBuilderTestEnv(v1.IntegrationPlatformClusterKubernetes, v1.IntegrationPlatformBuildPublishStrategyJib, v1.BuildStrategyRoutine)
+	builderTrait := createNominalBuilderTraitTest()
+	builderTrait.BuildTool = "ant"
+
+	_, _, err := builderTrait.Configure(env)
+	require.Error(t, err)
+	assert.Equal(t, "unknown build tool: ant. One of [maven, gradle] is expected", err.Error())
+}
+
+func TestBuilderMatchesBuildTool(t *testing.T) {
+	t1 := builderTrait{
+		BasePlatformTrait: NewBasePlatformTrait("builder", 600),
+		BuilderTrait:      traitv1.BuilderTrait{},
+	}
+	t2 := builderTrait{
+		BasePlatformTrait: NewBasePlatformTrait("builder", 600),
+		BuilderTrait: traitv1.BuilderTrait{
+			BuildTool: traitv1.MavenBuildTool,
+		},
+	}
+	assert.True(t, t1.Matches(&t2))
+	t2.BuildTool = traitv1.GradleBuildTool
+	assert.False(t, t1.Matches(&t2))
+}
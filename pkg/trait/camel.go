@@ -185,7 +185,7 @@ func (t *camelTrait) loadOrCreateCatalog(e *Environment) error {
 				extraRepositories = append(extraRepositories, e.IntegrationKit.Spec.Repositories...)
 			}
 			catalog, err = camel.CreateCatalog(e.Ctx, e.Client, catalogNamespace,
-				mavenSpec, e.Platform.Status.Build.GetTimeout().Duration, runtime, extraRepositories)
+				mavenSpec, e.Platform.Status.Build.Registry, e.Platform.Status.Build.GetTimeout().Duration, runtime, extraRepositories)
 			if err != nil {
 				return err
 			}
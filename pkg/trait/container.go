@@ -18,12 +18,18 @@ limitations under the License.
 package trait
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -37,6 +43,8 @@ import (
 	"github.com/apache/camel-k/v2/pkg/util/envvar"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/openshift"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+	"github.com/apache/camel-k/v2/pkg/util/signature"
 )
 
 const (
@@ -101,7 +109,14 @@ func (t *containerTrait) Apply(e *Environment) error {
 	if err := t.configureImageIntegrationKit(e); err != nil {
 		return err
 	}
-	return t.configureContainer(e)
+	image := e.Integration.Status.Image
+	if e.IntegrationInRunningPhases() {
+		var err error
+		if image, err = t.verifyImageSignature(e); err != nil {
+			return err
+		}
+	}
+	return t.configureContainer(e, image)
 }
 
 func (t *containerTrait) configureImageIntegrationKit(e *Environment) error {
@@ -120,13 +135,97 @@ func (t *containerTrait) configureImageIntegrationKit(e *Environment) error {
 	return nil
 }
 
-func (t *containerTrait) configureContainer(e *Environment) error {
+// verifyImageSignature verifies the signature of the image of the Integration, when the platform configures the
+// signing of the images, before the image is deployed. The images not signed are rejected when the verification is
+// enforced, the images with an invalid signature are always rejected. It returns the image to deploy, pinned to the
+// digest whose signature has been verified, so that a tag pushed again after the verification is not deployed.
+func (t *containerTrait) verifyImageSignature(e *Environment) (string, error) {
+	image := e.Integration.Status.Image
+	if e.Platform == nil || e.Platform.Status.Build.Signing == nil || image == "" {
+		e.Integration.Status.RemoveCondition(v1.IntegrationConditionImageSignatureVerified)
+		return image, nil
+	}
+
+	var opts []name.Option
+	registrySpec := e.Platform.Status.Build.Registry
+	if registrySpec.Insecure && registrySpec.Address != "" && strings.HasPrefix(image, registrySpec.Address) {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(image, opts...)
+	if err != nil {
+		return "", err
+	}
+	keychain, err := registry.NewKeychain(e.Ctx, e.Client, e.Platform.Namespace, registrySpec.Secret)
+	if err != nil {
+		return "", err
+	}
+	remoteOpts := []remote.Option{remote.WithContext(e.Ctx), remote.WithAuthFromKeychain(keychain)}
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		desc, err := remote.Head(ref, remoteOpts...)
+		if err != nil {
+			return "", fmt.Errorf("cannot resolve the digest of image %s: %w", image, err)
+		}
+		digest = ref.Context().Digest(desc.Digest.String())
+	}
+
+	verified := fmt.Sprintf("image %s signature verified", digest.String())
+	if c := e.Integration.Status.GetCondition(v1.IntegrationConditionImageSignatureVerified); c != nil &&
+		c.Status == corev1.ConditionTrue && c.Message == verified {
+		return digest.String(), nil
+	}
+
+	signing := e.Platform.Status.Build.Signing
+	secret, err := e.Client.CoreV1().Secrets(e.Platform.Namespace).Get(e.Ctx, signing.Secret, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot read the signing secret %s: %w", signing.Secret, err)
+	}
+	key, err := signature.ParsePublicKey(secret.Data[signature.PublicKeyEntry])
+	if err != nil {
+		return "", fmt.Errorf("cannot read the public key of the signing secret %s: %w", signing.Secret, err)
+	}
+
+	_, err = signature.Verify(digest, key, remoteOpts...)
+	switch {
+	case err == nil:
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionImageSignatureVerified,
+			corev1.ConditionTrue,
+			v1.IntegrationConditionImageSignatureVerifiedReason,
+			verified,
+		)
+	case errors.Is(err, signature.ErrUnsigned):
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionImageSignatureVerified,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionImageUnsignedReason,
+			err.Error(),
+		)
+		if signing.Enforce {
+			return "", fmt.Errorf("image %s rejected: %w", image, err)
+		}
+	case errors.Is(err, signature.ErrInvalidSignature):
+		e.Integration.Status.SetCondition(
+			v1.IntegrationConditionImageSignatureVerified,
+			corev1.ConditionFalse,
+			v1.IntegrationConditionImageSignatureInvalidReason,
+			err.Error(),
+		)
+		return "", fmt.Errorf("image %s rejected: %w", image, err)
+	default:
+		return "", fmt.Errorf("cannot verify the signature of image %s: %w", image, err)
+	}
+
+	return digest.String(), nil
+}
+
+func (t *containerTrait) configureContainer(e *Environment, image string) error {
 	if e.ApplicationProperties == nil {
 		e.ApplicationProperties = make(map[string]string)
 	}
 	container := corev1.Container{
 		Name:  t.getContainerName(),
-		Image: e.Integration.Status.Image,
+		Image: image,
 		Env:   make([]corev1.EnvVar, 0),
 	}
 	if t.ImagePullPolicy != "" {
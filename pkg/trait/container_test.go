@@ -19,8 +19,19 @@ package trait
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +48,7 @@ import (
 	"github.com/apache/camel-k/v2/pkg/internal"
 	"github.com/apache/camel-k/v2/pkg/util/camel"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
+	"github.com/apache/camel-k/v2/pkg/util/signature"
 )
 
 func TestContainerWithDefaults(t *testing.T) {
@@ -724,3 +736,97 @@ func createSettingContextEnvironment(t *testing.T, profile v1.TraitProfile) *Env
 
 	return &environment
 }
+
+func TestContainerImageSignature(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	image := strings.TrimPrefix(server.URL, "http://") + "/ns/camel-k-kit:1"
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	client, err := internal.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cosign"},
+		Data: map[string][]byte{
+			"cosign.pub": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}),
+		},
+	})
+	require.NoError(t, err)
+
+	platform := v1.NewIntegrationPlatform("ns", "camel-k")
+	platform.Status.Build.Signing = &v1.ImageSigningSpec{Secret: "cosign"}
+	environment := Environment{
+		Ctx:      context.TODO(),
+		Client:   client,
+		Platform: &platform,
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "my-it"},
+			Status: v1.IntegrationStatus{
+				Phase: v1.IntegrationPhaseDeploying,
+				Image: image,
+			},
+		},
+	}
+	trait, ok := newContainerTrait().(*containerTrait)
+	require.True(t, ok)
+
+	pinned := ref.Context().Digest(digest.String()).String()
+
+	// Not signed, and the verification is not enforced
+	deployed, err := trait.verifyImageSignature(&environment)
+	require.NoError(t, err)
+	assert.Equal(t, pinned, deployed)
+	condition := environment.Integration.Status.GetCondition(v1.IntegrationConditionImageSignatureVerified)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionImageUnsignedReason, condition.Reason)
+
+	// Not signed, and the verification is enforced
+	platform.Status.Build.Signing.Enforce = true
+	_, err = trait.verifyImageSignature(&environment)
+	require.ErrorIs(t, err, signature.ErrUnsigned)
+
+	// Signed with another key
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, signature.Sign(ref.Context().Digest(digest.String()), other))
+	_, err = trait.verifyImageSignature(&environment)
+	require.ErrorIs(t, err, signature.ErrInvalidSignature)
+	condition = environment.Integration.Status.GetCondition(v1.IntegrationConditionImageSignatureVerified)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionImageSignatureInvalidReason, condition.Reason)
+
+	// Signed with the key, the image is deployed by its verified digest
+	require.NoError(t, signature.Sign(ref.Context().Digest(digest.String()), key))
+	deployed, err = trait.verifyImageSignature(&environment)
+	require.NoError(t, err)
+	assert.Equal(t, pinned, deployed)
+	condition = environment.Integration.Status.GetCondition(v1.IntegrationConditionImageSignatureVerified)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1.IntegrationConditionImageSignatureVerifiedReason, condition.Reason)
+	assert.Contains(t, condition.Message, pinned)
+
+	// The tag pushed again with an image not signed is verified again
+	unsigned, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, unsigned))
+	_, err = trait.verifyImageSignature(&environment)
+	require.ErrorIs(t, err, signature.ErrUnsigned)
+
+	// No signing configured
+	platform.Status.Build.Signing = nil
+	deployed, err = trait.verifyImageSignature(&environment)
+	require.NoError(t, err)
+	assert.Equal(t, image, deployed)
+	assert.Nil(t, environment.Integration.Status.GetCondition(v1.IntegrationConditionImageSignatureVerified))
+}
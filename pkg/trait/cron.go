@@ -284,6 +284,10 @@ func (t *cronTrait) getCronJobFor(e *Environment) *batchv1.CronJob {
 			},
 		},
 	}
+	// The CronJob stops scheduling new jobs while the Integration is suspended
+	if v1.IsSuspended(e.Integration) {
+		cronjob.Spec.Suspend = ptr.To(true)
+	}
 
 	return &cronjob
 }
@@ -884,3 +884,23 @@ func TestCronRuntimeTriggerReplacement(t *testing.T) {
 		environment.Integration.Status.GeneratedSources[0].Content,
 	)
 }
+
+func TestCronSuspended(t *testing.T) {
+	trait, _ := newCronTrait().(*cronTrait)
+	trait.Schedule = "0 0/2 * * ?"
+	environment := Environment{
+		Integration: &v1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+		},
+	}
+
+	cronJob := trait.getCronJobFor(&environment)
+	assert.Nil(t, cronJob.Spec.Suspend)
+
+	v1.SetAnnotation(&environment.Integration.ObjectMeta, v1.SuspendedReplicasAnnotation, "")
+	cronJob = trait.getCronJobFor(&environment)
+	assert.True(t, *cronJob.Spec.Suspend)
+}
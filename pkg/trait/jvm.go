@@ -47,8 +47,17 @@ const (
 	defaultMaxMemoryPercentage          = int64(50)
 	lowMemoryThreshold                  = 300
 	lowMemoryMAxMemoryDefaultPercentage = int64(25)
+
+	jfrVolumeName       = "jfr"
+	jfrMountPath        = "/var/lib/camel/jfr"
+	defaultJFRMaxSize   = "250Mi"
+	jfrRecordingPattern = "-XX:StartFlightRecording=name=%s,disk=true,maxsize=%d,dumponexit=true,filename=%s/%s.jfr"
+	jfrRepositoryOption = "-XX:FlightRecorderOptions:repository=" + jfrMountPath
 )
 
+// JFRRecordingName is the name of the continuous JFR recording started by the jvm trait.
+const JFRRecordingName = "continuous"
+
 type jvmTrait struct {
 	BaseTrait
 	traitv1.JVMTrait `property:",squash"`
@@ -142,7 +151,51 @@ func (t *jvmTrait) Apply(e *Environment) error {
 		args = append(args, httpProxyArgs...)
 	}
 
-	return t.feedContainer(container, args, e)
+	if err := t.feedContainer(container, args, e); err != nil {
+		return err
+	}
+
+	// The JFR volume is mounted once the classpath is computed, as it must not be part of it
+	if ptr.Deref(t.JFR, false) {
+		return t.enableJFR(e, container)
+	}
+
+	return nil
+}
+
+// enableJFR configures a continuous Java Flight Recorder recording, with its disk repository in an emptyDir volume,
+// so that it can be dumped and retrieved at any time.
+func (t *jvmTrait) enableJFR(e *Environment, container *corev1.Container) error {
+	maxSize := t.JFRMaxSize
+	if maxSize == "" {
+		maxSize = defaultJFRMaxSize
+	}
+	sizeLimit, err := resource.ParseQuantity(maxSize)
+	if err != nil {
+		return fmt.Errorf("invalid JFR max size %q: %w", maxSize, err)
+	}
+
+	e.Resources.VisitPodSpec(func(spec *corev1.PodSpec) {
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: jfrVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					SizeLimit: &sizeLimit,
+				},
+			},
+		})
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      jfrVolumeName,
+		MountPath: jfrMountPath,
+	})
+
+	// The JVM options must precede the main class or jar. The disk repository of the recording is the volume, so
+	// that the recording chunks are bounded by its size limit, rather than written to the container layer.
+	jfrOption := fmt.Sprintf(jfrRecordingPattern, JFRRecordingName, sizeLimit.Value(), jfrMountPath, JFRRecordingName)
+	container.Args = append([]string{jfrRepositoryOption, jfrOption}, container.Args...)
+
+	return nil
 }
 
 //nolint:nestif
@@ -385,6 +385,66 @@ func TestApplyJvmTraitWithDebugEnabled(t *testing.T) {
 	)
 }
 
+func TestApplyJvmTraitWithJFREnabled(t *testing.T) {
+	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
+	trait.JFR = ptr.To(true)
+	trait.JFRMaxSize = "100Mi"
+
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment.Resources.Add(&d)
+	err := trait.Apply(environment)
+	require.NoError(t, err)
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "-XX:FlightRecorderOptions:repository=/var/lib/camel/jfr", container.Args[0])
+	assert.Equal(t, "-XX:StartFlightRecording=name=continuous,disk=true,maxsize=104857600,dumponexit=true,filename=/var/lib/camel/jfr/continuous.jfr", container.Args[1])
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "jfr", MountPath: "/var/lib/camel/jfr"})
+	for _, arg := range container.Args {
+		assert.NotContains(t, arg, "/var/lib/camel/jfr:")
+	}
+	require.Len(t, d.Spec.Template.Spec.Volumes, 1)
+	assert.Equal(t, "jfr", d.Spec.Template.Spec.Volumes[0].Name)
+	assert.Equal(t, "100Mi", d.Spec.Template.Spec.Volumes[0].EmptyDir.SizeLimit.String())
+}
+
+func TestApplyJvmTraitWithInvalidJFRMaxSize(t *testing.T) {
+	trait, environment := createNominalJvmTest(v1.IntegrationKitTypePlatform)
+	trait.JFR = ptr.To(true)
+	trait.JFRMaxSize = "lots"
+
+	d := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: defaultContainerName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	environment.Resources.Add(&d)
+	err := trait.Apply(environment)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid JFR max size "lots"`)
+}
+
 func TestApplyJvmTraitWithExternalKitType(t *testing.T) {
 	trait, environment := createNominalJvmTest(v1.IntegrationKitTypeExternal)
 
@@ -105,7 +105,7 @@ func (t *knativeServiceTrait) Configure(e *Environment) (bool, *TraitCondition,
 
 	if strategy == ControllerStrategyKnativeService {
 		t.Enabled = ptr.To(true)
-	} else if e.IntegrationInPhase(v1.IntegrationPhaseRunning, v1.IntegrationPhaseError) {
+	} else if e.IntegrationInPhase(v1.IntegrationPhaseRunning, v1.IntegrationPhaseError, v1.IntegrationPhaseSuspended) {
 		condition := e.Integration.Status.GetCondition(v1.IntegrationConditionKnativeServiceAvailable)
 		t.Enabled = ptr.To(condition != nil && condition.Status == corev1.ConditionTrue)
 	}
@@ -362,7 +362,12 @@ func (t *quarkusTrait) applyWhenBuildSubmitted(e *Environment) error {
 	if err != nil {
 		return err
 	}
-	buildSteps = append(buildSteps, builder.Quarkus.CommonSteps...)
+	gradle := t.isGradleBuild(e)
+	if gradle {
+		buildSteps = append(buildSteps, builder.Gradle.CommonSteps...)
+	} else {
+		buildSteps = append(buildSteps, builder.Quarkus.CommonSteps...)
+	}
 
 	packageSteps, err := builder.StepsFrom(packageTask.Steps...)
 	if err != nil {
@@ -402,7 +407,12 @@ func (t *quarkusTrait) applyWhenBuildSubmitted(e *Environment) error {
 	} else {
 		// Default, if nothing is specified
 		buildTask.Maven.Properties["quarkus.package.jar.type"] = string(fastJarPackageType)
-		packageSteps = append(packageSteps, builder.Quarkus.ComputeQuarkusDependencies)
+		if gradle {
+			// The Gradle builds neither use the persistent Maven cache, nor resolve the dependency tree of the kit
+			packageSteps = append(packageSteps, builder.Gradle.ComputeGradleDependencies)
+		} else {
+			packageSteps = append(packageSteps, builder.Quarkus.ComputeQuarkusDependencies, builder.Quarkus.ComputeQuarkusDependencyTree)
+		}
 		if t.isIncrementalImageBuild(e) {
 			packageSteps = append(packageSteps, builder.Image.IncrementalImageContext)
 		} else {
@@ -448,6 +458,16 @@ func (t *quarkusTrait) isIncrementalImageBuild(e *Environment) bool {
 	return true
 }
 
+func (t *quarkusTrait) isGradleBuild(e *Environment) bool {
+	// We need to get this information from the builder trait
+	if trait := e.Catalog.GetTrait(builderTraitID); trait != nil {
+		builder, ok := trait.(*builderTrait)
+		return ok && builder.buildTool() == traitv1.GradleBuildTool
+	}
+
+	return false
+}
+
 func (t *quarkusTrait) applyWhenKitReady(e *Environment) error {
 	if e.IntegrationInRunningPhases() && t.isNativeIntegration(e) {
 		container := e.GetIntegrationContainer()
@@ -53,7 +53,30 @@ func TestConfigureQuarkusTraitBuildSubmitted(t *testing.T) {
 
 	packageTask := getPackageTask(environment.Pipeline)
 	assert.NotNil(t, t, packageTask)
-	assert.Len(t, packageTask.Steps, 4)
+	assert.Len(t, packageTask.Steps, 5)
+	assert.Contains(t, packageTask.Steps, builder.Quarkus.ComputeQuarkusDependencyTree.ID())
+}
+
+func TestConfigureQuarkusTraitGradleBuildSubmitted(t *testing.T) {
+	quarkusTrait, environment := createNominalQuarkusTest()
+	environment.IntegrationKit.Status.Phase = v1.IntegrationKitPhaseBuildSubmitted
+	builderTrait, _ := environment.Catalog.GetTrait(builderTraitID).(*builderTrait)
+	builderTrait.BuildTool = traitv1.GradleBuildTool
+
+	configured, _, err := quarkusTrait.Configure(environment)
+	assert.True(t, configured)
+	require.NoError(t, err)
+	err = quarkusTrait.Apply(environment)
+	require.NoError(t, err)
+
+	build := getBuilderTask(environment.Pipeline)
+	assert.Equal(t, builder.StepIDsFor(builder.Gradle.CommonSteps...), build.Steps)
+	assert.NotContains(t, build.Steps, builder.Quarkus.BuildQuarkusMavenProject.ID())
+
+	packageTask := getPackageTask(environment.Pipeline)
+	assert.Contains(t, packageTask.Steps, builder.Gradle.ComputeGradleDependencies.ID())
+	assert.NotContains(t, packageTask.Steps, builder.Quarkus.ComputeQuarkusDependencies.ID())
+	assert.NotContains(t, packageTask.Steps, builder.Quarkus.ComputeQuarkusDependencyTree.ID())
 }
 
 func TestConfigureQuarkusTraitNativeNotSupported(t *testing.T) {
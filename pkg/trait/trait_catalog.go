@@ -97,7 +97,7 @@ func (c *Catalog) apply(environment *Environment) ([]*TraitCondition, *v1.Traits
 
 	applicable := false
 	for _, trait := range traits {
-		if !environment.PlatformInPhase(v1.IntegrationPlatformPhaseReady) && trait.RequiresIntegrationPlatform() {
+		if !environment.platformReady() && trait.RequiresIntegrationPlatform() {
 			c.L.Debugf("Skipping trait because of missing integration platform: %s", trait.ID())
 
 			continue
@@ -131,7 +131,7 @@ func (c *Catalog) apply(environment *Environment) ([]*TraitCondition, *v1.Traits
 	}
 	traitsConditions = append(traitsConditions, cs)
 
-	if !applicable && environment.PlatformInPhase(v1.IntegrationPlatformPhaseReady) {
+	if !applicable && environment.platformReady() {
 		return traitsConditions, nil, errors.New("no trait can be executed because of no ready platform found")
 	}
 
@@ -224,6 +224,7 @@ type Environment struct {
 	PostStepProcessors    []func(*Environment) error
 	PostProcessors        []func(*Environment) error
 	Pipeline              []v1.Task
+	BuildRetryPolicy      *v1.BuildRetryPolicy
 	ConfiguredTraits      []Trait
 	ExecutedTraits        []Trait
 	EnvVars               []corev1.EnvVar
@@ -267,7 +268,7 @@ func (e *Environment) IntegrationInPhase(phases ...v1.IntegrationPhase) bool {
 }
 
 func (e *Environment) IntegrationInRunningPhases() bool {
-	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning, v1.IntegrationPhaseError)
+	return e.IntegrationInPhase(v1.IntegrationPhaseDeploying, v1.IntegrationPhaseRunning, v1.IntegrationPhaseError, v1.IntegrationPhaseSuspended)
 }
 
 func (e *Environment) IntegrationKitInPhase(phases ...v1.IntegrationKitPhase) bool {
@@ -298,6 +299,16 @@ func (e *Environment) PlatformInPhase(phases ...v1.IntegrationPlatformPhase) boo
 	return false
 }
 
+// platformReady returns whether the platform is ready for the traits requiring it. The kits can also be built while the
+// platform is warming, so that its warm kits are built before the Integrations.
+func (e *Environment) platformReady() bool {
+	if e.Integration == nil && e.IntegrationKit != nil {
+		return e.PlatformInPhase(v1.IntegrationPlatformPhaseReady, v1.IntegrationPlatformPhaseWarming)
+	}
+
+	return e.PlatformInPhase(v1.IntegrationPlatformPhaseReady)
+}
+
 func (e *Environment) InPhase(c v1.IntegrationKitPhase, i v1.IntegrationPhase) bool {
 	return e.IntegrationKitInPhase(c) && e.IntegrationInPhase(i)
 }
@@ -35,10 +35,10 @@ import (
 // CreateCatalog --.
 func CreateCatalog(
 	ctx context.Context, client client.Client, namespace string,
-	mavenSpec v1.MavenSpec, timeout time.Duration, runtime v1.RuntimeSpec, extraRepositories []string) (*RuntimeCatalog, error) {
+	mavenSpec v1.MavenSpec, registry v1.RegistrySpec, timeout time.Duration, runtime v1.RuntimeSpec, extraRepositories []string) (*RuntimeCatalog, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	catalog, err := GenerateCatalog(ctx, client, namespace, mavenSpec, runtime, extraRepositories)
+	catalog, err := GenerateCatalog(ctx, client, namespace, mavenSpec, registry, runtime, extraRepositories)
 	if err != nil {
 		return nil, err
 	}
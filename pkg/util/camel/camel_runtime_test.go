@@ -56,6 +56,7 @@ func TestCreateCatalog(t *testing.T) {
 		c,
 		"",
 		ip.Status.Build.Maven,
+		ip.Status.Build.Registry,
 		ip.Status.Build.GetTimeout().Duration,
 		v1.RuntimeSpec{Provider: v1.RuntimeProviderQuarkus, Version: defaults.DefaultRuntimeVersion},
 		nil,
@@ -23,15 +23,15 @@ import (
 
 	yaml2 "gopkg.in/yaml.v2"
 
-	ctrl "sigs.k8s.io/controller-runtime/pkg/client"
-
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
 	"github.com/apache/camel-k/v2/pkg/resources"
 	"github.com/apache/camel-k/v2/pkg/util"
 	"github.com/apache/camel-k/v2/pkg/util/defaults"
 	"github.com/apache/camel-k/v2/pkg/util/jvm"
 	"github.com/apache/camel-k/v2/pkg/util/kubernetes"
 	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/offline"
 )
 
 func DefaultCatalog() (*RuntimeCatalog, error) {
@@ -74,9 +74,10 @@ func catalogForRuntimeProvider(provider v1.RuntimeProvider) (*RuntimeCatalog, er
 
 func GenerateCatalog(
 	ctx context.Context,
-	client ctrl.Reader,
+	client client.Client,
 	namespace string,
 	mvn v1.MavenSpec,
+	registry v1.RegistrySpec,
 	runtime v1.RuntimeSpec,
 	extraRepositories []string) (*RuntimeCatalog, error) {
 
@@ -84,8 +85,17 @@ func GenerateCatalog(
 	if err != nil {
 		return nil, err
 	}
-	settings, err := maven.NewSettings(
-		maven.DefaultRepositories, maven.ProxyFromEnvironment, maven.Repositories(extraRepositories...))
+	options := []maven.SettingsOption{
+		maven.DefaultRepositories, maven.ProxyFromEnvironment, maven.Repositories(extraRepositories...),
+	}
+	if mvn.Offline != nil {
+		dir, err := offline.Resolve(ctx, client, namespace, registry, mvn.Offline.Bundle)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, maven.OfflineRepository(dir))
+	}
+	settings, err := maven.NewSettings(options...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +131,7 @@ func GenerateCatalogCommon(
 		mc := maven.NewContext(tmpDir)
 		mc.LocalRepository = mvn.LocalRepository
 		mc.AdditionalArguments = mvn.CLIOptions
+		mc.Offline = mvn.Offline != nil
 		mc.AddSystemProperty("catalog.path", tmpDir)
 		mc.AddSystemProperty("catalog.file", "catalog.yaml")
 		mc.AddSystemProperty("catalog.runtime", string(runtime.Provider))
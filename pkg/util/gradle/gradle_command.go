@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/camel-k/v2/pkg/util"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+)
+
+var Log = log.WithName("gradle")
+
+type Command struct {
+	context Context
+	project Project
+}
+
+// NewContext returns a Gradle Context building the project in the given directory.
+func NewContext(buildDir string) Context {
+	return Context{
+		Path:             buildDir,
+		SystemProperties: make(map[string]string),
+	}
+}
+
+// AddArgument adds an argument, e.g. a task, to the Gradle command line.
+func (c *Context) AddArgument(argument string) {
+	c.AdditionalArguments = append(c.AdditionalArguments, argument)
+}
+
+// Command returns the Gradle command building the project in the given context.
+func (p *Project) Command(context Context) *Command {
+	return &Command{
+		context: context,
+		project: *p,
+	}
+}
+
+// DoProject is in charge to generate the build, settings, properties and init scripts of the project.
+func (c *Command) DoProject() error {
+	files := map[string][]byte{
+		BuildFile:      c.project.BuildScript(),
+		SettingsFile:   c.project.SettingsScript(),
+		PropertiesFile: c.context.Properties(),
+		InitScriptFile: c.context.InitScript(),
+	}
+	for name, content := range files {
+		if err := util.WriteFileWithContent(filepath.Join(c.context.Path, name), content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Do is in charge to execute the Gradle build. The Gradle command is driven by the GRADLE_CMD environment variable,
+// and defaults to the gradle executable found on the PATH.
+func (c *Command) Do(ctx context.Context) error {
+	gradleCmd := "gradle"
+	if cmd, ok := os.LookupEnv("GRADLE_CMD"); ok && cmd != "" {
+		gradleCmd = cmd
+	}
+
+	args := []string{"--no-daemon", "--console=plain", "--init-script", InitScriptFile}
+	args = append(args, c.context.AdditionalArguments...)
+
+	cmd := exec.CommandContext(ctx, gradleCmd, args...)
+	cmd.Dir = c.context.Path
+
+	Log.Infof("executing: %s", strings.Join(cmd.Args, " "))
+	return util.RunAndLog(ctx, cmd, LogHandler, LogHandler)
+}
+
+// LogHandler logs the output of the Gradle build, and returns the causes of a build failure, that Gradle prints
+// prefixed with "> ", so that they are reported.
+func LogHandler(s string) string {
+	Log.Info(s)
+	if strings.HasPrefix(s, "> ") && !strings.HasPrefix(s, "> Task ") && !strings.HasPrefix(s, "> Configure ") {
+		return strings.TrimPrefix(s, "> ")
+	}
+
+	return ""
+}
@@ -0,0 +1,167 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+const (
+	// quarkusMavenPlugin is the artifact id of the Maven plugin the version of the Quarkus Gradle plugin is taken from.
+	quarkusMavenPlugin = "quarkus-maven-plugin"
+	// quarkusPluginMarker is the marker artifact Gradle resolves the io.quarkus plugin from, that depends on the
+	// plugin implementation.
+	quarkusPluginMarker = "io.quarkus:io.quarkus.gradle.plugin"
+)
+
+// QuarkusPluginGoal returns the Maven goal resolving the io.quarkus Gradle plugin of the given version, along with
+// its dependencies, e.g. to add them to an offline bundle.
+func QuarkusPluginGoal(version string) []string {
+	return []string{
+		"org.apache.maven.plugins:maven-dependency-plugin:" + maven.DependencyPluginVersion + ":get",
+		"-Dartifact=" + quarkusPluginMarker + ":" + version + ":pom",
+		"-Dtransitive=true",
+	}
+}
+
+// NewProjectFromMaven converts the given Maven project into a Gradle project. The BOMs imported by the Maven project
+// become enforced platforms, and the version of the Quarkus Maven plugin is used for the Quarkus Gradle plugin.
+// Maven profiles, build extensions and plugins other than the Quarkus one have no Gradle equivalent and are ignored.
+func NewProjectFromMaven(p maven.Project) Project {
+	project := Project{
+		Group:   p.GroupID,
+		Name:    p.ArtifactID,
+		Version: p.Version,
+	}
+
+	if p.DependencyManagement != nil {
+		for _, d := range p.DependencyManagement.Dependencies {
+			if d.Scope == "import" && d.Type == "pom" {
+				project.Platforms = append(project.Platforms, d)
+			} else {
+				project.Constraints = append(project.Constraints, d)
+			}
+		}
+	}
+	project.Dependencies = append(project.Dependencies, p.Dependencies...)
+
+	if p.Build != nil {
+		for _, plugin := range p.Build.Plugins {
+			if plugin.ArtifactID == quarkusMavenPlugin {
+				project.QuarkusPluginVersion = plugin.Version
+			}
+		}
+	}
+
+	return project
+}
+
+// BuildScript returns the content of the build.gradle file of the project.
+func (p Project) BuildScript() []byte {
+	var b strings.Builder
+
+	b.WriteString("plugins {\n")
+	b.WriteString("    id 'java'\n")
+	if p.QuarkusPluginVersion != "" {
+		fmt.Fprintf(&b, "    id 'io.quarkus' version %s\n", quote(p.QuarkusPluginVersion))
+	} else {
+		b.WriteString("    id 'io.quarkus'\n")
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "group = %s\n", quote(p.Group))
+	fmt.Fprintf(&b, "version = %s\n\n", quote(p.Version))
+
+	b.WriteString("dependencies {\n")
+	for _, d := range p.Platforms {
+		fmt.Fprintf(&b, "    implementation enforcedPlatform(%s)\n", quote(notation(d)))
+	}
+	if len(p.Constraints) > 0 {
+		b.WriteString("    constraints {\n")
+		for _, d := range p.Constraints {
+			fmt.Fprintf(&b, "        implementation %s\n", quote(notation(d)))
+		}
+		b.WriteString("    }\n")
+	}
+	for _, d := range p.Dependencies {
+		configuration := configurationOf(d.Scope)
+		if configuration == "" {
+			continue
+		}
+		if d.Exclusions == nil || len(*d.Exclusions) == 0 {
+			fmt.Fprintf(&b, "    %s %s\n", configuration, quote(notation(d)))
+			continue
+		}
+		fmt.Fprintf(&b, "    %s(%s) {\n", configuration, quote(notation(d)))
+		for _, e := range *d.Exclusions {
+			if e.ArtifactID == "" || e.ArtifactID == "*" {
+				fmt.Fprintf(&b, "        exclude group: %s\n", quote(e.GroupID))
+			} else {
+				fmt.Fprintf(&b, "        exclude group: %s, module: %s\n", quote(e.GroupID), quote(e.ArtifactID))
+			}
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+
+	return []byte(b.String())
+}
+
+// SettingsScript returns the content of the settings.gradle file of the project.
+func (p Project) SettingsScript() []byte {
+	return []byte(fmt.Sprintf("rootProject.name = %s\n", quote(p.Name)))
+}
+
+// configurationOf returns the Gradle configuration matching the given Maven scope, or an empty string if the
+// dependency is not needed to build the application.
+func configurationOf(scope string) string {
+	switch scope {
+	case "", "compile":
+		return "implementation"
+	case "runtime":
+		return "runtimeOnly"
+	case "provided":
+		return "compileOnly"
+	default:
+		return ""
+	}
+}
+
+// notation returns the Gradle string notation of the dependency, i.e. group:name[:version[:classifier]][@type].
+func notation(d maven.Dependency) string {
+	gav := d.GroupID + ":" + d.ArtifactID
+	if d.Version != "" || d.Classifier != "" {
+		gav += ":" + d.Version
+	}
+	if d.Classifier != "" {
+		gav += ":" + d.Classifier
+	}
+	if d.Type != "" && d.Type != "jar" && d.Scope != "import" {
+		gav += "@" + d.Type
+	}
+
+	return gav
+}
+
+// quote returns the given value as a Groovy single-quoted string.
+func quote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+func TestNewProjectFromMaven(t *testing.T) {
+	p := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	p.DependencyManagement = &maven.DependencyManagement{
+		Dependencies: []maven.Dependency{
+			{GroupID: "org.apache.camel.k", ArtifactID: "camel-k-runtime-bom", Version: "3.2.0", Type: "pom", Scope: "import"},
+			{GroupID: "org.acme", ArtifactID: "managed", Version: "1.1"},
+		},
+	}
+	p.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-timer", "")
+	p.AddDependencies(
+		maven.Dependency{GroupID: "org.acme", ArtifactID: "api", Version: "1.0", Scope: "provided"},
+		maven.Dependency{GroupID: "org.acme", ArtifactID: "tests", Version: "1.0", Scope: "test"},
+		maven.Dependency{GroupID: "org.acme", ArtifactID: "native", Version: "1.0", Classifier: "linux", Type: "zip"},
+		maven.Dependency{GroupID: "org.acme", ArtifactID: "lib", Version: "2.0"},
+	)
+	p.AddDependencyExclusions(
+		maven.Dependency{GroupID: "org.acme", ArtifactID: "lib", Version: "2.0"},
+		maven.Exclusion{GroupID: "commons-logging", ArtifactID: "commons-logging"},
+		maven.Exclusion{GroupID: "org.slf4j", ArtifactID: "*"},
+	)
+	p.Build = &maven.Build{Plugins: []maven.Plugin{{GroupID: "io.quarkus", ArtifactID: "quarkus-maven-plugin", Version: "3.8.1"}}}
+
+	project := NewProjectFromMaven(p)
+
+	assert.Equal(t, "3.8.1", project.QuarkusPluginVersion)
+	assert.Equal(t, `plugins {
+    id 'java'
+    id 'io.quarkus' version '3.8.1'
+}
+
+group = 'org.apache.camel.k.integration'
+version = '1.0.0'
+
+dependencies {
+    implementation enforcedPlatform('org.apache.camel.k:camel-k-runtime-bom:3.2.0')
+    constraints {
+        implementation 'org.acme:managed:1.1'
+    }
+    implementation 'org.apache.camel.quarkus:camel-quarkus-timer'
+    compileOnly 'org.acme:api:1.0'
+    implementation 'org.acme:native:1.0:linux@zip'
+    implementation('org.acme:lib:2.0') {
+        exclude group: 'commons-logging', module: 'commons-logging'
+        exclude group: 'org.slf4j'
+    }
+}
+`, string(project.BuildScript()))
+	assert.Equal(t, "rootProject.name = 'camel-k-integration'\n", string(project.SettingsScript()))
+}
+
+func TestQuarkusPluginGoal(t *testing.T) {
+	assert.Equal(t, []string{
+		"org.apache.maven.plugins:maven-dependency-plugin:" + maven.DependencyPluginVersion + ":get",
+		"-Dartifact=io.quarkus:io.quarkus.gradle.plugin:3.8.1:pom",
+		"-Dtransitive=true",
+	}, QuarkusPluginGoal("3.8.1"))
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `'it\'s'`, quote("it's"))
+	assert.Equal(t, `'a\\b'`, quote(`a\b`))
+}
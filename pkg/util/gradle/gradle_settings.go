@@ -0,0 +1,237 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+// ParseSettings parses the given Maven settings. Empty settings are skipped.
+func ParseSettings(data ...[]byte) ([]maven.Settings, error) {
+	settings := make([]maven.Settings, 0, len(data))
+	for _, d := range data {
+		if len(d) == 0 {
+			continue
+		}
+		var s maven.Settings
+		if err := xml.Unmarshal(d, &s); err != nil {
+			return nil, fmt.Errorf("unable to parse Maven settings: %w", err)
+		}
+		settings = append(settings, s)
+	}
+
+	return settings, nil
+}
+
+// Configure sets the repositories and the proxies of the context from the given Maven settings, in the order of
+// precedence. The repositories of the settings profiles that are active by default come first, followed by the
+// given project repositories. The repositories matched by a mirror are replaced by the mirror, the mirror of an
+// offline bundle taking precedence over the mirrors of all the settings, and the credentials of the servers are set
+// on the repositories with the same id.
+func (c *Context) Configure(repositories []v1.Repository, settings ...maven.Settings) {
+	all := make([]v1.Repository, 0)
+	for _, s := range settings {
+		for _, p := range s.Profiles {
+			if p.Repositories == nil || (p.Activation != nil && !p.Activation.ActiveByDefault) {
+				continue
+			}
+			all = append(all, *p.Repositories...)
+		}
+	}
+	all = append(all, repositories...)
+
+	servers := make(map[string]v1.Server)
+	mirrors := make([]maven.Mirror, 0)
+	for _, s := range settings {
+		for _, server := range s.Servers {
+			if _, ok := servers[server.ID]; !ok {
+				servers[server.ID] = server
+			}
+		}
+		mirrors = append(mirrors, s.Mirrors...)
+	}
+	// The offline bundle mirrors all the repositories, whatever the mirrors of the user settings
+	sort.SliceStable(mirrors, func(i, j int) bool {
+		return mirrors[i].ID == maven.OfflineRepositoryID && mirrors[j].ID != maven.OfflineRepositoryID
+	})
+
+	c.Repositories = make([]Repository, 0, len(all))
+	index := make(map[string]int)
+	for _, r := range all {
+		id, u := r.ID, r.URL
+		for _, m := range mirrors {
+			if matchesMirrorOf(m.MirrorOf, r) {
+				id, u = m.ID, m.URL
+				break
+			}
+		}
+		if i, ok := index[u]; ok {
+			// The repositories sharing a location, e.g. through a mirror, are merged
+			c.Repositories[i].Releases = c.Repositories[i].Releases || r.Releases.Enabled
+			c.Repositories[i].Snapshots = c.Repositories[i].Snapshots || r.Snapshots.Enabled
+			continue
+		}
+		repository := Repository{
+			Name:      id,
+			URL:       u,
+			Releases:  r.Releases.Enabled,
+			Snapshots: r.Snapshots.Enabled,
+		}
+		if server, ok := servers[id]; ok {
+			repository.Username = server.Username
+			repository.Password = server.Password
+		}
+		index[u] = len(c.Repositories)
+		c.Repositories = append(c.Repositories, repository)
+	}
+
+	for _, s := range settings {
+		for _, proxy := range s.Proxies {
+			c.addProxy(proxy)
+		}
+	}
+}
+
+// addProxy sets the system properties configuring the given proxy, unless a proxy is already set for its protocol.
+func (c *Context) addProxy(proxy maven.Proxy) {
+	if !proxy.Active || (proxy.Protocol != "http" && proxy.Protocol != "https") {
+		return
+	}
+	prefix := "systemProp." + proxy.Protocol + "."
+	if _, ok := c.SystemProperties[prefix+"proxyHost"]; ok {
+		return
+	}
+	if c.SystemProperties == nil {
+		c.SystemProperties = make(map[string]string)
+	}
+	c.SystemProperties[prefix+"proxyHost"] = proxy.Host
+	if proxy.Port != "" {
+		c.SystemProperties[prefix+"proxyPort"] = proxy.Port
+	}
+	if proxy.Username != "" {
+		c.SystemProperties[prefix+"proxyUser"] = proxy.Username
+		c.SystemProperties[prefix+"proxyPassword"] = proxy.Password
+	}
+	if proxy.NonProxyHosts != "" {
+		c.SystemProperties["systemProp.http.nonProxyHosts"] = proxy.NonProxyHosts
+	}
+}
+
+// matchesMirrorOf returns whether the repository is matched by the mirrorOf pattern of a mirror, following the Maven
+// semantics: a comma separated list of repository ids, `*`, `external:*` or `external:http:*`, where ids prefixed
+// with `!` are excluded.
+func matchesMirrorOf(mirrorOf string, r v1.Repository) bool {
+	matches := false
+	for _, pattern := range strings.Split(mirrorOf, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case strings.HasPrefix(pattern, "!") && pattern[1:] == r.ID:
+			return false
+		case pattern == r.ID:
+			return true
+		case pattern == "*":
+			matches = true
+		case pattern == "external:*" && isExternal(r.URL):
+			matches = true
+		case pattern == "external:http:*" && isExternal(r.URL) && strings.HasPrefix(r.URL, "http:"):
+			matches = true
+		}
+	}
+
+	return matches
+}
+
+func isExternal(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+
+	return u.Scheme != "file" && host != "localhost" && host != "127.0.0.1"
+}
+
+// InitScript returns the content of the Gradle init script declaring the repositories of the context, both for
+// resolving the dependencies and the plugins of the build.
+func (c *Context) InitScript() []byte {
+	var b strings.Builder
+
+	b.WriteString("// Generated by Camel K\n")
+	b.WriteString("beforeSettings { settings ->\n")
+	b.WriteString("    settings.pluginManagement.repositories {\n")
+	writeRepositories(&b, c.Repositories, "        ")
+	b.WriteString("    }\n")
+	b.WriteString("}\n\n")
+	b.WriteString("allprojects {\n")
+	b.WriteString("    repositories {\n")
+	writeRepositories(&b, c.Repositories, "        ")
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+
+	return []byte(b.String())
+}
+
+func writeRepositories(b *strings.Builder, repositories []Repository, indent string) {
+	for _, r := range repositories {
+		fmt.Fprintf(b, "%smaven {\n", indent)
+		if r.Name != "" {
+			fmt.Fprintf(b, "%s    name = %s\n", indent, quote(r.Name))
+		}
+		fmt.Fprintf(b, "%s    url = %s\n", indent, quote(r.URL))
+		if strings.HasPrefix(r.URL, "http:") {
+			fmt.Fprintf(b, "%s    allowInsecureProtocol = true\n", indent)
+		}
+		if r.Username != "" {
+			fmt.Fprintf(b, "%s    credentials {\n", indent)
+			fmt.Fprintf(b, "%s        username = %s\n", indent, quote(r.Username))
+			fmt.Fprintf(b, "%s        password = %s\n", indent, quote(r.Password))
+			fmt.Fprintf(b, "%s    }\n", indent)
+		}
+		switch {
+		case r.Releases && !r.Snapshots:
+			fmt.Fprintf(b, "%s    mavenContent { releasesOnly() }\n", indent)
+		case r.Snapshots && !r.Releases:
+			fmt.Fprintf(b, "%s    mavenContent { snapshotsOnly() }\n", indent)
+		}
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
+
+// Properties returns the content of the gradle.properties file of the context.
+func (c *Context) Properties() []byte {
+	keys := make([]string, 0, len(c.SystemProperties))
+	for k := range c.SystemProperties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("org.gradle.daemon=false\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", k, c.SystemProperties[k])
+	}
+
+	return []byte(b.String())
+}
@@ -0,0 +1,177 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+const userSettings = `<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0">
+  <servers>
+    <server>
+      <id>internal</id>
+      <username>user</username>
+      <password>pass</password>
+    </server>
+  </servers>
+  <mirrors>
+    <mirror>
+      <id>internal</id>
+      <url>https://nexus.acme.org/repository/maven</url>
+      <mirrorOf>external:*,!snapshots</mirrorOf>
+    </mirror>
+  </mirrors>
+  <proxies>
+    <proxy>
+      <id>proxy</id>
+      <active>true</active>
+      <protocol>http</protocol>
+      <host>proxy.acme.org</host>
+      <port>3128</port>
+      <nonProxyHosts>localhost|*.acme.org</nonProxyHosts>
+    </proxy>
+  </proxies>
+</settings>
+`
+
+func TestConfigureFromSettings(t *testing.T) {
+	global, err := maven.NewSettings(maven.DefaultRepositories)
+	require.NoError(t, err)
+	globalSettings, err := global.MarshalBytes()
+	require.NoError(t, err)
+
+	settings, err := ParseSettings([]byte(userSettings), nil, globalSettings)
+	require.NoError(t, err)
+	assert.Len(t, settings, 2)
+
+	c := NewContext("/tmp/gradle")
+	c.Configure([]v1.Repository{
+		maven.NewRepository("https://repo.acme.org/snapshots@id=snapshots@snapshots@noreleases"),
+		maven.NewRepository("http://localhost:8081/repository@id=local"),
+		maven.NewRepository("https://repo.acme.org/releases@id=releases@snapshots"),
+	}, settings...)
+
+	assert.Equal(t, []Repository{
+		{Name: "internal", URL: "https://nexus.acme.org/repository/maven", Username: "user", Password: "pass", Releases: true, Snapshots: true},
+		{Name: "snapshots", URL: "https://repo.acme.org/snapshots", Snapshots: true},
+		{Name: "local", URL: "http://localhost:8081/repository", Releases: true},
+	}, c.Repositories)
+	assert.Equal(t, map[string]string{
+		"systemProp.http.proxyHost":     "proxy.acme.org",
+		"systemProp.http.proxyPort":     "3128",
+		"systemProp.http.nonProxyHosts": "localhost|*.acme.org",
+	}, c.SystemProperties)
+}
+
+func TestConfigureWithOfflineBundle(t *testing.T) {
+	global, err := maven.NewSettings(maven.DefaultRepositories, maven.OfflineRepository("/tmp/bundle"))
+	require.NoError(t, err)
+	globalSettings, err := global.MarshalBytes()
+	require.NoError(t, err)
+
+	// The mirror of the user settings must not preempt the offline bundle
+	settings, err := ParseSettings([]byte(userSettings), globalSettings)
+	require.NoError(t, err)
+
+	c := NewContext("/tmp/gradle")
+	c.Configure([]v1.Repository{
+		maven.NewRepository("https://repo.acme.org/releases@id=releases"),
+	}, settings...)
+
+	assert.Equal(t, []Repository{
+		{Name: maven.OfflineRepositoryID, URL: "file:///tmp/bundle", Releases: true},
+	}, c.Repositories)
+}
+
+func TestParseInvalidSettings(t *testing.T) {
+	_, err := ParseSettings([]byte("<settings"))
+	require.Error(t, err)
+}
+
+func TestMatchesMirrorOf(t *testing.T) {
+	central := v1.Repository{ID: "central", URL: "https://repo.maven.apache.org/maven2"}
+	local := v1.Repository{ID: "local", URL: "file:///tmp/repository"}
+	insecure := v1.Repository{ID: "insecure", URL: "http://repo.acme.org/maven"}
+
+	assert.True(t, matchesMirrorOf("*", local))
+	assert.True(t, matchesMirrorOf("central", central))
+	assert.True(t, matchesMirrorOf("other, central", central))
+	assert.False(t, matchesMirrorOf("*,!central", central))
+	assert.True(t, matchesMirrorOf("external:*", central))
+	assert.False(t, matchesMirrorOf("external:*", local))
+	assert.False(t, matchesMirrorOf("external:http:*", central))
+	assert.True(t, matchesMirrorOf("external:http:*", insecure))
+}
+
+func TestInitScript(t *testing.T) {
+	c := NewContext("/tmp/gradle")
+	c.Repositories = []Repository{
+		{Name: "internal", URL: "https://nexus.acme.org/repository/maven", Username: "user", Password: "pass", Releases: true, Snapshots: true},
+		{Name: "local", URL: "http://localhost:8081/repository", Releases: true},
+	}
+
+	assert.Equal(t, `// Generated by Camel K
+beforeSettings { settings ->
+    settings.pluginManagement.repositories {
+        maven {
+            name = 'internal'
+            url = 'https://nexus.acme.org/repository/maven'
+            credentials {
+                username = 'user'
+                password = 'pass'
+            }
+        }
+        maven {
+            name = 'local'
+            url = 'http://localhost:8081/repository'
+            allowInsecureProtocol = true
+            mavenContent { releasesOnly() }
+        }
+    }
+}
+
+allprojects {
+    repositories {
+        maven {
+            name = 'internal'
+            url = 'https://nexus.acme.org/repository/maven'
+            credentials {
+                username = 'user'
+                password = 'pass'
+            }
+        }
+        maven {
+            name = 'local'
+            url = 'http://localhost:8081/repository'
+            allowInsecureProtocol = true
+            mavenContent { releasesOnly() }
+        }
+    }
+}
+`, string(c.InitScript()))
+
+	c.SystemProperties["systemProp.https.proxyHost"] = "proxy"
+	assert.Equal(t, "org.gradle.daemon=false\nsystemProp.https.proxyHost=proxy\n", string(c.Properties()))
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import "github.com/apache/camel-k/v2/pkg/util/maven"
+
+const (
+	// BuildFile is the name of the Gradle build script of the project.
+	BuildFile = "build.gradle"
+	// SettingsFile is the name of the Gradle settings script of the project.
+	SettingsFile = "settings.gradle"
+	// PropertiesFile is the name of the Gradle properties file of the project.
+	PropertiesFile = "gradle.properties"
+	// InitScriptFile is the name of the Gradle init script holding the repositories of the build.
+	InitScriptFile = "init.gradle"
+)
+
+// Project models a Gradle project building a Quarkus application.
+type Project struct {
+	Group   string
+	Name    string
+	Version string
+	// QuarkusPluginVersion is the version of the io.quarkus Gradle plugin.
+	QuarkusPluginVersion string
+	// Platforms are the BOMs the dependency versions are enforced from.
+	Platforms []maven.Dependency
+	// Constraints are the managed dependencies that are not BOMs.
+	Constraints  []maven.Dependency
+	Dependencies []maven.Dependency
+}
+
+// Repository models a Maven repository, as declared in a Gradle script.
+type Repository struct {
+	Name      string
+	URL       string
+	Username  string
+	Password  string
+	Releases  bool
+	Snapshots bool
+}
+
+// Context holds the configuration of a Gradle build.
+type Context struct {
+	Path                string
+	AdditionalArguments []string
+	// SystemProperties are set in the gradle.properties file of the project, e.g. for proxies or trust stores.
+	SystemProperties map[string]string
+	Repositories     []Repository
+}
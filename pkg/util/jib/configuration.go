@@ -33,6 +33,11 @@ const JibDigestFile = "target/jib-image.digest"
 const JibMavenPluginVersionDefault = "3.4.1"
 const JibLayerFilterExtensionMavenVersionDefault = "0.3.0"
 
+// The creation time of the images and the modification time of their files, fixed so that the images built by Jib
+// are reproducible.
+const JibCreationTime = "EPOCH"
+const JibFilesModificationTime = "EPOCH_PLUS_SECOND"
+
 // See: https://github.com/GoogleContainerTools/jib/blob/master/jib-maven-plugin/README.md#using-docker-configuration-files
 const JibRegistryConfigEnvVar = "DOCKER_CONFIG"
 
@@ -65,6 +70,8 @@ const XMLJibProfile = `
             <args>
               <arg>jshell</arg>
             </args>
+            <creationTime>EPOCH</creationTime>
+            <filesModificationTime>EPOCH_PLUS_SECOND</filesModificationTime>
           </container>
           <allowInsecureRegistries>true</allowInsecureRegistries>
           <extraDirectories>
@@ -120,6 +127,8 @@ func JibMavenProfile(jibMavenPluginVersion string, jibLayerFilterExtensionMavenV
 				Args: v1.Args{
 					Arg: "jshell",
 				},
+				CreationTime:          JibCreationTime,
+				FilesModificationTime: JibFilesModificationTime,
 			},
 			AllowInsecureRegistries: "true",
 			ExtraDirectories: v1.ExtraDirectories{
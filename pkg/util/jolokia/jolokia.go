@@ -0,0 +1,260 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jolokia
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultPort is the default port the Jolokia agent listens to, as configured by the jolokia trait.
+	DefaultPort = 8778
+	// DefaultPath is the default path of the Jolokia agent endpoint.
+	DefaultPath = "/jolokia/"
+
+	// CamelContextMBeanPattern is the pattern matching the Camel context MBeans.
+	CamelContextMBeanPattern = "org.apache.camel:type=context,*"
+	// CamelRouteMBeanPattern is the pattern matching the Camel route MBeans.
+	CamelRouteMBeanPattern = "org.apache.camel:type=routes,*"
+)
+
+// Client is a minimal Jolokia agent client.
+type Client struct {
+	URL        string
+	User       string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// Request is a Jolokia request, as defined in https://jolokia.org/reference/html/manual/jolokia_protocol.html.
+type Request struct {
+	Type      string        `json:"type"`
+	MBean     string        `json:"mbean,omitempty"`
+	Attribute interface{}   `json:"attribute,omitempty"`
+	Operation string        `json:"operation,omitempty"`
+	Arguments []interface{} `json:"arguments,omitempty"`
+}
+
+// Response is a Jolokia response.
+type Response struct {
+	Status    int             `json:"status"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	ErrorType string          `json:"error_type,omitempty"`
+}
+
+// NewClient creates a Jolokia client targeting the agent listening to the given address (host:port).
+func NewClient(address string) *Client {
+	return &Client{
+		URL:        "http://" + address + DefaultPath,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// AgentOptions returns the options of the Jolokia JVM agent found in the given container arguments, as set by the
+// jolokia trait, e.g. `-javaagent:/deployments/dependencies/jolokia-agent-jvm.jar=port=8778,protocol=https`.
+func AgentOptions(args []string) map[string]string {
+	options := make(map[string]string)
+	for _, arg := range args {
+		agent, found := strings.CutPrefix(arg, "-javaagent:")
+		if !found || !strings.Contains(agent, "jolokia") {
+			continue
+		}
+		_, values, _ := strings.Cut(agent, "=")
+		for _, o := range strings.Split(values, ",") {
+			if k, v, ok := strings.Cut(o, "="); ok {
+				options[k] = v
+			}
+		}
+	}
+
+	return options
+}
+
+// NewClientForAgent creates a Jolokia client targeting the agent listening to the given address (host:port),
+// configured with the given agent options, i.e. its protocol, credentials and SSL client authentication. The
+// client certificate and key files are presented to the agents over HTTPS, and required by the agents enforcing
+// SSL client authentication.
+func NewClientForAgent(address string, options map[string]string, certFile string, keyFile string) (*Client, error) {
+	c := NewClient(address)
+	c.User = options["user"]
+	c.Password = options["password"]
+	if options["protocol"] != "https" {
+		return c, nil
+	}
+
+	c.URL = "https://" + address + DefaultPath
+	// The agent is reached through a port forward, so that its certificate, either self-signed or issued for the
+	// in-cluster service, cannot match the forwarded address. The tunnel itself is authenticated by the API server.
+	//nolint:gosec
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the Jolokia client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	} else if options["useSslClientAuthentication"] == "true" {
+		return nil, errors.New("the Jolokia agent requires SSL client authentication, " +
+			"a client certificate whose principal is allowed by the agent must be provided")
+	}
+	c.HTTPClient = &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+
+	return c, nil
+}
+
+// Search returns the sorted names of the MBeans matching the given pattern.
+func (c *Client) Search(ctx context.Context, pattern string) ([]string, error) {
+	names := make([]string, 0)
+	if err := c.Do(ctx, Request{Type: "search", MBean: pattern}, &names); err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Read reads the given attributes of an MBean. All the attributes are read when none is provided.
+func (c *Client) Read(ctx context.Context, mbean string, result interface{}, attributes ...string) error {
+	req := Request{Type: "read", MBean: mbean}
+	if len(attributes) > 0 {
+		req.Attribute = attributes
+	}
+
+	return c.Do(ctx, req, result)
+}
+
+// Exec executes an MBean operation. The operation signature must be provided when the operation is overloaded,
+// e.g. `sendBody(java.lang.String,java.lang.Object)`.
+func (c *Client) Exec(ctx context.Context, mbean string, operation string, result interface{}, arguments ...interface{}) error {
+	return c.Do(ctx, Request{Type: "exec", MBean: mbean, Operation: operation, Arguments: arguments}, result)
+}
+
+// Do sends a single request to the agent and decodes the response value into result, which can be nil.
+func (c *Client) Do(ctx context.Context, request Request, result interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Password)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %q from Jolokia agent at %s", resp.Status, c.URL)
+	}
+
+	response := Response{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("cannot decode Jolokia response: %w", err)
+	}
+	if response.Status != http.StatusOK {
+		if response.Error == "" {
+			return fmt.Errorf("jolokia %s request failed with status %d", request.Type, response.Status)
+		}
+		return errors.New(response.Error)
+	}
+
+	if result == nil || len(response.Value) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(response.Value, result)
+}
+
+// MBeanProperty returns the value of a key property of an MBean object name, e.g. `name` for
+// `org.apache.camel:context=camel-1,type=routes,name="route1"`. Quoted values, that can contain commas and
+// escaped quotes, are unquoted.
+func MBeanProperty(objectName string, key string) string {
+	_, properties, found := strings.Cut(objectName, ":")
+	if !found {
+		return ""
+	}
+	for properties != "" {
+		k, rest, ok := strings.Cut(properties, "=")
+		if !ok {
+			return ""
+		}
+		var value string
+		value, properties = mbeanPropertyValue(rest)
+		if k == key {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// mbeanPropertyValue parses the value at the beginning of the given key properties, and returns it along with the
+// remaining key properties.
+func mbeanPropertyValue(properties string) (string, string) {
+	if !strings.HasPrefix(properties, `"`) {
+		value, rest, _ := strings.Cut(properties, ",")
+		return value, rest
+	}
+
+	value := strings.Builder{}
+	for i := 1; i < len(properties); i++ {
+		switch c := properties[i]; c {
+		case '\\':
+			if i+1 < len(properties) {
+				i++
+				if properties[i] == 'n' {
+					value.WriteByte('\n')
+				} else {
+					value.WriteByte(properties[i])
+				}
+			}
+		case '"':
+			return value.String(), strings.TrimPrefix(properties[i+1:], ",")
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return value.String(), ""
+}
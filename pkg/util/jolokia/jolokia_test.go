@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jolokia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := Request{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch req.Type {
+		case "search":
+			_, _ = w.Write([]byte(`{"status":200,"value":["org.apache.camel:context=camel-1,type=routes,name=\"route2\"","org.apache.camel:context=camel-1,type=routes,name=\"route1\""]}`))
+		case "read":
+			_, _ = w.Write([]byte(`{"status":200,"value":{"State":"Started","ExchangesTotal":12}}`))
+		case "exec":
+			assert.Equal(t, "requestBody(java.lang.String,java.lang.Object)", req.Operation)
+			assert.Equal(t, []interface{}{"direct:foo", "hello"}, req.Arguments)
+			_, _ = w.Write([]byte(`{"status":200,"value":"HELLO"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":400,"error":"unsupported type"}`))
+		}
+	}))
+}
+
+func TestSearch(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := NewClient(strings.TrimPrefix(server.URL, "http://"))
+	names, err := c.Search(context.TODO(), CamelRouteMBeanPattern)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`org.apache.camel:context=camel-1,type=routes,name="route1"`,
+		`org.apache.camel:context=camel-1,type=routes,name="route2"`,
+	}, names)
+	assert.Equal(t, "route1", MBeanProperty(names[0], "name"))
+	assert.Equal(t, "camel-1", MBeanProperty(names[0], "context"))
+	assert.Empty(t, MBeanProperty(names[0], "missing"))
+}
+
+func TestReadAndExec(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := NewClient(strings.TrimPrefix(server.URL, "http://"))
+
+	attributes := struct {
+		State          string
+		ExchangesTotal int64
+	}{}
+	require.NoError(t, c.Read(context.TODO(), "org.apache.camel:type=routes,name=route1", &attributes, "State", "ExchangesTotal"))
+	assert.Equal(t, "Started", attributes.State)
+	assert.Equal(t, int64(12), attributes.ExchangesTotal)
+
+	reply := ""
+	require.NoError(t, c.Exec(context.TODO(), "org.apache.camel:type=context", "requestBody(java.lang.String,java.lang.Object)", &reply, "direct:foo", "hello"))
+	assert.Equal(t, "HELLO", reply)
+
+	err := c.Do(context.TODO(), Request{Type: "list"}, nil)
+	require.EqualError(t, err, "unsupported type")
+}
+
+func TestAgentOptions(t *testing.T) {
+	options := AgentOptions([]string{
+		"-cp", "/deployments/dependencies/*",
+		"-javaagent:/deployments/dependencies/org.jolokia.jolokia-agent-jvm-2.0.0-javaagent.jar=host=*,port=8778,protocol=https,useSslClientAuthentication=true",
+	})
+	assert.Equal(t, map[string]string{
+		"host":                       "*",
+		"port":                       "8778",
+		"protocol":                   "https",
+		"useSslClientAuthentication": "true",
+	}, options)
+	assert.Empty(t, AgentOptions([]string{"-javaagent:/deployments/opentelemetry-agent.jar=a=b"}))
+}
+
+func TestNewClientForAgent(t *testing.T) {
+	server := httptest.NewTLSServer(newTestServer(t).Config.Handler)
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	c, err := NewClientForAgent(address, map[string]string{"user": "admin", "password": "secret"}, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "http://"+address+DefaultPath, c.URL)
+	assert.Equal(t, "admin", c.User)
+	assert.Equal(t, "secret", c.Password)
+
+	c, err = NewClientForAgent(address, map[string]string{"protocol": "https"}, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://"+address+DefaultPath, c.URL)
+	names, err := c.Search(context.TODO(), CamelRouteMBeanPattern)
+	require.NoError(t, err)
+	assert.Len(t, names, 2)
+
+	_, err = NewClientForAgent(address, map[string]string{"protocol": "https", "useSslClientAuthentication": "true"}, "", "")
+	require.ErrorContains(t, err, "the Jolokia agent requires SSL client authentication")
+
+	_, err = NewClientForAgent(address, map[string]string{"protocol": "https"}, "/missing/tls.crt", "/missing/tls.key")
+	require.ErrorContains(t, err, "cannot load the Jolokia client certificate")
+}
+
+func TestMBeanPropertyQuoted(t *testing.T) {
+	name := `org.apache.camel:context=camel-1,type=routes,name="a,b=c",description="say \"hello\"",id=route3`
+	assert.Equal(t, "camel-1", MBeanProperty(name, "context"))
+	assert.Equal(t, "a,b=c", MBeanProperty(name, "name"))
+	assert.Equal(t, `say "hello"`, MBeanProperty(name, "description"))
+	assert.Equal(t, "route3", MBeanProperty(name, "id"))
+	assert.Empty(t, MBeanProperty(name, "b"))
+	assert.Empty(t, MBeanProperty("invalid", "name"))
+}
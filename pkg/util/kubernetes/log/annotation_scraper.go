@@ -22,6 +22,7 @@ import (
 	"context"
 	"io"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -48,11 +49,16 @@ type SelectorScraper struct {
 	podScrapers          sync.Map
 	counter              uint64
 	L                    klog.Logger
-	tailLines            *int64
+	options              Options
 }
 
 // NewSelectorScraper creates a new SelectorScraper.
 func NewSelectorScraper(client kubernetes.Interface, namespace string, defaultContainerName string, labelSelector string, tailLines *int64) *SelectorScraper {
+	return NewSelectorScraperWithOptions(client, namespace, defaultContainerName, labelSelector, Options{TailLines: tailLines})
+}
+
+// NewSelectorScraperWithOptions creates a new SelectorScraper with the given options.
+func NewSelectorScraperWithOptions(client kubernetes.Interface, namespace string, defaultContainerName string, labelSelector string, options Options) *SelectorScraper {
 	klog.InitForCmd()
 	return &SelectorScraper{
 		client:               client,
@@ -60,7 +66,7 @@ func NewSelectorScraper(client kubernetes.Interface, namespace string, defaultCo
 		defaultContainerName: defaultContainerName,
 		labelSelector:        labelSelector,
 		L:                    klog.WithName("scraper").WithName("label").WithValues("selector", labelSelector),
-		tailLines:            tailLines,
+		options:              options,
 	}
 }
 
@@ -137,10 +143,10 @@ func (s *SelectorScraper) synchronize(ctx context.Context, out *bufio.Writer) er
 }
 
 func (s *SelectorScraper) addPodScraper(ctx context.Context, podName string, out *bufio.Writer) {
-	podScraper := NewPodScraper(s.client, s.namespace, podName, s.defaultContainerName, s.tailLines)
+	podScraper := NewPodScraperWithOptions(s.client, s.namespace, podName, s.defaultContainerName, s.options)
 	podCtx, podCancel := context.WithCancel(ctx)
 	id := atomic.AddUint64(&s.counter, 1)
-	prefix := "[" + strconv.FormatUint(id, 10) + "] "
+	prefix := s.options.Prefix + "[" + strconv.FormatUint(id, 10) + "] "
 	podReader := podScraper.Start(podCtx)
 	s.podScrapers.Store(podName, podCancel)
 	go func() {
@@ -158,6 +164,13 @@ func (s *SelectorScraper) addPodScraper(ctx context.Context, podName string, out
 				s.L.Error(err, "Cannot read from pod stream")
 				return
 			}
+			if s.options.Filter != nil {
+				line, ok := s.options.Filter.Apply(strings.TrimSuffix(str, "\n"))
+				if !ok {
+					continue
+				}
+				str = line + "\n"
+			}
 			if _, err := out.WriteString(prefix + str); err != nil {
 				s.L.Error(err, "Cannot write to output")
 				return
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// MDCRouteID is the MDC key of the id of the route processing the exchange.
+	MDCRouteID = "camel.routeId"
+	// MDCExchangeID is the MDC key of the id of the exchange being processed.
+	MDCExchangeID = "camel.exchangeId"
+)
+
+// levels ranks the log levels by severity.
+var levels = map[string]int{
+	"TRACE":   0,
+	"DEBUG":   1,
+	"INFO":    2,
+	"WARN":    3,
+	"WARNING": 3,
+	"ERROR":   4,
+	"FATAL":   5,
+}
+
+// Filter selects and formats the log lines of an integration.
+type Filter struct {
+	// Grep selects the lines matching the regular expression.
+	Grep *regexp.Regexp
+	// JSON parses the lines in the JSON format of the logging trait, and formats them as text.
+	JSON bool
+	// Level selects the JSON lines of the given level or above.
+	Level string
+	// RouteID selects the JSON lines logged by the given route.
+	RouteID string
+	// ExchangeID selects the JSON lines logged while processing the given exchange.
+	ExchangeID string
+	// MDC selects the JSON lines having the given MDC values.
+	MDC map[string]string
+}
+
+// jsonEntry is a log line in the JSON format of the logging trait.
+type jsonEntry struct {
+	Timestamp  string            `json:"timestamp"`
+	Level      string            `json:"level"`
+	LoggerName string            `json:"loggerName"`
+	ThreadName string            `json:"threadName"`
+	Message    string            `json:"message"`
+	StackTrace string            `json:"stackTrace"`
+	MDC        map[string]string `json:"mdc"`
+}
+
+// Validate checks that the filter is consistent.
+func (f *Filter) Validate() error {
+	if f.Level != "" {
+		if _, ok := levels[strings.ToUpper(f.Level)]; !ok {
+			return fmt.Errorf("unknown log level %s, expected one of TRACE, DEBUG, INFO, WARN, ERROR or FATAL", f.Level)
+		}
+	}
+	if !f.JSON && f.structured() {
+		return fmt.Errorf("filtering by level, route id, exchange id or MDC requires the JSON parsing of the logs")
+	}
+
+	return nil
+}
+
+// structured tells whether the filter selects the lines on the content of the JSON logs.
+func (f *Filter) structured() bool {
+	return f.Level != "" || f.RouteID != "" || f.ExchangeID != "" || len(f.MDC) > 0
+}
+
+// Apply returns the formatted line, and whether it is selected by the filter. When the JSON parsing is enabled,
+// the lines that are not JSON are selected only if the filter does not select on the JSON content.
+func (f *Filter) Apply(line string) (string, bool) {
+	if f.JSON {
+		timestamp, content := splitTimestamp(line)
+		entry := jsonEntry{}
+		if err := json.Unmarshal([]byte(content), &entry); err == nil {
+			if !f.matches(entry) {
+				return "", false
+			}
+			line = timestamp + entry.format()
+		} else if f.structured() {
+			return "", false
+		}
+	}
+	if f.Grep != nil && !f.Grep.MatchString(line) {
+		return "", false
+	}
+
+	return line, true
+}
+
+func (f *Filter) matches(entry jsonEntry) bool {
+	if f.Level != "" && levels[strings.ToUpper(entry.Level)] < levels[strings.ToUpper(f.Level)] {
+		return false
+	}
+	if f.RouteID != "" && entry.MDC[MDCRouteID] != f.RouteID {
+		return false
+	}
+	if f.ExchangeID != "" && entry.MDC[MDCExchangeID] != f.ExchangeID {
+		return false
+	}
+	for k, v := range f.MDC {
+		if entry.MDC[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// format renders the entry as the default console format of the integrations.
+func (e jsonEntry) format() string {
+	line := fmt.Sprintf("%s %-5s [%s] (%s) %s", e.Timestamp, e.Level, e.LoggerName, e.ThreadName, e.Message)
+	if e.StackTrace != "" {
+		line += "\n" + strings.TrimSuffix(e.StackTrace, "\n")
+	}
+
+	return line
+}
+
+// splitTimestamp splits the timestamp Kubernetes prefixes the lines with, if any, from the content of the line.
+func splitTimestamp(line string) (string, string) {
+	if strings.HasPrefix(line, "{") {
+		return "", line
+	}
+	timestamp, content, ok := strings.Cut(line, " ")
+	if !ok {
+		return "", line
+	}
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return "", line
+	}
+
+	return timestamp + " ", content
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	infoLine  = `{"timestamp":"2024-05-02T10:00:00.000Z","level":"INFO","loggerName":"route1","threadName":"main","message":"Hello","mdc":{"camel.routeId":"route1","camel.exchangeId":"EX-1"}}`
+	errorLine = `{"timestamp":"2024-05-02T10:00:01.000Z","level":"ERROR","loggerName":"route2","threadName":"main","message":"Failed","stackTrace":"java.lang.Exception\n\tat Foo\n","mdc":{"camel.routeId":"route2","camel.exchangeId":"EX-2","tenant":"acme"}}`
+)
+
+func TestFilterGrep(t *testing.T) {
+	f := Filter{Grep: regexp.MustCompile("Hel+o")}
+
+	line, ok := f.Apply("Hello world")
+	assert.True(t, ok)
+	assert.Equal(t, "Hello world", line)
+	_, ok = f.Apply("Goodbye")
+	assert.False(t, ok)
+}
+
+func TestFilterJSON(t *testing.T) {
+	f := Filter{JSON: true}
+	require.NoError(t, f.Validate())
+
+	line, ok := f.Apply(infoLine)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-05-02T10:00:00.000Z INFO  [route1] (main) Hello", line)
+
+	line, ok = f.Apply(errorLine)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-05-02T10:00:01.000Z ERROR [route2] (main) Failed\njava.lang.Exception\n\tat Foo", line)
+
+	// Kubernetes timestamps are kept
+	line, ok = f.Apply("2024-05-02T10:00:00.123456789Z " + infoLine)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-05-02T10:00:00.123456789Z 2024-05-02T10:00:00.000Z INFO  [route1] (main) Hello", line)
+
+	// Lines that are not JSON are printed as is
+	line, ok = f.Apply("Starting the application")
+	assert.True(t, ok)
+	assert.Equal(t, "Starting the application", line)
+}
+
+func TestFilterJSONContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		info   bool
+		error  bool
+	}{
+		{name: "level", filter: Filter{JSON: true, Level: "warn"}, info: false, error: true},
+		{name: "route id", filter: Filter{JSON: true, RouteID: "route1"}, info: true, error: false},
+		{name: "exchange id", filter: Filter{JSON: true, ExchangeID: "EX-2"}, info: false, error: true},
+		{name: "mdc", filter: Filter{JSON: true, MDC: map[string]string{"tenant": "acme"}}, info: false, error: true},
+		{name: "grep", filter: Filter{JSON: true, Grep: regexp.MustCompile(`\[route1\]`)}, info: true, error: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, test.filter.Validate())
+			_, ok := test.filter.Apply(infoLine)
+			assert.Equal(t, test.info, ok)
+			_, ok = test.filter.Apply(errorLine)
+			assert.Equal(t, test.error, ok)
+			if test.filter.structured() {
+				_, ok = test.filter.Apply("Starting the application")
+				assert.False(t, ok)
+			}
+		})
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	f := Filter{JSON: true, Level: "VERBOSE"}
+	require.EqualError(t, f.Validate(), "unknown log level VERBOSE, expected one of TRACE, DEBUG, INFO, WARN, ERROR or FATAL")
+
+	f = Filter{RouteID: "route1"}
+	require.EqualError(t, f.Validate(), "filtering by level, route id, exchange id or MDC requires the JSON parsing of the logs")
+}
@@ -49,11 +49,16 @@ type PodScraper struct {
 	defaultContainerName string
 	client               kubernetes.Interface
 	L                    klog.Logger
-	tailLines            *int64
+	options              Options
 }
 
 // NewPodScraper creates a new pod scraper.
 func NewPodScraper(c kubernetes.Interface, namespace string, podName string, defaultContainerName string, tailLines *int64) *PodScraper {
+	return NewPodScraperWithOptions(c, namespace, podName, defaultContainerName, Options{TailLines: tailLines})
+}
+
+// NewPodScraperWithOptions creates a new pod scraper with the given options.
+func NewPodScraperWithOptions(c kubernetes.Interface, namespace string, podName string, defaultContainerName string, options Options) *PodScraper {
 	klog.InitForCmd()
 	return &PodScraper{
 		namespace:            namespace,
@@ -61,7 +66,7 @@ func NewPodScraper(c kubernetes.Interface, namespace string, podName string, def
 		defaultContainerName: defaultContainerName,
 		client:               c,
 		L:                    klog.WithName("scraper").WithName("pod").WithValues("name", podName),
-		tailLines:            tailLines,
+		options:              options,
 	}
 }
 
@@ -86,9 +91,11 @@ func (s *PodScraper) doScrape(ctx context.Context, out *bufio.Writer, clientClos
 		return
 	}
 	logOptions := corev1.PodLogOptions{
-		Follow:    true,
-		TailLines: s.tailLines,
-		Container: containerName,
+		Follow:       true,
+		TailLines:    s.options.TailLines,
+		SinceSeconds: s.options.SinceSeconds,
+		Timestamps:   s.options.Timestamps,
+		Container:    containerName,
 	}
 	byteReader, err := s.client.CoreV1().Pods(s.namespace).GetLogs(s.podName, &logOptions).Stream(ctx)
 	if err != nil {
@@ -20,8 +20,10 @@ package log
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
 	"github.com/spf13/cobra"
@@ -30,6 +32,20 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+// Options configures the scraping of the pod logs.
+type Options struct {
+	// TailLines is the number of lines from the end of the logs to show, all the lines when nil.
+	TailLines *int64
+	// SinceSeconds limits the logs to the ones newer than the given number of seconds, all the logs when nil.
+	SinceSeconds *int64
+	// Timestamps prefixes each line with its timestamp.
+	Timestamps bool
+	// Prefix is added to each line, before the prefix identifying the pod.
+	Prefix string
+	// Filter selects and formats the log lines, all of them being printed as is when nil.
+	Filter *Filter
+}
+
 // Print prints integrations logs to the stdout.
 func Print(ctx context.Context, cmd *cobra.Command, client kubernetes.Interface, integration *v1.Integration, tailLines *int64, out io.Writer) error {
 	return PrintUsingSelector(ctx, cmd, client, integration.Namespace, integration.Name, v1.IntegrationLabel+"="+integration.Name, tailLines, out)
@@ -47,6 +63,44 @@ func PrintUsingSelector(ctx context.Context, cmd *cobra.Command, client kubernet
 	return nil
 }
 
+// PrintIntegrations prints the logs of the given integrations, scraped with the options returned for each of them,
+// multiplexing them line by line.
+func PrintIntegrations(ctx context.Context, cmd *cobra.Command, client kubernetes.Interface, integrations []v1.Integration,
+	options func(*v1.Integration) Options, out io.Writer) error {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	for i := range integrations {
+		it := &integrations[i]
+		scraper := NewSelectorScraperWithOptions(client, it.Namespace, it.Name, v1.IntegrationLabel+"="+it.Name, options(it))
+		reader := scraper.Start(ctx)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				line, err := reader.ReadString('\n')
+				if line != "" {
+					lock.Lock()
+					_, werr := io.WriteString(out, line)
+					lock.Unlock()
+					if werr != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), werr.Error())
+						return
+					}
+				}
+				if err != nil {
+					if !errors.Is(err, io.EOF) {
+						fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+					}
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
 // DumpLog extract the full log from a Pod. Recommended when the quantity of log expected is minimum.
 func DumpLog(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod, podLogOpts corev1.PodLogOptions) (string, error) {
 	req := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &podLogOpts)
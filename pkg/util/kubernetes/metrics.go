@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+)
+
+const podMetricsPath = "/apis/metrics.k8s.io/v1beta1/namespaces"
+
+// PodMetrics is the subset of the metrics.k8s.io PodMetrics resource used to report pod resource usage.
+type PodMetrics struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Containers        []ContainerMetrics `json:"containers"`
+}
+
+// ContainerMetrics is the resource usage of a single container.
+type ContainerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// PodMetricsList is a list of PodMetrics.
+type PodMetricsList struct {
+	Items []PodMetrics `json:"items"`
+}
+
+// CPU returns the sum of the CPU usage of all the containers of the pod.
+func (m PodMetrics) CPU() resource.Quantity {
+	return m.sum(corev1.ResourceCPU)
+}
+
+// Memory returns the sum of the memory usage of all the containers of the pod.
+func (m PodMetrics) Memory() resource.Quantity {
+	return m.sum(corev1.ResourceMemory)
+}
+
+func (m PodMetrics) sum(name corev1.ResourceName) resource.Quantity {
+	total := resource.Quantity{}
+	for _, c := range m.Containers {
+		if q, ok := c.Usage[name]; ok {
+			total.Add(q)
+		}
+	}
+	return total
+}
+
+// LookupPodMetrics retrieves the resource usage of the pods matching the label selector from the metrics.k8s.io API.
+// It fails if the metrics server is not available on the cluster.
+func LookupPodMetrics(ctx context.Context, c client.Client, ns string, labelSelector string) (*PodMetricsList, error) {
+	data, err := c.CoreV1().RESTClient().Get().
+		AbsPath(podMetricsPath, ns, "pods").
+		Param("labelSelector", labelSelector).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := PodMetricsList{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
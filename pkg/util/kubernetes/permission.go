@@ -19,6 +19,7 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
 
 	authorizationv1 "k8s.io/api/authorization/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -52,3 +53,32 @@ func CheckPermission(ctx context.Context, client kubernetes.Interface, group, re
 
 	return sar.Status.Allowed, nil
 }
+
+// CheckServiceAccountPermission can be used to check if the given service account is allowed to execute a given operation
+// in the cluster. The current user must be allowed to create SubjectAccessReviews.
+// E.g. CheckServiceAccountPermission(client, "camel-k", "camel-k-operator", "", "pods", namespace, "create").
+func CheckServiceAccountPermission(ctx context.Context, client kubernetes.Interface, serviceAccountNamespace, serviceAccount, group, resource, namespace, verb string) (bool, error) {
+	sarReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User: fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccountNamespace, serviceAccount),
+			Groups: []string{
+				"system:serviceaccounts",
+				"system:serviceaccounts:" + serviceAccountNamespace,
+				"system:authenticated",
+			},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:     group,
+				Resource:  resource,
+				Namespace: namespace,
+				Verb:      verb,
+			},
+		},
+	}
+
+	sar, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sarReview, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	return sar.Status.Allowed, nil
+}
@@ -42,7 +42,7 @@ func PortForward(ctx context.Context, c client.Client, ns, labelSelector string,
 	defer forwardCtxCancel()
 
 	setupPortForward := func(pod *corev1.Pod) error {
-		if forwardPod == nil && podReady(pod) {
+		if forwardPod == nil && IsPodReady(pod) {
 			forwardPod = pod
 			log.Debugf("Setting up Port Forward for pod with name: %q\n", forwardPod.Name)
 			if _, err := portFowardPod(forwardCtx, c.GetConfig(), ns, forwardPod.Name, localPort, remotePort, stdOut, stdErr); err != nil {
@@ -120,6 +120,12 @@ func PortForward(ctx context.Context, c client.Client, ns, labelSelector string,
 	}
 }
 
+// PortForwardPod forwards a random local port to the given remote port of the pod and returns the local address.
+// The port forward stays open until the context is canceled.
+func PortForwardPod(ctx context.Context, c client.Client, ns, pod string, remotePort uint, stdOut, stdErr io.Writer) (string, error) {
+	return portFowardPod(ctx, c.GetConfig(), ns, pod, 0, remotePort, stdOut, stdErr)
+}
+
 func bootstrapPortForward(ctx context.Context, c client.Client, ns string, labelSelector string, setupPortForward func(pod *corev1.Pod) error) (*corev1.PodList, error) {
 	list, err := c.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
@@ -191,7 +197,8 @@ func portFowardPod(ctx context.Context, config *restclient.Config, ns, pod strin
 	}
 }
 
-func podReady(pod *corev1.Pod) bool {
+// IsPodReady returns true if the pod has the Ready condition set.
+func IsPodReady(pod *corev1.Pod) bool {
 	for _, c := range pod.Status.Conditions {
 		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
 			return true
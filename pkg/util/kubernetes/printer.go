@@ -18,19 +18,31 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
+	"text/tabwriter"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
 )
 
+// OutputFormatCustomColumns is the prefix of the output format listing the columns of a table,
+// e.g. "custom-columns=NAME:.metadata.name,PHASE:.status.phase".
+const OutputFormatCustomColumns = "custom-columns="
+
+var jsonPathExpression = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
+
 // CLIPrinter is delegated to print the runtime object.
 type CLIPrinter struct {
-	// It accepts either yaml or json format
+	// It accepts either yaml, json or name format
 	Format string
 }
 
-// PrintObj prints the obj in json|yaml format according to the type of the obj.
+// PrintObj prints the obj in json|yaml|name format according to the type of the obj.
 func (p *CLIPrinter) PrintObj(obj runtime.Object, output io.Writer) error {
 	var data []byte
 	var err error
@@ -39,8 +51,10 @@ func (p *CLIPrinter) PrintObj(obj runtime.Object, output io.Writer) error {
 		data, err = ToYAML(obj)
 	case "json":
 		data, err = ToJSON(obj)
+	case "name":
+		data, err = toNames(obj)
 	default:
-		err = fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", p.Format)
+		err = fmt.Errorf("invalid output format option '%s', should be one of: yaml|json|name", p.Format)
 	}
 	if err != nil {
 		return err
@@ -48,3 +62,152 @@ func (p *CLIPrinter) PrintObj(obj runtime.Object, output io.Writer) error {
 	fmt.Fprint(output, string(data))
 	return nil
 }
+
+// toNames returns the kind/name lines of the given object, or of the items of the given list.
+func toNames(obj runtime.Object) ([]byte, error) {
+	objects := []runtime.Object{obj}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		objects = items
+	}
+
+	var buf bytes.Buffer
+	for _, o := range objects {
+		accessor, err := meta.Accessor(o)
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.ToLower(o.GetObjectKind().GroupVersionKind().Kind)
+		if kind == "" {
+			fmt.Fprintln(&buf, accessor.GetName())
+		} else {
+			fmt.Fprintf(&buf, "%s/%s\n", kind, accessor.GetName())
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// TableColumn is a column of the table printed by a TablePrinter.
+type TableColumn struct {
+	Header string
+	// Wide columns are only printed in wide mode
+	Wide  bool
+	Value func(obj runtime.Object) (string, error)
+}
+
+// TablePrinter prints objects as the rows of a table. The header is only printed once, so that the printer
+// can be used to stream the changes of the objects.
+type TablePrinter struct {
+	Columns   []TableColumn
+	Wide      bool
+	NoHeaders bool
+
+	headerPrinted bool
+}
+
+// PrintObj prints the obj, or the items of the obj if it is a list, as rows of the table.
+func (p *TablePrinter) PrintObj(obj runtime.Object, output io.Writer) error {
+	objects := []runtime.Object{obj}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return err
+		}
+		objects = items
+	}
+
+	columns := make([]TableColumn, 0, len(p.Columns))
+	for _, c := range p.Columns {
+		if !c.Wide || p.Wide {
+			columns = append(columns, c)
+		}
+	}
+
+	w := tabwriter.NewWriter(output, 0, 8, 1, '\t', 0)
+	if !p.NoHeaders && !p.headerPrinted {
+		headers := make([]string, 0, len(columns))
+		for _, c := range columns {
+			headers = append(headers, c.Header)
+		}
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+		p.headerPrinted = true
+	}
+	for _, o := range objects {
+		values := make([]string, 0, len(columns))
+		for _, c := range columns {
+			value, err := c.Value(o)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// NewCustomColumns parses the columns of the custom-columns output format, e.g.
+// "NAME:.metadata.name,PHASE:.status.phase", whose values are JSONPath expressions.
+func NewCustomColumns(spec string) ([]TableColumn, error) {
+	spec = strings.TrimPrefix(spec, OutputFormatCustomColumns)
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+
+	parts := strings.Split(spec, ",")
+	columns := make([]TableColumn, 0, len(parts))
+	for _, part := range parts {
+		header, expression, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", part)
+		}
+		submatches := jsonPathExpression.FindStringSubmatch(expression)
+		if submatches == nil {
+			return nil, fmt.Errorf("unexpected path string %s, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'", expression)
+		}
+		field := submatches[1]
+		if field == "" {
+			field = submatches[2]
+		}
+		parser := jsonpath.New(header).AllowMissingKeys(true)
+		if err := parser.Parse(fmt.Sprintf("{.%s}", field)); err != nil {
+			return nil, err
+		}
+		columns = append(columns, TableColumn{
+			Header: header,
+			Value: func(obj runtime.Object) (string, error) {
+				return jsonPathValue(parser, obj)
+			},
+		})
+	}
+
+	return columns, nil
+}
+
+func jsonPathValue(parser *jsonpath.JSONPath, obj runtime.Object) (string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	results, err := parser.FindResults(content)
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]string, 0)
+	for _, result := range results {
+		for _, r := range result {
+			values = append(values, fmt.Sprintf("%v", r.Interface()))
+		}
+	}
+	if len(values) == 0 {
+		return "<none>", nil
+	}
+
+	return strings.Join(values, ","), nil
+}
@@ -0,0 +1,487 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/camel-k/v2/pkg/util/io"
+)
+
+const (
+	// CacheLeasesDir is the directory, at the root of a shared repository, holding the leases of its users.
+	CacheLeasesDir = ".camel-k-leases"
+
+	cacheWriterLease   = "writer.lease"
+	cacheReaderLease   = "reader-"
+	cacheLeaseTakeover = ".takeover-"
+)
+
+var (
+	// CacheLeaseExpiration is the duration after which a lease that is no longer refreshed is considered released,
+	// e.g. when the build holding it has been interrupted.
+	CacheLeaseExpiration = time.Minute
+	// CacheLeasePolling is the interval at which an unavailable lease is polled.
+	CacheLeasePolling = 2 * time.Second
+
+	// leaseObservations are the states of the leases observed by this process, keyed by path, as the clocks of the
+	// nodes sharing the cache cannot be compared.
+	leaseObservations sync.Map
+)
+
+// leaseObservation is the state of a lease file, and the time, on the local clock, it has been first observed.
+type leaseObservation struct {
+	info os.FileInfo
+	seen time.Time
+}
+
+// Cache is a Maven local repository shared by concurrent builds, e.g. from a persistent volume. The builds that only
+// read from the cache hold a shared lease, while the builds that write into it, and the evictions, hold an exclusive
+// lease. The leases are files, at the root of the cache, that are refreshed while they are held. A lease expires when
+// it has not been refreshed for CacheLeaseExpiration, as measured on the local clock of the process observing it.
+type Cache struct {
+	Dir string
+	// Holder identifies the holder of the leases in the logs and the statistics, e.g. the name of the build.
+	Holder string
+}
+
+// CacheEntry is a directory of the cache holding the files of an artifact version.
+type CacheEntry struct {
+	Path     string
+	Size     int64
+	LastUsed time.Time
+}
+
+// CacheStats are the statistics of a cache.
+type CacheStats struct {
+	Size              int64
+	Entries           int
+	LeastRecentlyUsed time.Time
+	MostRecentlyUsed  time.Time
+	// Writer is the holder of the exclusive lease, if any.
+	Writer string
+	// Readers are the holders of the shared leases.
+	Readers []string
+}
+
+// NewCache returns the cache in the given directory.
+func NewCache(dir string, holder string) Cache {
+	return Cache{
+		Dir:    dir,
+		Holder: holder,
+	}
+}
+
+// Lock waits until it gets a shared or an exclusive lease on the cache, and returns the function releasing it.
+// A pending exclusive lease prevents new shared leases from being granted, so that the writers are not starved.
+func (c Cache) Lock(ctx context.Context, exclusive bool) (func(), error) {
+	if err := os.MkdirAll(c.leasesDir(), io.FilePerm755); err != nil {
+		return nil, err
+	}
+	if !exclusive {
+		return c.lockShared(ctx)
+	}
+
+	writer := filepath.Join(c.leasesDir(), cacheWriterLease)
+	for {
+		acquired, err := c.acquire(writer)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			break
+		}
+		if err := waitLease(ctx); err != nil {
+			return nil, err
+		}
+	}
+	release := c.refresh(writer)
+	for {
+		readers, err := c.readers()
+		if err != nil {
+			release()
+			return nil, err
+		}
+		if len(readers) == 0 {
+			return release, nil
+		}
+		if err := waitLease(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+}
+
+// TryLock returns the function releasing an exclusive lease on the cache, or nil if the lease is not available.
+func (c Cache) TryLock() (func(), error) {
+	if err := os.MkdirAll(c.leasesDir(), io.FilePerm755); err != nil {
+		return nil, err
+	}
+	writer := filepath.Join(c.leasesDir(), cacheWriterLease)
+	acquired, err := c.acquire(writer)
+	if err != nil || !acquired {
+		return nil, err
+	}
+	release := c.refresh(writer)
+	readers, err := c.readers()
+	if err != nil || len(readers) > 0 {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+func (c Cache) lockShared(ctx context.Context) (func(), error) {
+	writer := filepath.Join(c.leasesDir(), cacheWriterLease)
+	reader := filepath.Join(c.leasesDir(), cacheReaderLease+strconv.FormatInt(time.Now().UnixNano(), 36)+"-"+sanitize(c.Holder))
+	for {
+		if !isLive(writer) {
+			if err := os.WriteFile(reader, []byte(c.Holder), io.FilePerm644); err != nil {
+				return nil, err
+			}
+			// The writer may have acquired its lease concurrently, in which case it wins
+			if !isLive(writer) {
+				return c.refresh(reader), nil
+			}
+			if err := os.Remove(reader); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+		}
+		if err := waitLease(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// acquire creates the lease file, taking it over if it has expired, and returns whether it has been acquired.
+func (c Cache) acquire(lease string) (bool, error) {
+	f, err := os.CreateTemp(filepath.Dir(lease), "."+filepath.Base(lease)+"-*")
+	if err != nil {
+		return false, err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+	_, err = f.WriteString(c.Holder)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Linking the lease fails if it exists, so that it is created, with its holder, atomically
+	err = os.Link(tmp, lease)
+	if !errors.Is(err, os.ErrExist) {
+		return err == nil, err
+	}
+	expired, ok := expiredLease(lease)
+	if !ok {
+		return false, nil
+	}
+
+	// The contenders observing the same expired lease link it to the same takeover file, so that only one of them
+	// replaces it, provided that it has not been replaced in the meantime
+	takeover := lease + cacheLeaseTakeover + strconv.FormatInt(expired.ModTime().UnixNano(), 36)
+	if err := os.Link(lease, takeover); err != nil {
+		if errors.Is(err, os.ErrExist) || errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer os.Remove(takeover)
+	info, err := os.Stat(takeover)
+	if err != nil {
+		return false, err
+	}
+	if !os.SameFile(info, expired) {
+		return false, nil
+	}
+	holder, _ := os.ReadFile(takeover)
+	Log.Infof("Releasing the expired lease of %s on the Maven cache %s", string(holder), c.Dir)
+	if err := os.Rename(tmp, lease); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// refresh keeps the lease alive until the returned function is called, which releases the lease.
+func (c Cache) refresh(lease string) func() {
+	done := make(chan struct{})
+	ticker := time.NewTicker(CacheLeaseExpiration / 3)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				if err := os.Chtimes(lease, now, now); err != nil {
+					Log.Errorf(err, "Unable to refresh the lease %s on the Maven cache", lease)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			leaseObservations.Delete(lease)
+			if err := os.Remove(lease); err != nil && !errors.Is(err, os.ErrNotExist) {
+				Log.Errorf(err, "Unable to release the lease %s on the Maven cache", lease)
+			}
+		})
+	}
+}
+
+// readers returns the holders of the live shared leases.
+func (c Cache) readers() ([]string, error) {
+	entries, err := os.ReadDir(c.leasesDir())
+	if err != nil {
+		return nil, err
+	}
+	readers := make([]string, 0)
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), cacheReaderLease) {
+			continue
+		}
+		lease := filepath.Join(c.leasesDir(), e.Name())
+		if !isLive(lease) {
+			// The expired shared leases are removed, as they are never acquired again
+			leaseObservations.Delete(lease)
+			_ = os.Remove(lease)
+			continue
+		}
+		holder, err := os.ReadFile(lease)
+		if err != nil {
+			continue
+		}
+		readers = append(readers, string(holder))
+	}
+	sort.Strings(readers)
+
+	return readers, nil
+}
+
+func (c Cache) leasesDir() string {
+	return filepath.Join(c.Dir, CacheLeasesDir)
+}
+
+// Touch marks the versions of the given dependencies as used, so that they are the last ones to be evicted.
+func (c Cache) Touch(dependencies ...Dependency) {
+	now := time.Now()
+	for _, d := range dependencies {
+		if d.Version == "" {
+			continue
+		}
+		dir := filepath.Join(append([]string{c.Dir}, strings.Split(d.GroupID, ".")...)...)
+		dir = filepath.Join(dir, d.ArtifactID, d.Version)
+		if err := os.Chtimes(dir, now, now); err != nil && !errors.Is(err, os.ErrNotExist) {
+			Log.Errorf(err, "Unable to mark %s as used in the Maven cache", dir)
+		}
+	}
+}
+
+// Entries returns the entries of the cache, from the least to the most recently used.
+// An entry is used when its directory is created or updated by a build, or touched.
+func (c Cache) Entries() ([]CacheEntry, error) {
+	entries := make(map[string]*CacheEntry)
+	err := filepath.Walk(c.Dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == CacheLeasesDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		dir := filepath.Dir(file)
+		e, ok := entries[dir]
+		if !ok {
+			dirInfo, err := os.Stat(dir)
+			if err != nil {
+				return err
+			}
+			e = &CacheEntry{Path: dir, LastUsed: dirInfo.ModTime()}
+			entries[dir] = e
+		}
+		e.Size += info.Size()
+
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("the Maven cache %s does not exist", c.Dir)
+	} else if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]CacheEntry, 0, len(entries))
+	for _, e := range entries {
+		sorted = append(sorted, *e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].LastUsed.Equal(sorted[j].LastUsed) {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].LastUsed.Before(sorted[j].LastUsed)
+	})
+
+	return sorted, nil
+}
+
+// Stats returns the statistics of the cache.
+func (c Cache) Stats() (CacheStats, error) {
+	stats := CacheStats{}
+	entries, err := c.Entries()
+	if err != nil {
+		return stats, err
+	}
+	for _, e := range entries {
+		stats.Size += e.Size
+	}
+	stats.Entries = len(entries)
+	if len(entries) > 0 {
+		stats.LeastRecentlyUsed = entries[0].LastUsed
+		stats.MostRecentlyUsed = entries[len(entries)-1].LastUsed
+	}
+
+	writer := filepath.Join(c.leasesDir(), cacheWriterLease)
+	if isLive(writer) {
+		holder, _ := os.ReadFile(writer)
+		stats.Writer = string(holder)
+	}
+	if _, err := os.Stat(c.leasesDir()); err == nil {
+		stats.Readers, err = c.readers()
+		if err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// Prune evicts the entries that have not been used since the given time, if not zero, then the least recently used
+// entries until the size of the cache is below the given maximum size, if positive. The caller must hold an exclusive
+// lease on the cache. It returns the evicted entries.
+func (c Cache) Prune(maxSize int64, unusedSince time.Time) ([]CacheEntry, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return nil, err
+	}
+	size := int64(0)
+	for _, e := range entries {
+		size += e.Size
+	}
+
+	evicted := make([]CacheEntry, 0)
+	for _, e := range entries {
+		unused := !unusedSince.IsZero() && e.LastUsed.Before(unusedSince)
+		if !unused && (maxSize <= 0 || size <= maxSize) {
+			break
+		}
+		if err := c.evict(e); err != nil {
+			return evicted, err
+		}
+		size -= e.Size
+		evicted = append(evicted, e)
+	}
+
+	return evicted, nil
+}
+
+// evict removes the files of the entry, and its parent directories up to the root of the cache when they are empty.
+func (c Cache) evict(e CacheEntry) error {
+	files, err := os.ReadDir(e.Path)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(e.Path, f.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	root := filepath.Clean(c.Dir)
+	for dir := e.Path; dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			// The directory is not empty
+			break
+		}
+	}
+
+	return nil
+}
+
+func isLive(lease string) bool {
+	info, expired := expiredLease(lease)
+
+	return info != nil && !expired
+}
+
+// expiredLease returns the state of the lease, and whether it has expired. The lease has expired when neither its
+// file nor its modification time have changed for CacheLeaseExpiration since this process first observed them. The
+// modification time, that may be set from another node, is never compared with the local clock.
+func expiredLease(lease string) (os.FileInfo, bool) {
+	info, err := os.Stat(lease)
+	if err != nil {
+		leaseObservations.Delete(lease)
+		return nil, false
+	}
+	if o, ok := leaseObservations.Load(lease); ok {
+		observed, _ := o.(leaseObservation)
+		if os.SameFile(observed.info, info) && observed.info.ModTime().Equal(info.ModTime()) {
+			return info, time.Since(observed.seen) >= CacheLeaseExpiration
+		}
+	}
+	leaseObservations.Store(lease, leaseObservation{info: info, seen: time.Now()})
+
+	return info, false
+}
+
+func waitLease(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(CacheLeasePolling):
+		return nil
+	}
+}
+
+func sanitize(holder string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, holder)
+}
@@ -0,0 +1,198 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T) Cache {
+	t.Helper()
+
+	c := NewCache(t.TempDir(), "test")
+	now := time.Now()
+	for i, gav := range []string{"org/acme/a/1.0", "org/acme/b/1.0", "org/acme/b/2.0"} {
+		dir := filepath.Join(c.Dir, gav)
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "artifact.jar"), make([]byte, 100), 0o600))
+		used := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(dir, used, used))
+	}
+
+	return c
+}
+
+func TestCacheStats(t *testing.T) {
+	c := newTestCache(t)
+
+	stats, err := c.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), stats.Size)
+	assert.Equal(t, 3, stats.Entries)
+	assert.True(t, stats.LeastRecentlyUsed.Before(stats.MostRecentlyUsed))
+	assert.Empty(t, stats.Writer)
+	assert.Empty(t, stats.Readers)
+
+	c.Touch(Dependency{GroupID: "org.acme", ArtifactID: "a", Version: "1.0"})
+	entries, err := c.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(c.Dir, "org/acme/b/1.0"), entries[0].Path)
+	assert.Equal(t, filepath.Join(c.Dir, "org/acme/a/1.0"), entries[2].Path)
+}
+
+func TestCachePrune(t *testing.T) {
+	c := newTestCache(t)
+
+	evicted, err := c.Prune(250, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, filepath.Join(c.Dir, "org/acme/a/1.0"), evicted[0].Path)
+	assert.NoDirExists(t, filepath.Join(c.Dir, "org/acme/a"))
+	assert.DirExists(t, filepath.Join(c.Dir, "org/acme/b/1.0"))
+
+	evicted, err = c.Prune(0, time.Now().Add(-90*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, filepath.Join(c.Dir, "org/acme/b/1.0"), evicted[0].Path)
+
+	stats, err := c.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), stats.Size)
+	assert.Equal(t, 1, stats.Entries)
+}
+
+func TestCacheLeases(t *testing.T) {
+	polling := CacheLeasePolling
+	CacheLeasePolling = 10 * time.Millisecond
+	defer func() { CacheLeasePolling = polling }()
+
+	c := newTestCache(t)
+	releaseReader, err := c.Lock(context.TODO(), false)
+	require.NoError(t, err)
+	stats, err := c.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test"}, stats.Readers)
+
+	// The exclusive lease is not available while the shared lease is held
+	releaseWriter, err := c.TryLock()
+	require.NoError(t, err)
+	assert.Nil(t, releaseWriter)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Lock(ctx, true)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	releaseReader()
+	writer := NewCache(c.Dir, "writer")
+	releaseWriter, err = writer.Lock(context.TODO(), true)
+	require.NoError(t, err)
+	stats, err = c.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, "writer", stats.Writer)
+
+	// The shared lease is not available while the exclusive lease is held
+	ctx, cancel = context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Lock(ctx, false)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	releaseWriter()
+	releaseReader, err = c.Lock(context.TODO(), false)
+	require.NoError(t, err)
+	releaseReader()
+}
+
+func TestCacheExpiredLease(t *testing.T) {
+	expiration := CacheLeaseExpiration
+	CacheLeaseExpiration = 50 * time.Millisecond
+	defer func() { CacheLeaseExpiration = expiration }()
+
+	c := newTestCache(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(c.Dir, CacheLeasesDir), os.ModePerm))
+	lease := filepath.Join(c.Dir, CacheLeasesDir, cacheWriterLease)
+	require.NoError(t, os.WriteFile(lease, []byte("interrupted"), 0o600))
+	// The modification time, that may come from the clock of another node, does not expire the lease
+	expired := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(lease, expired, expired))
+
+	release, err := c.TryLock()
+	require.NoError(t, err)
+	assert.Nil(t, release)
+
+	// The lease expires when it has not been refreshed since it has been first observed
+	time.Sleep(2 * CacheLeaseExpiration)
+	release, err = c.TryLock()
+	require.NoError(t, err)
+	require.NotNil(t, release)
+	holder, err := os.ReadFile(lease)
+	require.NoError(t, err)
+	assert.Equal(t, "test", string(holder))
+
+	release()
+	assert.NoFileExists(t, lease)
+}
+
+func TestCacheExpiredLeaseTakeover(t *testing.T) {
+	expiration := CacheLeaseExpiration
+	CacheLeaseExpiration = 50 * time.Millisecond
+	defer func() { CacheLeaseExpiration = expiration }()
+
+	c := newTestCache(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(c.Dir, CacheLeasesDir), os.ModePerm))
+	lease := filepath.Join(c.Dir, CacheLeasesDir, cacheWriterLease)
+	require.NoError(t, os.WriteFile(lease, []byte("interrupted"), 0o600))
+	acquired, err := c.acquire(lease)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	time.Sleep(2 * CacheLeaseExpiration)
+
+	// Only one of the contenders observing the expired lease takes it over
+	var wg sync.WaitGroup
+	var count atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			acquired, err := NewCache(c.Dir, fmt.Sprintf("contender-%d", i)).acquire(lease)
+			assert.NoError(t, err)
+			if acquired {
+				count.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), count.Load())
+	holder, err := os.ReadFile(lease)
+	require.NoError(t, err)
+	assert.Contains(t, string(holder), "contender-")
+
+	files, err := os.ReadDir(filepath.Join(c.Dir, CacheLeasesDir))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
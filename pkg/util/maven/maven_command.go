@@ -57,6 +57,10 @@ func (c *Command) Do(ctx context.Context) error {
 
 // DoPom is in charge to generate the pom file.
 func (c *Command) DoPom(ctx context.Context) error {
+	if c.context.SkipPomGeneration {
+		return nil
+	}
+
 	return generateProjectPom(c.context, c.project)
 }
 
@@ -75,6 +79,10 @@ func (c *Command) DoSettings(ctx context.Context) error {
 		}
 	}
 
+	if c.context.Offline {
+		args = append(args, "--offline", "-Daether.offline.protocols=file")
+	}
+
 	settingsPath := filepath.Join(c.context.Path, "settings.xml")
 	if settingsExists, err := util.FileExists(settingsPath); err != nil {
 		return err
@@ -186,6 +194,7 @@ func NewContext(buildDir string) Context {
 
 type Context struct {
 	SkipMavenConfigGeneration bool
+	SkipPomGeneration         bool
 	Path                      string
 	ExtraMavenOpts            []string
 	GlobalSettings            []byte
@@ -194,6 +203,9 @@ type Context struct {
 	AdditionalArguments       []string
 	AdditionalEntries         map[string]interface{}
 	LocalRepository           string
+	// Offline runs Maven in offline mode, only resolving the dependencies from file based repositories,
+	// e.g. the ones of an offline bundle.
+	Offline bool
 }
 
 func (c *Context) AddEntry(id string, entry interface{}) {
@@ -171,3 +171,21 @@ func TestDoSettings(t *testing.T) {
 	assert.Contains(t, string(content), fmt.Sprintf("-Dmaven.repo.local=%s", localRepo))
 	assert.Contains(t, string(content), "extra")
 }
+
+func TestDoSettingsOffline(t *testing.T) {
+	dir, err := os.MkdirTemp("", "ck-mvnsettings-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	command := Command{
+		context: NewContext(dir),
+		project: NewProject(),
+	}
+	command.context.Offline = true
+
+	err = command.DoSettings(context.TODO())
+	require.NoError(t, err)
+
+	content, err := util.ReadFile(path.Join(dir, ".mvn", "maven.config"))
+	require.NoError(t, err)
+	assert.Equal(t, "--offline\n-Daether.offline.protocols=file\n", string(content))
+}
@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+)
+
+// DependencyPluginVersion is the version of the Maven dependency plugin used to resolve the dependency trees.
+const DependencyPluginVersion = "3.8.1"
+
+// DependencyTreeGoal returns the goal resolving the verbose dependency tree of a project into the given file.
+func DependencyTreeGoal(outputFile string) []string {
+	return []string{
+		"org.apache.maven.plugins:maven-dependency-plugin:" + DependencyPluginVersion + ":tree",
+		"-Dverbose",
+		"-DoutputFile=" + outputFile,
+	}
+}
+
+const (
+	treeIndentWidth   = 3
+	managedVersionRef = "version managed from "
+	conflictRef       = "omitted for conflict with "
+	omittedRef        = "omitted for "
+)
+
+// ParseDependencyTree parses the verbose text output of the Maven dependency tree goal, into the
+// flattened tree of the dependencies of the project. The project itself, i.e. the root of the tree,
+// is not part of the result, and the dependencies omitted for duplicate or cycle are discarded,
+// while the dependencies omitted for conflict are kept, so that the conflicts can be reported.
+func ParseDependencyTree(r io.Reader) ([]v1.DependencyTreeNode, error) {
+	nodes := make([]v1.DependencyTreeNode, 0)
+
+	scanner := bufio.NewScanner(r)
+	root := true
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		if line == "" {
+			continue
+		}
+		if root {
+			// The first line is the project itself
+			root = false
+			continue
+		}
+
+		content := strings.TrimLeft(line, "|+-\\ ")
+		depth := (len(line)-len(content))/treeIndentWidth - 1
+		if depth < 0 {
+			return nil, fmt.Errorf("invalid dependency tree entry: %s", line)
+		}
+
+		var coordinates, notes string
+		omitted := strings.HasPrefix(content, "(") && strings.HasSuffix(content, ")")
+		if omitted {
+			coordinates, notes, _ = strings.Cut(content[1:len(content)-1], " - ")
+		} else {
+			coordinates, notes, _ = strings.Cut(content, " (")
+			notes = strings.TrimSuffix(notes, ")")
+		}
+
+		// groupId:artifactId:type[:classifier]:version:scope
+		parts := strings.Split(coordinates, ":")
+		//nolint:mnd
+		if len(parts) < 5 {
+			return nil, fmt.Errorf("invalid dependency tree entry: %s", line)
+		}
+		node := v1.DependencyTreeNode{
+			ID:    strings.Join(parts[:len(parts)-1], ":"),
+			Depth: depth,
+			Scope: parts[len(parts)-1],
+		}
+
+		discard := false
+		for _, note := range strings.Split(notes, ";") {
+			note = strings.TrimSpace(note)
+			switch {
+			case strings.HasPrefix(note, managedVersionRef):
+				node.ManagedFrom = strings.TrimPrefix(note, managedVersionRef)
+			case strings.HasPrefix(note, conflictRef):
+				node.ConflictsWith = strings.TrimPrefix(note, conflictRef)
+			case strings.HasPrefix(note, omittedRef):
+				// omitted for duplicate or cycle
+				discard = true
+			}
+		}
+		if omitted && node.ConflictsWith == "" {
+			discard = true
+		}
+		if !discard {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes, scanner.Err()
+}
+
+// DependencyGroupArtifact returns the groupId:artifactId part of the given Maven coordinates, e.g. the ID of
+// a dependency tree node.
+func DependencyGroupArtifact(id string) string {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) < 2 {
+		return id
+	}
+
+	return parts[0] + ":" + parts[1]
+}
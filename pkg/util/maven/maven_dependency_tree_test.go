@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dependencyTree = `org.apache.camel.k.integration:camel-k-integration:jar:2.5.0
++- org.apache.camel.k:camel-k-runtime:jar:3.8.1:compile
+|  +- org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0:compile (version managed from 3.7.0)
+|  |  +- (com.fasterxml.jackson.core:jackson-core:jar:2.16.1:compile - version managed from 2.15.0; omitted for conflict with 2.17.0)
+|  |  \- (org.slf4j:slf4j-api:jar:2.0.9:compile - omitted for duplicate)
+|  \- org.slf4j:slf4j-api:jar:2.0.9:compile
++- io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.100.Final:runtime
+\- com.fasterxml.jackson.core:jackson-core:jar:2.17.0:compile
+`
+
+func TestParseDependencyTree(t *testing.T) {
+	nodes, err := ParseDependencyTree(strings.NewReader(dependencyTree))
+	require.NoError(t, err)
+
+	assert.Equal(t, []v1.DependencyTreeNode{
+		{ID: "org.apache.camel.k:camel-k-runtime:jar:3.8.1", Depth: 0, Scope: "compile"},
+		{ID: "org.apache.camel.quarkus:camel-quarkus-core:jar:3.8.0", Depth: 1, Scope: "compile", ManagedFrom: "3.7.0"},
+		{ID: "com.fasterxml.jackson.core:jackson-core:jar:2.16.1", Depth: 2, Scope: "compile", ManagedFrom: "2.15.0", ConflictsWith: "2.17.0"},
+		{ID: "org.slf4j:slf4j-api:jar:2.0.9", Depth: 1, Scope: "compile"},
+		{ID: "io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.100.Final", Depth: 0, Scope: "runtime"},
+		{ID: "com.fasterxml.jackson.core:jackson-core:jar:2.17.0", Depth: 0, Scope: "compile"},
+	}, nodes)
+}
+
+func TestParseDependencyTreeInvalid(t *testing.T) {
+	_, err := ParseDependencyTree(strings.NewReader("org.acme:project:jar:1.0\n+- org.acme:invalid\n"))
+	require.Error(t, err)
+}
+
+func TestDependencyTreeGoal(t *testing.T) {
+	assert.Equal(t, []string{
+		"org.apache.maven.plugins:maven-dependency-plugin:" + DependencyPluginVersion + ":tree",
+		"-Dverbose",
+		"-DoutputFile=/tmp/tree.txt",
+	}, DependencyTreeGoal("/tmp/tree.txt"))
+}
+
+func TestDependencyGroupArtifact(t *testing.T) {
+	assert.Equal(t, "org.acme:lib", DependencyGroupArtifact("org.acme:lib:jar:1.0"))
+	assert.Equal(t, "org.acme:lib", DependencyGroupArtifact("org.acme:lib"))
+	assert.Equal(t, "lib", DependencyGroupArtifact("lib"))
+}
@@ -37,9 +37,34 @@ const (
 	FATAL   = "FATAL"
 )
 
+// ErrorClass classifies the errors of the builds, depending on whether they may not happen again when retrying.
+type ErrorClass int
+
+const (
+	// UnknownError is the class of the errors that cannot be classified.
+	UnknownError ErrorClass = iota
+	// TransientError is the class of the errors that may not happen again when retrying, e.g. a network timeout or
+	// a server error of the Maven repository or of the image registry.
+	TransientError
+	// PermanentError is the class of the errors that happen again when retrying, e.g. a compilation error or
+	// a missing artifact.
+	PermanentError
+)
+
 var mavenLogger = log.WithName("maven.build")
 var mavenLoggingFormat = regexp.MustCompile(`^\[(TRACE|DEBUG|INFO|WARNING|ERROR|FATAL)\] (.*)$`)
 
+var (
+	compilationErrors = regexp.MustCompile(`(?i)compilation (error|failure|failed)`)
+	transientErrors   = regexp.MustCompile(`(?i)(timed out|SocketTimeoutException|ConnectTimeoutException|` +
+		`connection (reset|refused|closed)|Connection timed out|no route to host|UnknownHostException|` +
+		`temporary failure in name resolution|could not transfer artifact|failure to transfer|` +
+		`(status code|status|HTTP)[: /]*(429|5\d\d)\b|` +
+		`too many requests|internal server error|bad gateway|service unavailable|gateway time-?out)`)
+	permanentErrors = regexp.MustCompile(`(?i)(could not find artifact|failure to find|` +
+		`could not be resolved|non-resolvable (parent|import) POM|non-parseable POM|unknown packaging)`)
+)
+
 // LogHandler is in charge to log the text passed and, if the trace is an error, to return the message to the caller.
 func LogHandler(s string) string {
 	l := parseLog(s)
@@ -83,3 +108,19 @@ func normalizeLog(mavenLog mavenLog) {
 		mavenLogger.Error(nil, mavenLog.Msg)
 	}
 }
+
+// ClassifyError returns the class of the given error message of a build, as returned by LogHandler. The compilation
+// errors are always permanent, the network errors and the server errors of the Maven repositories and of the image
+// registries are transient, and the missing artifacts are permanent.
+func ClassifyError(msg string) ErrorClass {
+	switch {
+	case compilationErrors.MatchString(msg):
+		return PermanentError
+	case transientErrors.MatchString(msg):
+		return TransientError
+	case permanentErrors.MatchString(msg):
+		return PermanentError
+	default:
+		return UnknownError
+	}
+}
@@ -58,3 +58,17 @@ func TestParseLogCannotTrace(t *testing.T) {
 	assert.Equal(t, INFO, mavenLogLine.Level)
 	assert.Equal(t, "[FAILING] this is a failing log trace", mavenLogLine.Msg)
 }
+
+func TestClassifyError(t *testing.T) {
+	for msg, class := range map[string]ErrorClass{
+		"COMPILATION ERROR : ": PermanentError,
+		"Failed to execute goal org.apache.maven.plugins:maven-compiler-plugin:3.11.0:compile (default-compile) on project camel-k-integration: Compilation failure":                                                                                                                                 PermanentError,
+		"Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project org.apache.camel.k.integration:camel-k-integration:jar:2.5.0: Could not find artifact org.acme:missing:jar:1.0 in central (https://repo.maven.apache.org/maven2)":                         PermanentError,
+		"Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project org.apache.camel.k.integration:camel-k-integration:jar:2.5.0: Could not transfer artifact org.acme:lib:jar:1.0 from/to central (https://repo.maven.apache.org/maven2): Connect timed out": TransientError,
+		"Could not transfer artifact org.acme:lib:pom:1.0 from/to central (https://repo.maven.apache.org/maven2): status code: 503, reason phrase: Service Unavailable (503)":                                                                                                                        TransientError,
+		"Failed to execute goal com.google.cloud.tools:jib-maven-plugin:3.4.1:build (default-cli) on project camel-k-integration: Unexpected server response: 502 Bad Gateway":                                                                                                                       TransientError,
+		"Failed to execute goal io.quarkus:quarkus-maven-plugin:3.8.1:build (default) on project camel-k-integration: Failed to build quarkus application":                                                                                                                                           UnknownError,
+	} {
+		assert.Equal(t, class, ClassifyError(msg), msg)
+	}
+}
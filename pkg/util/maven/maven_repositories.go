@@ -18,7 +18,9 @@ limitations under the License.
 package maven
 
 import (
+	"encoding/xml"
 	"fmt"
+	"regexp"
 	"strings"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
@@ -104,3 +106,61 @@ func upsertMirror(mirror Mirror, mirrors *[]Mirror) *[]Mirror {
 
 	return mirrors
 }
+
+// OfflineRepositoryID is the id of the mirror serving the dependencies of an offline bundle.
+const OfflineRepositoryID = "camel-k-offline-bundle"
+
+// mirrorsElement matches the mirrors element of a settings file.
+var mirrorsElement = regexp.MustCompile(`(?s)<mirrors\s*/>|<mirrors>.*?</mirrors>`)
+
+// OfflineRepository makes the repository in the given directory the mirror of all the repositories, replacing the
+// other mirrors. Maven selects a mirror matching the id of a repository before a wildcard one, so that any other
+// mirror would otherwise preempt the offline bundle.
+func OfflineRepository(dir string) SettingsOption {
+	return offlineRepository{dir: dir}
+}
+
+type offlineRepository struct {
+	dir string
+}
+
+func (o offlineRepository) apply(settings *Settings) error {
+	settings.Mirrors = []Mirror{offlineMirror(o.dir)}
+
+	return nil
+}
+
+func offlineMirror(dir string) Mirror {
+	return Mirror{
+		ID:       OfflineRepositoryID,
+		Name:     "Camel K offline bundle",
+		URL:      "file://" + dir,
+		MirrorOf: "*",
+	}
+}
+
+// OfflineUserSettings replaces the mirrors of the given user settings with the mirror of the offline bundle in the
+// given directory. Maven merges the mirrors of the user settings before the ones of the global settings, so that
+// they would otherwise preempt the offline bundle.
+func OfflineUserSettings(settings []byte, dir string) ([]byte, error) {
+	data, err := xml.MarshalIndent(struct {
+		XMLName xml.Name `xml:"mirrors"`
+		Mirrors []Mirror `xml:"mirror"`
+	}{Mirrors: []Mirror{offlineMirror(dir)}}, "  ", "  ")
+	if err != nil {
+		return nil, err
+	}
+	mirrors := strings.TrimSpace(string(data))
+
+	content := string(settings)
+	switch {
+	case mirrorsElement.MatchString(content):
+		content = mirrorsElement.ReplaceAllLiteralString(content, mirrors)
+	case strings.Contains(content, "</settings>"):
+		content = strings.Replace(content, "</settings>", "  "+mirrors+"\n</settings>", 1)
+	default:
+		return nil, fmt.Errorf("invalid Maven settings, no settings element found")
+	}
+
+	return []byte(content), nil
+}
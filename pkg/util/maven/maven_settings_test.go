@@ -244,3 +244,48 @@ func TestDefaultSettingsGenerationWithAdditionalRepo(t *testing.T) {
 
 	assert.Equal(t, expectedDefaultSettingsWithExtraRepo, string(content))
 }
+
+func TestOfflineRepositorySettings(t *testing.T) {
+	settings, err := NewSettings(DefaultRepositories, Repositories("https://foo.bar.org/repo@id=foo@mirrorOf=central"),
+		OfflineRepository("/opt/bundle"))
+	require.NoError(t, err)
+
+	// The offline bundle replaces the other mirrors
+	assert.Len(t, settings.Mirrors, 1)
+	assert.Equal(t, Mirror{
+		ID:       OfflineRepositoryID,
+		Name:     "Camel K offline bundle",
+		URL:      "file:///opt/bundle",
+		MirrorOf: "*",
+	}, settings.Mirrors[0])
+}
+
+func TestOfflineUserSettings(t *testing.T) {
+	settings, err := OfflineUserSettings([]byte(`<settings>
+  <mirrors>
+    <mirror>
+      <id>internal</id>
+      <url>https://nexus.acme.org/repository/maven</url>
+      <mirrorOf>central</mirrorOf>
+    </mirror>
+  </mirrors>
+</settings>`), "/opt/bundle")
+	require.NoError(t, err)
+	assert.Equal(t, `<settings>
+  <mirrors>
+    <mirror>
+      <id>camel-k-offline-bundle</id>
+      <name>Camel K offline bundle</name>
+      <url>file:///opt/bundle</url>
+      <mirrorOf>*</mirrorOf>
+    </mirror>
+  </mirrors>
+</settings>`, string(settings))
+
+	settings, err = OfflineUserSettings([]byte("<settings>\n</settings>"), "/opt/bundle")
+	require.NoError(t, err)
+	assert.Contains(t, string(settings), "<url>file:///opt/bundle</url>")
+
+	_, err = OfflineUserSettings([]byte("<profiles/>"), "/opt/bundle")
+	require.Error(t, err)
+}
@@ -0,0 +1,435 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package offline manages the bundles of pre-resolved dependencies, that let integrations be built without access
+// to remote Maven repositories. A bundle is a Maven repository tree, with a manifest at its root, distributed as a
+// directory, a .tar.gz archive or a single layer OCI image.
+package offline
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"gopkg.in/yaml.v2"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/client"
+	utilio "github.com/apache/camel-k/v2/pkg/util/io"
+	"github.com/apache/camel-k/v2/pkg/util/log"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+	"github.com/apache/camel-k/v2/pkg/util/registry"
+)
+
+const (
+	// ManifestFile is the file, at the root of a bundle, describing its content.
+	ManifestFile = "camel-k-bundle.yaml"
+	// ImagePrefix is the prefix of the bundle locations referencing an OCI image.
+	ImagePrefix = "oci://"
+)
+
+var Log = log.WithName("offline")
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	// RuntimeProvider is the provider of the Camel K runtime the bundle has been resolved for.
+	RuntimeProvider string `yaml:"runtimeProvider"`
+	// RuntimeVersion is the version of the Camel K runtime the bundle has been resolved for.
+	RuntimeVersion string `yaml:"runtimeVersion"`
+	// Dependencies are the dependencies the bundle has been resolved for, in addition to the runtime ones.
+	Dependencies []string `yaml:"dependencies,omitempty"`
+	// Created is the creation time of the bundle, in RFC 3339 format.
+	Created string `yaml:"created,omitempty"`
+}
+
+// ReadManifest reads the manifest of the bundle in the given directory. It returns nil if the bundle has no manifest,
+// e.g. when it is a plain Maven repository.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid bundle manifest %s: %w", ManifestFile, err)
+	}
+
+	return &m, nil
+}
+
+// WriteManifest writes the manifest of the bundle in the given directory.
+func WriteManifest(dir string, m Manifest) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, ManifestFile), data, utilio.FilePerm644)
+}
+
+// Resolve returns the directory holding the bundle at the given location, extracting it first when it is an archive
+// or an image. Extracted bundles are cached in the temporary directory, so that they are extracted once. Images are
+// pulled with the credentials of the secret of the given registry, in the given namespace.
+func Resolve(ctx context.Context, c client.Client, namespace string, reg v1.RegistrySpec, location string) (string, error) {
+	switch {
+	case strings.HasPrefix(location, ImagePrefix):
+		return resolveImage(ctx, c, namespace, reg, strings.TrimPrefix(location, ImagePrefix))
+	case strings.HasSuffix(location, ".tar.gz") || strings.HasSuffix(location, ".tgz"):
+		return resolveArchive(location)
+	default:
+		info, err := os.Stat(location)
+		if err != nil {
+			return "", fmt.Errorf("unable to access the offline bundle: %w", err)
+		}
+		if !info.IsDir() {
+			return "", fmt.Errorf("the offline bundle %s is neither a directory nor a .tar.gz archive", location)
+		}
+		return location, nil
+	}
+}
+
+func resolveArchive(location string) (string, error) {
+	info, err := os.Stat(location)
+	if err != nil {
+		return "", fmt.Errorf("unable to access the offline bundle: %w", err)
+	}
+	key := fmt.Sprintf("%s:%d:%d", location, info.Size(), info.ModTime().UnixNano())
+
+	return cached(key, func(dir string) error {
+		f, err := os.Open(location)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return extractTarGz(f, dir)
+	})
+}
+
+func resolveImage(ctx context.Context, c client.Client, namespace string, reg v1.RegistrySpec, image string) (string, error) {
+	ref, err := imageReference(image, reg)
+	if err != nil {
+		return "", fmt.Errorf("invalid offline bundle image %s: %w", image, err)
+	}
+	keychain, err := registry.NewKeychain(ctx, c, namespace, reg.Secret)
+	if err != nil {
+		return "", fmt.Errorf("unable to get the credentials of registry %s: %w", reg.Address, err)
+	}
+	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return "", fmt.Errorf("unable to pull the offline bundle image %s: %w", image, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return cached(digest.String(), func(dir string) error {
+		rc := mutate.Extract(img)
+		defer rc.Close()
+
+		return extract(rc, dir)
+	})
+}
+
+// imageReference parses the image, pulled over plain HTTP when it is hosted on the given insecure registry.
+func imageReference(image string, reg v1.RegistrySpec) (name.Reference, error) {
+	var options []name.Option
+	if reg.Insecure && reg.Address != "" && strings.HasPrefix(image, reg.Address) {
+		options = append(options, name.Insecure)
+	}
+
+	return name.ParseReference(image, options...)
+}
+
+// cached returns the cache directory matching the given key, populating it with the given function if needed.
+func cached(key string, populate func(dir string) error) (string, error) {
+	sum := sha256.Sum256([]byte(key))
+	root := filepath.Join(os.TempDir(), "camel-k-bundles")
+	dir := filepath.Join(root, hex.EncodeToString(sum[:])[:16])
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+	if err := os.MkdirAll(root, utilio.FilePerm755); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(root, "extract-")
+	if err != nil {
+		return "", err
+	}
+	Log.Infof("Extracting offline bundle into %s", dir)
+	if err := populate(tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", fmt.Errorf("unable to extract the offline bundle: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.RemoveAll(tmp)
+		// Another build may have extracted the same bundle concurrently
+		if _, statErr := os.Stat(dir); statErr == nil {
+			return dir, nil
+		}
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func extractTarGz(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	return extract(gz, dir)
+}
+
+// extract writes the directories and the regular files of the given tar stream into the directory.
+func extract(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.Clean("/"+header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, utilio.FilePerm755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), utilio.FilePerm755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, utilio.FilePerm644)
+			if err != nil {
+				return err
+			}
+			//nolint:gosec
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isLocalRepositoryFile returns whether the file is specific to the local repository it has been resolved into,
+// and must not be part of a bundle.
+func isLocalRepositoryFile(file string) bool {
+	base := filepath.Base(file)
+	return base == "_remote.repositories" || base == "resolver-status.properties" ||
+		strings.HasSuffix(base, ".lastUpdated") ||
+		(strings.HasPrefix(base, "maven-metadata-") && strings.HasSuffix(base, ".xml"))
+}
+
+// Archive writes the bundle in the given directory as a .tar.gz archive, leaving out the files specific to the
+// local repository the dependencies have been resolved into.
+func Archive(dir string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || rel == "." || isLocalRepositoryFile(file) {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// Push publishes the given bundle archive as a single layer OCI image, and returns the digest of the image.
+func Push(ctx context.Context, archive string, image string) (string, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(image, ImagePrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid image %s: %w", image, err)
+	}
+	layer, err := tarball.LayerFromFile(archive)
+	if err != nil {
+		return "", err
+	}
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", err
+	}
+	if err := remote.Write(ref, img, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+		return "", fmt.Errorf("unable to push the bundle image %s: %w", image, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return digest.String(), nil
+}
+
+// Missing returns the coordinates of the artifacts of the dependency tree of the project that cannot be found in the
+// bundle in the given directory. The tree is resolved from the POMs of the bundle, as Maven would resolve it: the
+// imported BOMs and the dependency management of the project manage the versions of the transitive dependencies, and
+// the optional, test and provided dependencies as well as the exclusions are left out. The tree of each plugin is
+// resolved the same way. The dependencies whose version cannot be determined are looked up by group and artifact ids
+// only.
+func Missing(dir string, project maven.Project) []string {
+	_, missing := Artifacts(dir, project)
+
+	return missing
+}
+
+// Artifacts returns the artifacts of the dependency tree of the project, including the POMs of the dependencies, their
+// parents and the imported BOMs, that are found in the repository in the given directory, and the coordinates of the
+// ones that cannot be found. See Missing.
+func Artifacts(dir string, project maven.Project) ([]maven.Dependency, []string) {
+	t := newTree(dir)
+
+	managed := make(map[string]maven.Dependency)
+	if project.DependencyManagement != nil {
+		imports := make([]maven.Dependency, 0)
+		for _, d := range project.DependencyManagement.Dependencies {
+			if d.Scope == "import" {
+				imports = append(imports, d)
+				continue
+			}
+			managed[managementKey(d)] = d
+		}
+		for _, d := range imports {
+			bom := t.load(d.GroupID, d.ArtifactID, d.Version)
+			if bom == nil {
+				continue
+			}
+			for k, v := range bom.managed {
+				if _, ok := managed[k]; !ok {
+					managed[k] = v
+				}
+			}
+		}
+	}
+
+	dependencies := make([]pomDependency, 0, len(project.Dependencies))
+	for _, d := range project.Dependencies {
+		if d.Scope == "test" || d.Scope == "system" {
+			continue
+		}
+		dependencies = append(dependencies, fromDependency(d))
+	}
+	t.walk(dependencies, managed)
+
+	if project.Build != nil {
+		for _, p := range project.Build.Plugins {
+			plugin := pomDependency{GroupID: p.GroupID, ArtifactID: p.ArtifactID, Version: p.Version}
+			t.walk([]pomDependency{plugin}, nil)
+		}
+	}
+
+	gavs := make([]string, 0, len(t.found))
+	for gav := range t.found {
+		gavs = append(gavs, gav)
+	}
+	sort.Strings(gavs)
+	artifacts := make([]maven.Dependency, 0, len(gavs))
+	for _, gav := range gavs {
+		artifacts = append(artifacts, t.found[gav])
+	}
+	coordinates := make([]string, 0, len(t.missing))
+	for gav := range t.missing {
+		coordinates = append(coordinates, gav)
+	}
+	sort.Strings(coordinates)
+
+	return artifacts, coordinates
+}
+
+func extensionOf(packaging string) string {
+	switch packaging {
+	case "", "bundle", "maven-plugin", "test-jar":
+		return "jar"
+	default:
+		return packaging
+	}
+}
+
+// contains returns whether the artifact is found in the repository in the given directory.
+func contains(dir string, d maven.Dependency, extension string) bool {
+	artifactDir := filepath.Join(append([]string{dir}, strings.Split(d.GroupID, ".")...)...)
+	artifactDir = filepath.Join(artifactDir, d.ArtifactID)
+	if d.Version == "" {
+		entries, err := os.ReadDir(artifactDir)
+		return err == nil && len(entries) > 0
+	}
+	file := d.ArtifactID + "-" + d.Version
+	if d.Classifier != "" {
+		file += "-" + d.Classifier
+	}
+	_, err := os.Stat(filepath.Join(artifactDir, d.Version, file+"."+extension))
+
+	return err == nil
+}
@@ -0,0 +1,321 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"archive/zip"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+func newBundle(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, file := range []string{
+		"org/apache/camel/k/camel-k-runtime-bom/3.15.2/camel-k-runtime-bom-3.15.2.pom",
+		"org/apache/camel/k/camel-k-runtime-bom/3.15.2/_remote.repositories",
+		"org/apache/camel/quarkus/camel-quarkus-timer/3.15.0/camel-quarkus-timer-3.15.0.jar",
+		"io/quarkus/quarkus-maven-plugin/3.15.1/quarkus-maven-plugin-3.15.1.jar",
+		"io/quarkus/quarkus-maven-plugin/3.15.1/quarkus-maven-plugin-3.15.1.jar.lastUpdated",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(file), 0o600))
+	}
+	require.NoError(t, WriteManifest(dir, Manifest{RuntimeProvider: "quarkus", RuntimeVersion: "3.15.2"}))
+
+	return dir
+}
+
+func TestManifest(t *testing.T) {
+	m, err := ReadManifest(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	m, err = ReadManifest(newBundle(t))
+	require.NoError(t, err)
+	assert.Equal(t, &Manifest{RuntimeProvider: "quarkus", RuntimeVersion: "3.15.2"}, m)
+}
+
+func TestArchiveAndResolve(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	require.NoError(t, Archive(newBundle(t), f))
+	require.NoError(t, f.Close())
+
+	dir, err := Resolve(context.TODO(), nil, "", v1.RegistrySpec{}, archive)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	assert.FileExists(t, filepath.Join(dir, ManifestFile))
+	assert.FileExists(t, filepath.Join(dir, "org/apache/camel/k/camel-k-runtime-bom/3.15.2/camel-k-runtime-bom-3.15.2.pom"))
+	assert.NoFileExists(t, filepath.Join(dir, "org/apache/camel/k/camel-k-runtime-bom/3.15.2/_remote.repositories"))
+	assert.NoFileExists(t, filepath.Join(dir, "io/quarkus/quarkus-maven-plugin/3.15.1/quarkus-maven-plugin-3.15.1.jar.lastUpdated"))
+
+	cached, err := Resolve(context.TODO(), nil, "", v1.RegistrySpec{}, archive)
+	require.NoError(t, err)
+	assert.Equal(t, dir, cached)
+}
+
+func TestPushAndResolveImage(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	require.NoError(t, Archive(newBundle(t), f))
+	require.NoError(t, f.Close())
+
+	image := ImagePrefix + strings.TrimPrefix(server.URL, "http://") + "/camel-k/bundle:3.15.2"
+	digest, err := Push(context.TODO(), archive, image)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(digest, "sha256:"))
+
+	dir, err := Resolve(context.TODO(), nil, "", v1.RegistrySpec{}, image)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	m, err := ReadManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "3.15.2", m.RuntimeVersion)
+}
+
+func TestImageReferenceInsecureRegistry(t *testing.T) {
+	reg := v1.RegistrySpec{Address: "registry.example.com:5000", Insecure: true}
+
+	ref, err := imageReference("registry.example.com:5000/camel-k/bundle:3.15.2", reg)
+	require.NoError(t, err)
+	assert.Equal(t, "http", ref.Context().Scheme())
+
+	ref, err = imageReference("quay.io/camel-k/bundle:3.15.2", reg)
+	require.NoError(t, err)
+	assert.Equal(t, "https", ref.Context().Scheme())
+}
+
+func TestResolveInvalidLocation(t *testing.T) {
+	_, err := Resolve(context.TODO(), nil, "", v1.RegistrySpec{}, filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "bundle.zip")
+	require.NoError(t, os.WriteFile(file, []byte{}, 0o600))
+	_, err = Resolve(context.TODO(), nil, "", v1.RegistrySpec{}, file)
+	require.EqualError(t, err, "the offline bundle "+file+" is neither a directory nor a .tar.gz archive")
+}
+
+func TestMissing(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"org/apache/camel/k/camel-k-runtime-bom/3.15.2/camel-k-runtime-bom-3.15.2.pom": `<project>
+  <groupId>org.apache.camel.k</groupId>
+  <artifactId>camel-k-runtime-bom</artifactId>
+  <version>3.15.2</version>
+  <properties>
+    <camel-quarkus.version>3.15.0</camel-quarkus.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.apache.camel.quarkus</groupId>
+        <artifactId>camel-quarkus-timer</artifactId>
+        <version>${camel-quarkus.version}</version>
+      </dependency>
+      <dependency>
+        <groupId>org.acme</groupId>
+        <artifactId>overridden</artifactId>
+        <version>1.1</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+		"org/apache/camel/quarkus/camel-quarkus-parent/3.15.0/camel-quarkus-parent-3.15.0.pom": `<project>
+  <groupId>org.apache.camel.quarkus</groupId>
+  <artifactId>camel-quarkus-parent</artifactId>
+  <version>3.15.0</version>
+  <properties>
+    <core.version>1.0</core.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.acme</groupId>
+        <artifactId>core</artifactId>
+        <version>${core.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+		"org/apache/camel/quarkus/camel-quarkus-timer/3.15.0/camel-quarkus-timer-3.15.0.jar": "",
+		"org/apache/camel/quarkus/camel-quarkus-timer/3.15.0/camel-quarkus-timer-3.15.0.pom": `<project>
+  <parent>
+    <groupId>org.apache.camel.quarkus</groupId>
+    <artifactId>camel-quarkus-parent</artifactId>
+    <version>3.15.0</version>
+  </parent>
+  <artifactId>camel-quarkus-timer</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>core</artifactId>
+      <exclusions>
+        <exclusion>
+          <groupId>org.acme</groupId>
+          <artifactId>excluded</artifactId>
+        </exclusion>
+      </exclusions>
+    </dependency>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>overridden</artifactId>
+      <version>1.0</version>
+    </dependency>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>optional</artifactId>
+      <version>1.0</version>
+      <optional>true</optional>
+    </dependency>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>tests</artifactId>
+      <version>1.0</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>`,
+		"org/acme/core/1.0/core-1.0.jar": "",
+		"org/acme/core/1.0/core-1.0.pom": `<project>
+  <groupId>org.acme</groupId>
+  <artifactId>core</artifactId>
+  <version>1.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>excluded</artifactId>
+      <version>1.0</version>
+    </dependency>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>transitive</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+		"org/acme/overridden/1.0/overridden-1.0.jar":                             "",
+		"org/acme/overridden/1.0/overridden-1.0.pom":                             "<project/>",
+		"io/quarkus/quarkus-maven-plugin/3.15.1/quarkus-maven-plugin-3.15.1.jar": "",
+		"io/quarkus/quarkus-maven-plugin/3.15.1/quarkus-maven-plugin-3.15.1.pom": `<project>
+  <dependencies>
+    <dependency>
+      <groupId>io.quarkus</groupId>
+      <artifactId>quarkus-bootstrap-core</artifactId>
+      <version>3.15.1</version>
+    </dependency>
+  </dependencies>
+</project>`,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	}
+
+	project := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0")
+	project.DependencyManagement = &maven.DependencyManagement{
+		Dependencies: []maven.Dependency{
+			{GroupID: "org.apache.camel.k", ArtifactID: "camel-k-runtime-bom", Version: "3.15.2", Type: "pom", Scope: "import"},
+			{GroupID: "org.acme", ArtifactID: "acme-bom", Version: "1.0", Type: "pom", Scope: "import"},
+		},
+	}
+	project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-timer", "")
+	project.AddDependencyGAV("org.apache.camel.quarkus", "camel-quarkus-kafka", "")
+	project.AddDependencyGAV("org.acme", "lib", "2.0")
+	project.Dependencies = append(project.Dependencies, maven.Dependency{GroupID: "org.acme", ArtifactID: "test", Version: "1.0", Scope: "test"})
+	project.Build = &maven.Build{
+		Plugins: []maven.Plugin{
+			{GroupID: "io.quarkus", ArtifactID: "quarkus-maven-plugin", Version: "3.15.1"},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"io.quarkus:quarkus-bootstrap-core:3.15.1",
+		"org.acme:acme-bom:1.0",
+		"org.acme:lib:2.0",
+		// The version of the transitive dependency is managed by the runtime BOM
+		"org.acme:overridden:1.1",
+		"org.acme:transitive:1.0",
+		"org.apache.camel.quarkus:camel-quarkus-kafka",
+	}, Missing(dir, project))
+}
+
+func TestArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"org/acme/ext/1.0/ext-1.0.pom": `<project>
+  <groupId>org.acme</groupId>
+  <artifactId>ext</artifactId>
+  <version>1.0</version>
+</project>`,
+		"org/acme/ext-deployment/1.0/ext-deployment-1.0.jar": "",
+		"org/acme/ext-deployment/1.0/ext-deployment-1.0.pom": `<project>
+  <groupId>org.acme</groupId>
+  <artifactId>ext-deployment</artifactId>
+  <version>1.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.acme</groupId>
+      <artifactId>transitive</artifactId>
+      <version>1.0</version>
+    </dependency>
+  </dependencies>
+</project>`,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	}
+	// The extension references its deployment artifact, that the Quarkus build resolves
+	jar, err := os.Create(filepath.Join(dir, "org/acme/ext/1.0/ext-1.0.jar"))
+	require.NoError(t, err)
+	w := zip.NewWriter(jar)
+	f, err := w.Create(quarkusExtensionDescriptor)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("deployment-artifact=org.acme\\:ext-deployment\\:1.0\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, jar.Close())
+
+	project := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0")
+	project.AddDependencyGAV("org.acme", "ext", "1.0")
+
+	artifacts, missing := Artifacts(dir, project)
+	assert.Equal(t, []maven.Dependency{
+		{GroupID: "org.acme", ArtifactID: "ext-deployment", Version: "1.0"},
+		{GroupID: "org.acme", ArtifactID: "ext", Version: "1.0"},
+	}, artifacts)
+	assert.Equal(t, []string{"org.acme:transitive:1.0"}, missing)
+}
@@ -0,0 +1,424 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package offline
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/apache/camel-k/v2/pkg/util/maven"
+)
+
+const (
+	// maxParentDepth bounds the chain of parent POMs, in case of a cycle.
+	maxParentDepth = 32
+	// quarkusExtensionDescriptor is the descriptor of the Quarkus extensions, referencing their deployment artifact.
+	quarkusExtensionDescriptor = "META-INF/quarkus-extension.properties"
+	deploymentArtifactProperty = "deployment-artifact="
+)
+
+var propertyReference = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// pom is the subset of a POM needed to resolve the dependency tree of an artifact.
+type pom struct {
+	Parent *struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+	GroupID              string          `xml:"groupId"`
+	ArtifactID           string          `xml:"artifactId"`
+	Version              string          `xml:"version"`
+	Properties           pomProperties   `xml:"properties"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string            `xml:"groupId"`
+	ArtifactID string            `xml:"artifactId"`
+	Version    string            `xml:"version"`
+	Type       string            `xml:"type"`
+	Classifier string            `xml:"classifier"`
+	Scope      string            `xml:"scope"`
+	Optional   string            `xml:"optional"`
+	Exclusions []maven.Exclusion `xml:"exclusions>exclusion"`
+}
+
+type pomProperties map[string]string
+
+func (p *pomProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = make(pomProperties)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// effectivePOM is a POM merged with its parents, with interpolated coordinates, and whose imported BOMs have been
+// merged into its dependency management.
+type effectivePOM struct {
+	properties   map[string]string
+	managed      map[string]maven.Dependency
+	dependencies []pomDependency
+}
+
+// tree resolves the dependency tree of a project against the repository in a directory, collecting the artifacts,
+// and the POMs, that are found, and the coordinates of the ones that cannot be found.
+type tree struct {
+	dir     string
+	poms    map[string]*effectivePOM
+	found   map[string]maven.Dependency
+	missing map[string]bool
+}
+
+func newTree(dir string) *tree {
+	return &tree{
+		dir:     dir,
+		poms:    make(map[string]*effectivePOM),
+		found:   make(map[string]maven.Dependency),
+		missing: make(map[string]bool),
+	}
+}
+
+func (t *tree) report(d maven.Dependency) {
+	gav := d.GroupID + ":" + d.ArtifactID
+	if d.Version != "" {
+		gav += ":" + d.Version
+	}
+	t.missing[gav] = true
+}
+
+// check reports the artifact if it cannot be found, and returns whether it has been found.
+func (t *tree) check(d maven.Dependency, extension string) bool {
+	if contains(t.dir, d, extension) {
+		if d.Version != "" {
+			t.found[d.GroupID+":"+d.ArtifactID+":"+d.Version] = maven.Dependency{GroupID: d.GroupID, ArtifactID: d.ArtifactID, Version: d.Version}
+		}
+		return true
+	}
+	t.report(d)
+
+	return false
+}
+
+// load returns the effective POM of the given artifact, or nil if it, or one of its parents, cannot be found.
+func (t *tree) load(groupID string, artifactID string, version string) *effectivePOM {
+	return t.loadAt(groupID, artifactID, version, 0)
+}
+
+func (t *tree) loadAt(groupID string, artifactID string, version string, depth int) *effectivePOM {
+	key := groupID + ":" + artifactID + ":" + version
+	if p, ok := t.poms[key]; ok {
+		return p
+	}
+	// Marks the POM as being loaded, in case of a cycle
+	t.poms[key] = nil
+	if depth > maxParentDepth {
+		return nil
+	}
+
+	d := maven.Dependency{GroupID: groupID, ArtifactID: artifactID, Version: version}
+	if !t.check(d, "pom") {
+		return nil
+	}
+	data, err := os.ReadFile(artifactPath(t.dir, d, "pom"))
+	if err != nil {
+		t.report(d)
+		return nil
+	}
+	var model pom
+	if err := xml.Unmarshal(data, &model); err != nil {
+		Log.Debugf("Unable to parse the POM of %s: %v", key, err)
+		return nil
+	}
+
+	properties := make(map[string]string)
+	effective := effectivePOM{properties: properties, managed: make(map[string]maven.Dependency)}
+	if model.Parent != nil {
+		parent := t.loadAt(model.Parent.GroupID, model.Parent.ArtifactID, model.Parent.Version, depth+1)
+		if parent == nil {
+			return nil
+		}
+		for k, v := range parent.properties {
+			properties[k] = v
+		}
+		for k, v := range parent.managed {
+			effective.managed[k] = v
+		}
+		effective.dependencies = append(effective.dependencies, parent.dependencies...)
+		properties["project.parent.version"] = model.Parent.Version
+		if model.GroupID == "" {
+			model.GroupID = model.Parent.GroupID
+		}
+		if model.Version == "" {
+			model.Version = model.Parent.Version
+		}
+	}
+	for k, v := range model.Properties {
+		properties[k] = v
+	}
+	properties["project.groupId"] = model.GroupID
+	properties["project.artifactId"] = model.ArtifactID
+	properties["project.version"] = model.Version
+	properties["pom.version"] = model.Version
+
+	imports := make([]maven.Dependency, 0)
+	for _, m := range model.DependencyManagement {
+		dependency := interpolate(m, properties)
+		if dependency.Scope == "import" {
+			imports = append(imports, dependency)
+			continue
+		}
+		effective.managed[managementKey(dependency)] = dependency
+	}
+	// The imported BOMs do not override the versions managed by the POM itself
+	for _, bom := range imports {
+		if imported := t.loadAt(bom.GroupID, bom.ArtifactID, bom.Version, depth+1); imported != nil {
+			for k, v := range imported.managed {
+				if _, ok := effective.managed[k]; !ok {
+					effective.managed[k] = v
+				}
+			}
+		}
+	}
+	for _, dependency := range model.Dependencies {
+		effective.dependencies = append(effective.dependencies, toPOMDependency(interpolate(dependency, properties), dependency))
+	}
+
+	t.poms[key] = &effective
+
+	return &effective
+}
+
+// walk resolves the transitive dependencies of the given ones, the versions managed by the given dependency management
+// taking precedence over the ones of the transitive dependencies, as with Maven. The dependencies are visited breadth
+// first, so that the nearest version of an artifact wins. The deployment artifacts of the Quarkus extensions, that the
+// Quarkus build resolves, are part of the tree too.
+func (t *tree) walk(dependencies []pomDependency, managed map[string]maven.Dependency) {
+	type node struct {
+		dependency pomDependency
+		exclusions []maven.Exclusion
+		transitive bool
+	}
+	queue := make([]node, 0, len(dependencies))
+	for _, d := range dependencies {
+		queue = append(queue, node{dependency: d})
+	}
+	visited := make(map[string]bool)
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		d := n.dependency
+		dependency := d.toDependency()
+		if visited[managementKey(dependency)] {
+			continue
+		}
+		visited[managementKey(dependency)] = true
+
+		if m, ok := managed[managementKey(dependency)]; ok && (dependency.Version == "" || n.transitive) {
+			dependency.Version = m.Version
+		}
+		if dependency.Version == "" || strings.Contains(dependency.Version, "${") {
+			// The version cannot be determined, the artifact is looked up by group and artifact ids only
+			dependency.Version = ""
+			t.check(dependency, extensionOf(dependency.Type))
+			continue
+		}
+		if !t.check(dependency, extensionOf(dependency.Type)) {
+			continue
+		}
+		p := t.load(dependency.GroupID, dependency.ArtifactID, dependency.Version)
+		if p == nil {
+			continue
+		}
+
+		exclusions := append(append([]maven.Exclusion{}, n.exclusions...), d.Exclusions...)
+		if extensionOf(dependency.Type) == "jar" {
+			if deployment := deploymentArtifact(artifactPath(t.dir, dependency, "jar")); deployment != nil {
+				queue = append(queue, node{dependency: *deployment, exclusions: exclusions, transitive: true})
+			}
+		}
+		for _, child := range p.dependencies {
+			if !isTransitive(child) || isExcluded(child, exclusions) {
+				continue
+			}
+			if child.Version == "" {
+				if m, ok := p.managed[managementKey(child.toDependency())]; ok {
+					child.Version = m.Version
+				}
+			}
+			queue = append(queue, node{dependency: child, exclusions: exclusions, transitive: true})
+		}
+	}
+}
+
+// deploymentArtifact returns the deployment artifact of the Quarkus extension in the given jar, if it is one.
+func deploymentArtifact(jar string) *pomDependency {
+	r, err := zip.OpenReader(jar)
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+	f, err := r.Open(quarkusExtensionDescriptor)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, deploymentArtifactProperty) {
+			continue
+		}
+		// The colons are escaped in the properties file
+		value := strings.ReplaceAll(strings.TrimPrefix(line, deploymentArtifactProperty), `\:`, ":")
+		gav := strings.Split(value, ":")
+		if len(gav) != 3 {
+			return nil
+		}
+		return &pomDependency{GroupID: gav[0], ArtifactID: gav[1], Version: gav[2]}
+	}
+
+	return nil
+}
+
+func (d pomDependency) toDependency() maven.Dependency {
+	return maven.Dependency{
+		GroupID:    d.GroupID,
+		ArtifactID: d.ArtifactID,
+		Version:    d.Version,
+		Type:       d.Type,
+		Classifier: d.Classifier,
+		Scope:      d.Scope,
+	}
+}
+
+func toPOMDependency(d maven.Dependency, source pomDependency) pomDependency {
+	return pomDependency{
+		GroupID:    d.GroupID,
+		ArtifactID: d.ArtifactID,
+		Version:    d.Version,
+		Type:       d.Type,
+		Classifier: d.Classifier,
+		Scope:      d.Scope,
+		Optional:   source.Optional,
+		Exclusions: source.Exclusions,
+	}
+}
+
+func fromDependency(d maven.Dependency) pomDependency {
+	p := pomDependency{
+		GroupID:    d.GroupID,
+		ArtifactID: d.ArtifactID,
+		Version:    d.Version,
+		Type:       d.Type,
+		Classifier: d.Classifier,
+		Scope:      d.Scope,
+	}
+	if d.Exclusions != nil {
+		p.Exclusions = *d.Exclusions
+	}
+
+	return p
+}
+
+func interpolate(d pomDependency, properties map[string]string) maven.Dependency {
+	dependency := d.toDependency()
+	dependency.GroupID = interpolateValue(dependency.GroupID, properties)
+	dependency.ArtifactID = interpolateValue(dependency.ArtifactID, properties)
+	dependency.Version = interpolateValue(dependency.Version, properties)
+	dependency.Classifier = interpolateValue(dependency.Classifier, properties)
+
+	return dependency
+}
+
+// interpolateValue replaces the property references of the value, leaving the unknown ones unchanged.
+func interpolateValue(value string, properties map[string]string) string {
+	for i := 0; i < maxParentDepth && strings.Contains(value, "${"); i++ {
+		replaced := propertyReference.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := properties[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+		if replaced == value {
+			break
+		}
+		value = replaced
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// managementKey identifies a dependency in a dependency management section.
+func managementKey(d maven.Dependency) string {
+	return d.GroupID + ":" + d.ArtifactID + ":" + extensionOf(d.Type) + ":" + d.Classifier
+}
+
+// isTransitive returns whether the dependency of an artifact is part of the runtime classpath of its dependents.
+func isTransitive(d pomDependency) bool {
+	if strings.TrimSpace(d.Optional) == "true" {
+		return false
+	}
+	switch d.Scope {
+	case "", "compile", "runtime":
+		return true
+	default:
+		return false
+	}
+}
+
+func isExcluded(d pomDependency, exclusions []maven.Exclusion) bool {
+	for _, e := range exclusions {
+		if (e.GroupID == "*" || e.GroupID == d.GroupID) && (e.ArtifactID == "*" || e.ArtifactID == d.ArtifactID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func artifactPath(dir string, d maven.Dependency, extension string) string {
+	artifactDir := filepath.Join(append([]string{dir}, strings.Split(d.GroupID, ".")...)...)
+	file := d.ArtifactID + "-" + d.Version
+	if d.Classifier != "" && extension != "pom" {
+		file += "-" + d.Classifier
+	}
+
+	return filepath.Join(artifactDir, d.ArtifactID, d.Version, file+"."+extension)
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces the redacted values.
+const Placeholder = "**REDACTED**"
+
+// sensitiveKeyPattern matches the property, environment variable and header names that are likely to hold credentials.
+const sensitiveKeyPattern = `passw(?:or)?d|pwd|secret|token|credential|api[._-]?key|access[._-]?key|private[._-]?key|passphrase|auth[._-]?(?:token|header|key)|authorization`
+
+var sensitiveKey = regexp.MustCompile(`(?i)(` + sensitiveKeyPattern + `)`)
+
+// sensitivePair matches a key=value or key: value pair with a sensitive key anywhere in a line, e.g. in a log line
+// prefixed with a timestamp, or in a YAML or JSON document. The value is either quoted, or ends at the first
+// whitespace or separator.
+var sensitivePair = regexp.MustCompile(`(?i)([\w.\-]*(?:` + sensitiveKeyPattern + `)[\w.\-]*"?[ \t]*[=:][ \t]*)("[^"]*"|'[^']*'|[^\s,;&"']+)`)
+
+// propertyLine matches a key=value or key: value properties line.
+var propertyLine = regexp.MustCompile(`^(\s*[^#!\s][^=:]*?\s*[=:]\s*)(.*)$`)
+
+// IsSensitiveKey returns true if the name of the property is likely to reference a credential.
+func IsSensitiveKey(key string) bool {
+	return sensitiveKey.MatchString(key)
+}
+
+// Property returns the redacted value of the property, if its name is sensitive.
+func Property(key string, value string) string {
+	if value != "" && IsSensitiveKey(key) {
+		return Placeholder
+	}
+	return value
+}
+
+// KeyValue redacts a `key=value` pair, if the key is sensitive.
+func KeyValue(pair string) string {
+	key, value, ok := strings.Cut(pair, "=")
+	if !ok {
+		return pair
+	}
+	return key + "=" + Property(key, value)
+}
+
+// Properties redacts the sensitive values of a properties file content.
+func Properties(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		m := propertyLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		key := strings.TrimRight(m[1], " \t=:")
+		if IsSensitiveKey(key) && strings.TrimSpace(m[2]) != "" {
+			lines[i] = m[1] + Placeholder
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Text redacts the values of the sensitive pairs found anywhere in a free form text, such as a log, an event message,
+// or a YAML configuration file.
+func Text(content string) string {
+	return sensitivePair.ReplaceAllStringFunc(content, func(pair string) string {
+		m := sensitivePair.FindStringSubmatch(pair)
+		return m[1] + Placeholder
+	})
+}
+
+// Object redacts in place the sensitive values of an unstructured object, as decoded from JSON:
+//   - values of map entries with a sensitive key,
+//   - values of name/value pairs with a sensitive name (i.e. container environment variables),
+//   - key=value strings with a sensitive key (i.e. trait properties),
+//   - sensitive values of embedded properties files,
+//   - sensitive pairs of the other strings (i.e. embedded YAML files or status messages).
+func Object(obj interface{}) interface{} {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		if name, ok := o["name"].(string); ok && IsSensitiveKey(name) {
+			if v, ok := o["value"].(string); ok {
+				o["value"] = Property(name, v)
+			}
+		}
+		for k, v := range o {
+			if s, ok := v.(string); ok {
+				switch {
+				case strings.HasSuffix(k, ".properties"):
+					o[k] = Properties(s)
+				case k != "name" && k != "value" && IsSensitiveKey(k):
+					o[k] = Property(k, s)
+				default:
+					o[k] = Text(s)
+				}
+				continue
+			}
+			o[k] = Object(v)
+		}
+		return o
+	case []interface{}:
+		for i, v := range o {
+			if s, ok := v.(string); ok {
+				o[i] = Text(KeyValue(s))
+				continue
+			}
+			o[i] = Object(v)
+		}
+		return o
+	default:
+		return obj
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, k := range []string{"password", "db.PASSWORD", "camel.component.aws2-s3.secretKey", "API_KEY", "quarkus.oidc.credentials", "my-token", "accessKey", "Authorization", "http.auth-header", "AUTH_KEY"} {
+		assert.True(t, IsSensitiveKey(k), k)
+	}
+	for _, k := range []string{"camel.component.kafka.brokers", "replicas", "image", "username", "author", "authority", "quarkus.oauth.client-id"} {
+		assert.False(t, IsSensitiveKey(k), k)
+	}
+}
+
+func TestKeyValue(t *testing.T) {
+	assert.Equal(t, "db.password="+Placeholder, KeyValue("db.password=changeme"))
+	assert.Equal(t, "db.user=admin", KeyValue("db.user=admin"))
+	assert.Equal(t, "no-value", KeyValue("no-value"))
+}
+
+func TestProperties(t *testing.T) {
+	content := `# a comment with a password
+db.user=admin
+db.password = changeme
+api.token: abc
+empty.secret=
+`
+	expected := `# a comment with a password
+db.user=admin
+db.password = ` + Placeholder + `
+api.token: ` + Placeholder + `
+empty.secret=
+`
+	assert.Equal(t, expected, Properties(content))
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "2024-01-01 12:00:00 INFO connecting with db.password="+Placeholder+" and db.user=admin",
+		Text("2024-01-01 12:00:00 INFO connecting with db.password=secret and db.user=admin"))
+	assert.Equal(t, "db:\n  user: admin\n  password: "+Placeholder+"\n",
+		Text("db:\n  user: admin\n  password: secret\n"))
+	assert.Equal(t, `{"level":"info","apiKey":`+Placeholder+`,"msg":"started"}`,
+		Text(`{"level":"info","apiKey":"secret","msg":"started"}`))
+	assert.Equal(t, "url=http://localhost:8080 token="+Placeholder+" end",
+		Text("url=http://localhost:8080 token='a b' end"))
+	assert.Equal(t, "author=jdoe authority=ca Authorization: "+Placeholder,
+		Text("author=jdoe authority=ca Authorization: Bearer"))
+	assert.Equal(t, "nothing to redact", Text("nothing to redact"))
+}
+
+func TestObject(t *testing.T) {
+	data := `{
+	"spec": {
+		"traits": {
+			"camel": {
+				"properties": ["foo=bar", "my.secret=xyz"]
+			}
+		},
+		"template": {
+			"env": [
+				{"name": "DB_PASSWORD", "value": "changeme"},
+				{"name": "DB_HOST", "value": "localhost"},
+				{"name": "API_TOKEN", "valueFrom": {"secretKeyRef": {"name": "api", "key": "token"}}}
+			]
+		}
+	},
+	"data": {
+		"application.properties": "a=b\nsecret.key=c",
+		"application.yaml": "a: b\nclient:\n  secret: c\nclient-secret: d",
+		"accessKey": "AKIA"
+	}
+}`
+	obj := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(data), &obj))
+	Object(obj)
+
+	result, err := json.Marshal(obj)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+	"spec": {
+		"traits": {
+			"camel": {
+				"properties": ["foo=bar", "my.secret=**REDACTED**"]
+			}
+		},
+		"template": {
+			"env": [
+				{"name": "DB_PASSWORD", "value": "**REDACTED**"},
+				{"name": "DB_HOST", "value": "localhost"},
+				{"name": "API_TOKEN", "valueFrom": {"secretKeyRef": {"name": "api", "key": "token"}}}
+			]
+		}
+	},
+	"data": {
+		"application.properties": "a=b\nsecret.key=**REDACTED**",
+		"application.yaml": "a: b\nclient:\n  secret: **REDACTED**\nclient-secret: **REDACTED**",
+		"accessKey": "**REDACTED**"
+	}
+}`, string(result))
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/v2/pkg/client"
+)
+
+// NewKeychain returns the keychain resolving the credentials of the registries from the Docker config of the given
+// Secret, if any, then from the default keychain.
+func NewKeychain(ctx context.Context, c client.Client, namespace, name string) (authn.Keychain, error) {
+	if name == "" {
+		return authn.DefaultKeychain, nil
+	}
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	k := configKeychain{}
+	for file, content := range secret.Data {
+		if remap(file) != "config.json" {
+			continue
+		}
+		if err := json.Unmarshal(content, &k.config); err != nil {
+			return nil, err
+		}
+	}
+
+	return authn.NewMultiKeychain(&k, authn.DefaultKeychain), nil
+}
+
+// configKeychain resolves the credentials of the registries from a Docker config.
+type configKeychain struct {
+	config DockerConfigList
+}
+
+func (k *configKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	servers := []string{target.RegistryStr()}
+	if target.RegistryStr() == name.DefaultRegistry {
+		servers = append(servers, strings.Split(knownServersByRegistry["docker.io"], ",")...)
+	}
+	for _, server := range servers {
+		c, ok := k.config.Auths[server]
+		if !ok {
+			continue
+		}
+		if c.Username == "" && c.Auth != "" {
+			if decoded, err := base64.StdEncoding.DecodeString(c.Auth); err == nil {
+				if username, password, ok := strings.Cut(string(decoded), ":"); ok {
+					return authn.FromConfig(authn.AuthConfig{Username: username, Password: password}), nil
+				}
+			}
+		}
+		return authn.FromConfig(authn.AuthConfig{Username: c.Username, Password: c.Password}), nil
+	}
+
+	return authn.Anonymous, nil
+}
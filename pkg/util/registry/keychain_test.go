@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/v2/pkg/internal"
+)
+
+func TestNewKeychain(t *testing.T) {
+	conf, err := Auth{Username: "nic", Password: "secret", Registry: "docker.io"}.GenerateDockerConfig()
+	require.NoError(t, err)
+	secret := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "my-secret",
+		},
+		Type: v1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			v1.DockerConfigJsonKey: conf,
+		},
+	}
+	c, err := internal.NewFakeClient(&secret)
+	require.NoError(t, err)
+
+	keychain, err := NewKeychain(context.TODO(), c, "test", "my-secret")
+	require.NoError(t, err)
+	repo, err := name.NewRepository("camel-k/kit")
+	require.NoError(t, err)
+	auth, err := keychain.Resolve(repo)
+	require.NoError(t, err)
+	config, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "nic", config.Username)
+	assert.Equal(t, "secret", config.Password)
+
+	repo, err = name.NewRepository("quay.io/camel-k/kit")
+	require.NoError(t, err)
+	auth, err = (&configKeychain{}).Resolve(repo)
+	require.NoError(t, err)
+	assert.Equal(t, authn.Anonymous, auth)
+
+	_, err = NewKeychain(context.TODO(), c, "test", "missing")
+	require.Error(t, err)
+}
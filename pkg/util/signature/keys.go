@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PrivateKeyEntry is the entry of the keys Secret holding the private key the images are signed with.
+	PrivateKeyEntry = "cosign.key"
+	// PasswordEntry is the entry of the keys Secret holding the password of the private key, if encrypted.
+	PasswordEntry = "cosign.password"
+	// PublicKeyEntry is the entry of the keys Secret holding the public key the signatures are verified with.
+	PublicKeyEntry = "cosign.pub"
+)
+
+// The PEM block types of the private keys encrypted by cosign.
+var encryptedKeyTypes = map[string]bool{
+	"ENCRYPTED SIGSTORE PRIVATE KEY": true,
+	"ENCRYPTED COSIGN PRIVATE KEY":   true,
+}
+
+// encryptedKey is the content of a private key encrypted by cosign.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// ParsePrivateKey parses a PEM encoded ECDSA private key, either in PKCS #8 or SEC 1 form, or encrypted with the given
+// password as generated by `cosign generate-key-pair`.
+func ParsePrivateKey(data []byte, password []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	der := block.Bytes
+	switch {
+	case encryptedKeyTypes[block.Type]:
+		var err error
+		if der, err = decrypt(block.Bytes, password); err != nil {
+			return nil, err
+		}
+	case block.Type == "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(der)
+	case block.Type != "PRIVATE KEY":
+		return nil, fmt.Errorf("unsupported private key type %s", block.Type)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key %T, an ECDSA key is expected", key)
+	}
+
+	return ecdsaKey, nil
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA public key.
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key %T, an ECDSA key is expected", key)
+	}
+
+	return ecdsaKey, nil
+}
+
+// decrypt decrypts a private key encrypted by cosign, with a key derived from the password with scrypt.
+func decrypt(data []byte, password []byte) ([]byte, error) {
+	var k encryptedKey
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("invalid encrypted private key: %w", err)
+	}
+	if k.KDF.Name != "scrypt" || k.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported private key encryption %s with %s", k.Cipher.Name, k.KDF.Name)
+	}
+	if len(k.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid encrypted private key nonce")
+	}
+
+	secret, err := scrypt.Key(password, k.KDF.Salt, k.KDF.Params.N, k.KDF.Params.R, k.KDF.Params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	var key [32]byte
+	var nonce [24]byte
+	copy(key[:], secret)
+	copy(nonce[:], k.Cipher.Nonce)
+	der, ok := secretbox.Open(nil, k.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.New("cannot decrypt the private key: wrong password")
+	}
+
+	return der, nil
+}
@@ -0,0 +1,198 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature signs the container images and verifies their signatures, in the Sigstore format used by cosign:
+// the signatures of an image are the layers of an image tagged after the digest of the signed image, in the same
+// repository, so that they can be verified with `cosign verify --key cosign.pub <image>`.
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the signature layers.
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// SignatureAnnotation is the annotation of the signature layers holding the base64 encoded signature.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SignatureTagSuffix is the suffix of the tag of the signature image.
+	SignatureTagSuffix = ".sig"
+
+	payloadType = "cosign container image signature"
+)
+
+var (
+	// ErrUnsigned is returned when the image has no signature.
+	ErrUnsigned = errors.New("image is not signed")
+	// ErrInvalidSignature is returned when no signature of the image is valid for the public key.
+	ErrInvalidSignature = errors.New("image signature is not valid")
+)
+
+// payload is the simple signing payload, holding the digest of the signed image.
+type payload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// SignatureTag returns the tag of the image holding the signatures of the image with the given digest.
+func SignatureTag(digest name.Digest) name.Tag {
+	return digest.Repository.Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + SignatureTagSuffix)
+}
+
+// Sign signs the image with the given digest, and pushes the signature to the registry, next to the previous
+// signatures of the image, if any.
+func Sign(digest name.Digest, key *ecdsa.PrivateKey, opts ...remote.Option) error {
+	data, err := json.Marshal(newPayload(digest))
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		return err
+	}
+
+	tag := SignatureTag(digest)
+	base, err := remote.Image(tag, opts...)
+	if isNotFound(err) {
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	} else if err != nil {
+		return err
+	}
+	img, err := mutate.Append(base, mutate.Addendum{
+		Layer: static.NewLayer(data, SimpleSigningMediaType),
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return remote.Write(tag, img, opts...)
+}
+
+// Verify verifies that the image is signed with the private key of the given public key, and returns the digest of the
+// verified image. It returns an error wrapping ErrUnsigned if the image has no signature, or ErrInvalidSignature if no
+// signature is valid, e.g. when the image has been tampered with.
+func Verify(ref name.Reference, key *ecdsa.PublicKey, opts ...remote.Option) (name.Digest, error) {
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		desc, err := remote.Head(ref, opts...)
+		if err != nil {
+			return name.Digest{}, err
+		}
+		digest = ref.Context().Digest(desc.Digest.String())
+	}
+
+	tag := SignatureTag(digest)
+	img, err := remote.Image(tag, opts...)
+	if isNotFound(err) {
+		return digest, fmt.Errorf("%w: no signature found for %s", ErrUnsigned, digest.String())
+	} else if err != nil {
+		return digest, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return digest, err
+	}
+
+	for _, desc := range manifest.Layers {
+		if desc.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		if err := verifyLayer(img, desc, digest, key); err == nil {
+			return digest, nil
+		}
+	}
+
+	return digest, fmt.Errorf("%w: no signature of %s matches the public key", ErrInvalidSignature, digest.String())
+}
+
+// verifyLayer verifies the signature of the given layer, and that its payload is about the given digest.
+func verifyLayer(img containerv1.Image, desc containerv1.Descriptor, digest name.Digest, key *ecdsa.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(desc.Annotations[SignatureAnnotation])
+	if err != nil {
+		return err
+	}
+	layer, err := img.LayerByDigest(desc.Digest)
+	if err != nil {
+		return err
+	}
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(key, hash[:], sig) {
+		return ErrInvalidSignature
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if p.Critical.Type != payloadType || p.Critical.Image.DockerManifestDigest != digest.DigestStr() {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func newPayload(digest name.Digest) payload {
+	p := payload{}
+	p.Critical.Identity.DockerReference = digest.Repository.Name()
+	p.Critical.Image.DockerManifestDigest = digest.DigestStr()
+	p.Critical.Type = payloadType
+	return p
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,138 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+func pushRandomImage(t *testing.T, image string) name.Digest {
+	t.Helper()
+
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	return ref.Context().Digest(digest.String())
+}
+
+func TestSignAndVerify(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	image := strings.TrimPrefix(server.URL, "http://") + "/ns/camel-k-kit:1"
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	digest := pushRandomImage(t, image)
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+
+	_, err = Verify(ref, &key.PublicKey)
+	require.ErrorIs(t, err, ErrUnsigned)
+
+	require.NoError(t, Sign(digest, key))
+	verified, err := Verify(ref, &key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, digest.String(), verified.String())
+	_, err = Verify(digest, &key.PublicKey)
+	require.NoError(t, err)
+
+	// Signed with another key
+	_, err = Verify(ref, &other.PublicKey)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	// The signatures are appended
+	require.NoError(t, Sign(digest, other))
+	_, err = Verify(ref, &other.PublicKey)
+	require.NoError(t, err)
+	_, err = Verify(ref, &key.PublicKey)
+	require.NoError(t, err)
+
+	// The tag now references a different image
+	pushRandomImage(t, image)
+	_, err = Verify(ref, &key.PublicKey)
+	require.ErrorIs(t, err, ErrUnsigned)
+}
+
+func TestParseKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	parsed, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	parsedPub, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(parsedPub))
+
+	// A private key encrypted by cosign
+	var k encryptedKey
+	k.KDF.Name = "scrypt"
+	k.KDF.Params.N = 1024
+	k.KDF.Params.R = 8
+	k.KDF.Params.P = 1
+	k.KDF.Salt = []byte("0123456789abcdef0123456789abcdef")
+	k.Cipher.Name = "nacl/secretbox"
+	k.Cipher.Nonce = []byte("0123456789abcdef01234567")
+	secret, err := scrypt.Key([]byte("password"), k.KDF.Salt, k.KDF.Params.N, k.KDF.Params.R, k.KDF.Params.P, 32)
+	require.NoError(t, err)
+	var sk [32]byte
+	var nonce [24]byte
+	copy(sk[:], secret)
+	copy(nonce[:], k.Cipher.Nonce)
+	k.Ciphertext = secretbox.Seal(nil, der, &nonce, &sk)
+	data, err := json.Marshal(k)
+	require.NoError(t, err)
+	encrypted := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: data})
+
+	parsed, err = ParsePrivateKey(encrypted, []byte("password"))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = ParsePrivateKey(encrypted, []byte("wrong"))
+	require.EqualError(t, err, "cannot decrypt the private key: wrong password")
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "route.yaml"), []byte("data"), 0o600))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(100*time.Second))
+	defer cancel()
+	changes, err := Dir(ctx, dir)
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	// A change at the root of the tree
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "route.yaml"), []byte("data-1"), 0o600))
+	waitForChange(ctx, t, changes)
+
+	// A file added to a directory created after the watch started
+	sub := filepath.Join(dir, "resources")
+	require.NoError(t, os.Mkdir(sub, 0o700))
+	waitForChange(ctx, t, changes)
+	time.Sleep(100 * time.Millisecond)
+	drain(changes)
+	require.NoError(t, os.WriteFile(filepath.Join(sub, "data.txt"), []byte("data"), 0o600))
+	waitForChange(ctx, t, changes)
+}
+
+func waitForChange(ctx context.Context, t *testing.T, changes <-chan bool) {
+	t.Helper()
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "no change notified")
+	case changed := <-changes:
+		assert.True(t, changed)
+	}
+}
+
+func drain(changes <-chan bool) {
+	for {
+		select {
+		case <-changes:
+		case <-time.After(200 * time.Millisecond):
+			return
+		}
+	}
+}
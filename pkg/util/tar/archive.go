@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tar
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"time"
+)
+
+// ArchiveWriter writes in-memory entries to a gzip compressed tar archive.
+type ArchiveWriter struct {
+	gw *gzip.Writer
+	tw *tar.Writer
+}
+
+// NewArchiveWriter creates a gzip compressed tar archive writer. The writer must be closed to flush the archive.
+func NewArchiveWriter(out io.Writer) *ArchiveWriter {
+	gw := gzip.NewWriter(out)
+	return &ArchiveWriter{
+		gw: gw,
+		tw: tar.NewWriter(gw),
+	}
+}
+
+// AddEntry adds a regular file entry with the given path and content to the archive.
+func (a *ArchiveWriter) AddEntry(name string, data []byte) error {
+	header := tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(data)),
+		Mode:     0o644,
+		ModTime:  time.Now(),
+	}
+	if err := a.tw.WriteHeader(&header); err != nil {
+		return err
+	}
+	_, err := a.tw.Write(data)
+	return err
+}
+
+// Close flushes and closes the archive.
+func (a *ArchiveWriter) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gw.Close()
+}
@@ -23,6 +23,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 
 	v1 "github.com/apache/camel-k/v2/pkg/apis/camel/v1"
@@ -41,15 +42,32 @@ func HandleIntegrationStateChanges(ctx context.Context, c client.Client, integra
 		return nil, err
 	}
 
+	var lastObservedState *v1.IntegrationPhase
+	state := func(it *v1.Integration) string {
+		return string(it.Status.Phase)
+	}
+	HandleStateChanges(ctx, watcher, integration, state, func(it *v1.Integration) bool {
+		lastObservedState = &it.Status.Phase
+		return handler(it)
+	})
+
+	return lastObservedState, nil
+}
+
+// HandleStateChanges consumes the events of the given watcher, and invokes the given handler with the initial object,
+// then every time the state of the watched object, as returned by the given state function, changes.
+// This function blocks until the handler function returns false or either the events channel or the context is closed,
+// and stops the watcher.
+func HandleStateChanges[T runtime.Object](ctx context.Context, watcher watch.Interface, initial T,
+	state func(obj T) string, handler func(obj T) bool) {
 	defer watcher.Stop()
 	events := watcher.ResultChan()
 
-	var lastObservedState *v1.IntegrationPhase
-
-	handlerWrapper := func(it *v1.Integration) bool {
-		if lastObservedState == nil || *lastObservedState != it.Status.Phase {
-			lastObservedState = &it.Status.Phase
-			if !handler(it) {
+	var lastObservedState *string
+	handlerWrapper := func(obj T) bool {
+		if s := state(obj); lastObservedState == nil || *lastObservedState != s {
+			lastObservedState = &s
+			if !handler(obj) {
 				return false
 			}
 		}
@@ -57,23 +75,51 @@ func HandleIntegrationStateChanges(ctx context.Context, c client.Client, integra
 	}
 
 	// Check completion before starting the watch
-	if !handlerWrapper(integration) {
-		return lastObservedState, nil
+	if !handlerWrapper(initial) {
+		return
 	}
 
 	for {
 		select {
 		case <-ctx.Done():
-			return lastObservedState, nil
+			return
 		case e, ok := <-events:
 			if !ok {
-				return lastObservedState, nil
+				return
 			}
-			if e.Object != nil {
-				if it, ok := e.Object.(*v1.Integration); ok {
-					if !handlerWrapper(it) {
-						return lastObservedState, nil
-					}
+			if obj, ok := e.Object.(T); ok {
+				if !handlerWrapper(obj) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// HandleIntegrationListChanges watches the Integration resources matching the given list options and invokes the
+// given handler for every change. This function blocks until the handler function returns false or either the
+// events channel or the context is closed.
+func HandleIntegrationListChanges(ctx context.Context, c client.Client, namespace string, options metav1.ListOptions,
+	handler func(eventType watch.EventType, integration *v1.Integration) bool) error {
+	watcher, err := c.CamelV1().Integrations(namespace).Watch(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	defer watcher.Stop()
+	events := watcher.ResultChan()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if it, ok := e.Object.(*v1.Integration); ok {
+				if !handler(e.Type, it) {
+					return nil
 				}
 			}
 		}
@@ -133,43 +179,12 @@ func HandlePlatformStateChanges(ctx context.Context, c client.Client, platform *
 		return err
 	}
 
-	defer watcher.Stop()
-	events := watcher.ResultChan()
-
-	var lastObservedState *v1.IntegrationPlatformPhase
-
-	handlerWrapper := func(pl *v1.IntegrationPlatform) bool {
-		if lastObservedState == nil || *lastObservedState != pl.Status.Phase {
-			lastObservedState = &pl.Status.Phase
-			if !handler(pl) {
-				return false
-			}
-		}
-		return true
-	}
-
-	// Check completion before starting the watch
-	if !handlerWrapper(platform) {
-		return nil
+	state := func(pl *v1.IntegrationPlatform) string {
+		return string(pl.Status.Phase)
 	}
+	HandleStateChanges(ctx, watcher, platform, state, handler)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case e, ok := <-events:
-			if !ok {
-				return nil
-			}
-			if e.Object != nil {
-				if p, ok := e.Object.(*v1.IntegrationPlatform); ok {
-					if !handlerWrapper(p) {
-						return nil
-					}
-				}
-			}
-		}
-	}
+	return nil
 }
 
 // HandleIntegrationPlatformEvents watches all events related to the given integration platform.